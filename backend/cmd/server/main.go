@@ -40,11 +40,16 @@ func main() {
 		utils.LoggerInstance.Fatalf("ENTRY POINT: failed to load configuration: %v", err)
 	}
 	utils.LoggerInstance.Infof(
-		"ENTRY POINT: loaded configuration: Server - %+v, DB - %+v, MigrationsConfig - %+v, API Key - %+v",
+		"ENTRY POINT: loaded configuration: Server - %+v, DB - %+v, MigrationsConfig - %+v, API Key - %+v, Retention - %+v, Alert rules - %d, Notifiers - %d, Agents - %+v, Queue - %+v",
 		cfg.Server,
 		cfg.DB,
 		cfg.MigrationsConfig,
 		cfg.AuthAPI,
+		cfg.Retention,
+		len(cfg.Alerts.Rules),
+		len(cfg.Notifications.Webhooks)+len(cfg.Notifications.Slack)+len(cfg.Notifications.SMTP),
+		cfg.Agents,
+		cfg.Queue,
 	)
 
 	utils.LoggerInstance.Infof(
@@ -19,6 +19,265 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/agents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the registered pingers. An agent that missed the configured number of heartbeats in a row is stale.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Retrieve agents",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AgentResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/agents/register": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers a pinger, replacing a previous registration with the same ID. The response tells the agent how often to send heartbeats.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Register an agent",
+                "parameters": [
+                    {
+                        "description": "Agent data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterAgentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterAgentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/agents/{agent_id}/heartbeat": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores the current state of a registered pinger. An unknown agent gets 404 and is expected to register again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agents"
+                ],
+                "summary": "Record an agent heartbeat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Agent ID",
+                        "name": "agent_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Agent state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AgentHeartbeatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns firing and resolved alerts produced by the configured rules, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Retrieve alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by container ID",
+                        "name": "container_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by rule name",
+                        "name": "rule",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "firing",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Filter by alert state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of returned alerts (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AlertResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the alert rules loaded from the alerts section of the config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Retrieve alert rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AlertRuleResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/container_status": {
             "get": {
                 "security": [
@@ -46,22 +305,34 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by IP",
+                        "description": "Filter by the host the pinger runs on",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by IP address or CIDR network, prefix with ! to negate",
                         "name": "ip",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by name",
+                        "description": "Filter by name, * and ? are glob wildcards, prefix with ! to negate",
                         "name": "name",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by status",
+                        "description": "Filter by comma separated statuses, prefix with ! to negate",
                         "name": "status",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated health statuses (none, starting, healthy, unhealthy), prefix with ! to negate",
+                        "name": "health",
+                        "in": "query"
+                    },
                     {
                         "type": "number",
                         "description": "Filter by minimum ping time",
@@ -74,6 +345,18 @@ const docTemplate = `{
                         "name": "ping_time_max",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter by last successful ping (greater than or equal to), format: RFC3339",
+                        "name": "last_successful_ping_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last successful ping (less than or equal to), format: RFC3339",
+                        "name": "last_successful_ping_lte",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by creation date (greater than or equal to), format: RFC3339",
@@ -100,9 +383,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Limit the number of returned records",
+                        "description": "Limit the number of returned records, enables the next page cursor",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by a column, format: field:asc|desc, default: container_id:asc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue after the page that returned this X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -113,6 +408,22 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/dto.GetContainerStatusResponse"
                             }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Number of containers matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -173,14 +484,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/container_status/{container_id}": {
-            "delete": {
+        "/container_status/batch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a container from the database",
+                "description": "Stores the results of one ping cycle of an agent in a single transaction. Every status is created or updated, and the statuses of the host whose containers are neither in statuses nor in live_container_ids are deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -190,7 +501,271 @@ const docTemplate = `{
                 "tags": [
                     "Containers"
                 ],
-                "summary": "Delete container by container ID",
+                "summary": "Apply a batch of container statuses",
+                "parameters": [
+                    {
+                        "description": "Ping cycle results",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerStatusBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerStatusBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/container_status/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams created, updated and deleted container statuses as Server-Sent Events. The event name is the change type, the data is a JSON encoded dto.ContainerStatusChangeResponse. Comment lines are sent periodically to keep the connection alive.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Stream container status changes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerStatusChangeResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/container_status/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrades the connection to a WebSocket and sends every created, updated and deleted container status matching the subscription filter as a dto.ContainerStatusChangeResponse. The initial filter is taken from the query params, which are the same as for GET /container_status (limit is ignored). A client changes the filter by sending a dto.ContainerStatusSubscriptionRequest and receives a dto.ContainerStatusSubscriptionResponse in reply.",
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Subscribe to container status changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by container ID",
+                        "name": "container_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by the host the pinger runs on",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by IP address or CIDR network, prefix with ! to negate",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name, * and ? are glob wildcards, prefix with ! to negate",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated statuses, prefix with ! to negate",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated health statuses (none, starting, healthy, unhealthy), prefix with ! to negate",
+                        "name": "health",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Filter by minimum ping time",
+                        "name": "ping_time_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Filter by maximum ping time",
+                        "name": "ping_time_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last successful ping (greater than or equal to), format: RFC3339",
+                        "name": "last_successful_ping_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last successful ping (less than or equal to), format: RFC3339",
+                        "name": "last_successful_ping_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by creation date (greater than or equal to), format: RFC3339",
+                        "name": "created_at_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by creation date (less than or equal to), format: RFC3339",
+                        "name": "created_at_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last update date (greater than or equal to), format: RFC3339",
+                        "name": "updated_at_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last update date (less than or equal to), format: RFC3339",
+                        "name": "updated_at_lte",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/container_status/{container_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores the full state of a container, creating it when it is unknown. Repeating the request has no further effect, except that last_successful_ping never moves back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Create or replace container by container ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "container_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Container state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpsertContainerStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replaced",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetContainerStatusResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetContainerStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Container belongs to another host",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a container from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Delete container by container ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -217,63 +792,718 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially updates a container by its container ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Update container by container ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "container_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateContainerStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Container belongs to another host",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/container_status/{container_id}/aggregate": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns per-bucket min/avg/max/p95 ping time, sample count and success ratio in ascending time order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Retrieve aggregated ping history of a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "container_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket size, Go duration format (e.g. 5m, 1h)",
+                        "name": "bucket",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the time range (inclusive), format: RFC3339, defaults to 24h before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the time range (inclusive), format: RFC3339, defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ContainerPingAggregateResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/container_status/{container_id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns recorded ping samples of a container in ascending time order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Containers"
+                ],
+                "summary": "Retrieve ping history of a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "container_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the time range (inclusive), format: RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the time range (inclusive), format: RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep only the latest sample per step, Go duration format (e.g. 1m, 1h)",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of returned samples, the most recent ones are kept (default 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ContainerPingSampleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns container state transitions (status changes, reachability changes, removals), newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Retrieve container events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by container ID",
+                        "name": "container_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "status_changed",
+                            "unreachable",
+                            "reachable",
+                            "removed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by event type",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the time range (inclusive), format: RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the time range (inclusive), format: RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of returned events (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ContainerEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AgentHeartbeatRequest": {
+            "type": "object",
+            "properties": {
+                "container_count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "cycle_duration": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "docker_version": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "host_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "overrun_cycles": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "ping_interval": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "version": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.AgentResponse": {
+            "type": "object",
+            "properties": {
+                "container_count": {
+                    "type": "integer"
+                },
+                "cycle_duration": {
+                    "type": "number"
+                },
+                "docker_version": {
+                    "type": "string"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_heartbeat_at": {
+                    "type": "string"
+                },
+                "overrun_cycles": {
+                    "type": "integer"
+                },
+                "ping_interval": {
+                    "type": "number"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AlertResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "fired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AlertRuleResponse": {
+            "type": "object",
+            "properties": {
+                "consecutive": {
+                    "type": "integer"
+                },
+                "for": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerEventResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "current_status": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_successful_ping": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "previous_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerHealthRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "failing_streak": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "last_output": {
+                    "type": "string",
+                    "maxLength": 4096
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "starting",
+                        "healthy",
+                        "unhealthy"
+                    ]
+                }
+            }
+        },
+        "dto.ContainerHealthResponse": {
+            "type": "object",
+            "properties": {
+                "failing_streak": {
+                    "type": "integer"
+                },
+                "last_output": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerNetworkRequest": {
+            "type": "object",
+            "required": [
+                "network_name"
+            ],
+            "properties": {
+                "gateway": {
+                    "type": "string"
+                },
+                "ipv4_address": {
+                    "type": "string"
+                },
+                "ipv4_ping_time": {
+                    "type": "number"
+                },
+                "ipv6_address": {
+                    "type": "string"
+                },
+                "ipv6_ping_time": {
+                    "type": "number"
+                },
+                "mac_address": {
+                    "type": "string"
+                },
+                "network_name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.ContainerNetworkResponse": {
+            "type": "object",
+            "properties": {
+                "gateway": {
+                    "type": "string"
+                },
+                "ipv4_address": {
+                    "type": "string"
+                },
+                "ipv4_ping_time": {
+                    "type": "number"
+                },
+                "ipv6_address": {
+                    "type": "string"
+                },
+                "ipv6_ping_time": {
+                    "type": "number"
+                },
+                "mac_address": {
+                    "type": "string"
+                },
+                "network_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerPingAggregateResponse": {
+            "type": "object",
+            "properties": {
+                "avg_ping_time": {
+                    "type": "number"
+                },
+                "bucket_start": {
+                    "type": "string"
+                },
+                "max_ping_time": {
+                    "type": "number"
+                },
+                "min_ping_time": {
+                    "type": "number"
+                },
+                "p95_ping_time": {
+                    "type": "number"
+                },
+                "sample_count": {
+                    "type": "integer"
+                },
+                "success_ratio": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ContainerPingSampleResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "ping_time": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ContainerStatusBatchItem": {
+            "type": "object",
+            "required": [
+                "container_id",
+                "status"
+            ],
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "health": {
+                    "$ref": "#/definitions/dto.ContainerHealthRequest"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_successful_ping": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerNetworkRequest"
                     }
-                ],
-                "description": "Partially updates a container by its container ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Containers"
-                ],
-                "summary": "Update container by container ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Container ID",
-                        "name": "container_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Fields to update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateContainerStatusRequest"
-                        }
+                },
+                "ping_stats": {
+                    "$ref": "#/definitions/dto.PingStatsRequest"
+                },
+                "ping_time": {
+                    "type": "number"
+                },
+                "probe_type": {
+                    "type": "string",
+                    "enum": [
+                        "icmp",
+                        "tcp",
+                        "http",
+                        "https",
+                        "dns"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "restarting",
+                        "running",
+                        "removing",
+                        "paused",
+                        "exited",
+                        "dead"
+                    ]
+                }
+            }
+        },
+        "dto.ContainerStatusBatchRequest": {
+            "type": "object",
+            "required": [
+                "live_container_ids"
+            ],
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "live_container_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
+                },
+                "replayed": {
+                    "type": "boolean"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerStatusBatchItem"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "dto.ContainerStatusBatchResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ContainerStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/dto.GetContainerStatusResponse"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.CreateContainerStatusRequest": {
             "type": "object",
             "required": [
@@ -286,6 +1516,10 @@ const docTemplate = `{
                 "container_id": {
                     "type": "string"
                 },
+                "host_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "ip_address": {
                     "type": "string"
                 },
@@ -298,6 +1532,16 @@ const docTemplate = `{
                 "ping_time": {
                     "type": "number"
                 },
+                "probe_type": {
+                    "type": "string",
+                    "enum": [
+                        "icmp",
+                        "tcp",
+                        "http",
+                        "https",
+                        "dns"
+                    ]
+                },
                 "status": {
                     "type": "string",
                     "enum": [
@@ -321,6 +1565,12 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "health": {
+                    "$ref": "#/definitions/dto.ContainerHealthResponse"
+                },
+                "host_id": {
+                    "type": "string"
+                },
                 "ip_address": {
                     "type": "string"
                 },
@@ -330,9 +1580,21 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerNetworkResponse"
+                    }
+                },
+                "ping_stats": {
+                    "$ref": "#/definitions/dto.PingStatsResponse"
+                },
                 "ping_time": {
                     "type": "number"
                 },
+                "probe_type": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -341,18 +1603,213 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PingStatsRequest": {
+            "type": "object",
+            "properties": {
+                "packet_loss_percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "packets_received": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "packets_sent": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "rtt_avg_ms": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "rtt_max_ms": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "rtt_min_ms": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "rtt_stddev_ms": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.PingStatsResponse": {
+            "type": "object",
+            "properties": {
+                "packet_loss_percent": {
+                    "type": "number"
+                },
+                "packets_received": {
+                    "type": "integer"
+                },
+                "packets_sent": {
+                    "type": "integer"
+                },
+                "rtt_avg_ms": {
+                    "type": "number"
+                },
+                "rtt_max_ms": {
+                    "type": "number"
+                },
+                "rtt_min_ms": {
+                    "type": "number"
+                },
+                "rtt_stddev_ms": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.RegisterAgentRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "container_count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "cycle_duration": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "docker_version": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "host_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "overrun_cycles": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "ping_interval": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "version": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.RegisterAgentResponse": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "$ref": "#/definitions/dto.AgentResponse"
+                },
+                "heartbeat_interval": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UpdateContainerStatusRequest": {
             "type": "object",
             "properties": {
+                "host_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "last_successful_ping": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ping_time": {
+                    "type": "number"
+                },
+                "probe_type": {
+                    "type": "string",
+                    "enum": [
+                        "icmp",
+                        "tcp",
+                        "http",
+                        "https",
+                        "dns"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "restarting",
+                        "running",
+                        "removing",
+                        "paused",
+                        "exited",
+                        "dead"
+                    ]
+                }
+            }
+        },
+        "dto.UpsertContainerStatusRequest": {
+            "type": "object",
+            "required": [
+                "ip_address",
+                "status"
+            ],
+            "properties": {
+                "health": {
+                    "description": "Health replaces the stored health of the container when present.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ContainerHealthRequest"
+                        }
+                    ]
+                },
+                "host_id": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "ip_address": {
+                    "type": "string"
+                },
                 "last_successful_ping": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
+                "networks": {
+                    "description": "Networks replaces the stored networks of the container when present.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ContainerNetworkRequest"
+                    }
+                },
+                "ping_stats": {
+                    "description": "PingStats replaces the stored ping stats of the container when present.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PingStatsRequest"
+                        }
+                    ]
+                },
                 "ping_time": {
                     "type": "number"
                 },
+                "probe_type": {
+                    "type": "string",
+                    "enum": [
+                        "icmp",
+                        "tcp",
+                        "http",
+                        "https",
+                        "dns"
+                    ]
+                },
                 "status": {
                     "type": "string",
                     "enum": [
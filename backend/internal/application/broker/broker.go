@@ -0,0 +1,14 @@
+package broker
+
+import "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+
+type ContainerStatusPublisher interface {
+	Publish(change *dto.ContainerStatusChangeDTO)
+}
+
+// ContainerStatusSubscriber hands out a channel of changes together with a
+// function that ends the subscription. The channel is closed when the
+// subscription ends, also when the subscriber falls too far behind.
+type ContainerStatusSubscriber interface {
+	Subscribe() (<-chan *dto.ContainerStatusChangeDTO, func())
+}
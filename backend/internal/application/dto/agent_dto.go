@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type AgentDTO struct {
+	ID              string
+	HostName        string
+	Version         string
+	DockerVersion   string
+	ContainerCount  int
+	CycleDuration   float64
+	PingInterval    float64
+	OverrunCycles   int
+	State           string
+	RegisteredAt    time.Time
+	LastHeartbeatAt time.Time
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type AlertDTO struct {
+	ID          int64
+	RuleName    string
+	ContainerID string
+	Severity    string
+	State       string
+	Message     string
+	FiredAt     time.Time
+	ResolvedAt  *time.Time
+}
+
+type AlertFilter struct {
+	ContainerID *string
+	RuleName    *string
+	State       *string
+	Limit       *int
+}
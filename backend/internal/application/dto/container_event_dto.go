@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type ContainerEventDTO struct {
+	ID                 int64
+	ContainerID        string
+	Name               string
+	EventType          string
+	PreviousStatus     string
+	CurrentStatus      string
+	LastSuccessfulPing *time.Time
+	OccurredAt         time.Time
+}
+
+type ContainerEventFilter struct {
+	ContainerID *string
+	EventType   *string
+	From        *time.Time
+	To          *time.Time
+	Limit       *int
+}
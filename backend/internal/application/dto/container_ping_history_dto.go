@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type ContainerPingSampleDTO struct {
+	ContainerID string
+	IPAddress   string
+	Status      string
+	PingTime    *float64
+	Success     bool
+	RecordedAt  time.Time
+}
+
+type ContainerPingHistoryFilter struct {
+	ContainerID string
+	From        *time.Time
+	To          *time.Time
+	Step        *time.Duration
+	Limit       *int
+}
+
+type ContainerPingAggregateDTO struct {
+	BucketStart  time.Time
+	MinPingTime  *float64
+	AvgPingTime  *float64
+	MaxPingTime  *float64
+	P95PingTime  *float64
+	SampleCount  int64
+	SuccessRatio float64
+}
+
+type ContainerPingAggregateFilter struct {
+	ContainerID string
+	Bucket      time.Duration
+	From        time.Time
+	To          time.Time
+}
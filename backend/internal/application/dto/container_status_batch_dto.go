@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// ContainerStatusBatchDTO is the outcome of one ping cycle of an agent.
+// LiveContainerIDs lists every container present on the host; together with
+// the IDs of Statuses it is authoritative, so the other statuses of the host
+// are deleted. CheckedAt is when the cycle ran, zero means now. A Replayed
+// batch was delivered late, its live containers are outdated and nothing is
+// deleted for it.
+type ContainerStatusBatchDTO struct {
+	HostID           string
+	CheckedAt        time.Time
+	Replayed         bool
+	Statuses         []*ContainerStatusDTO
+	LiveContainerIDs []string
+}
+
+// ContainerStatusBatchResultDTO counts what a batch changed. Skipped statuses
+// are new containers without an IP address, which cannot be stored.
+type ContainerStatusBatchResultDTO struct {
+	Created int
+	Updated int
+	Deleted int
+	Skipped int
+}
@@ -0,0 +1,16 @@
+package dto
+
+const (
+	ChangeTypeCreated = "created"
+	ChangeTypeUpdated = "updated"
+	ChangeTypeDeleted = "deleted"
+)
+
+// ContainerStatusChangeDTO is published after a status has been stored or
+// deleted. Status holds the stored state; for deletions it is the last state
+// before removal.
+type ContainerStatusChangeDTO struct {
+	Type        string
+	ContainerID string
+	Status      *ContainerStatusDTO
+}
@@ -1,28 +1,117 @@
 package dto
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
 
 type ContainerStatusDTO struct {
 	ContainerID        string
+	HostID             string
 	Name               string
 	IPAddress          string
 	Status             string
 	PingTime           float64
 	LastSuccessfulPing time.Time
+	ProbeType          string
 	UpdatedAt          time.Time
 	CreatedAt          time.Time
+	// Health is nil when the health was not reported, the stored one is
+	// kept then.
+	Health *ContainerHealthDTO
+	// PingStats is nil when no stats were reported, the stored ones are
+	// kept then.
+	PingStats *PingStatsDTO
+	// Networks is nil when the networks were not reported, the stored ones
+	// are kept then.
+	Networks []ContainerNetworkDTO
+}
+
+type ContainerNetworkDTO struct {
+	NetworkName  string
+	MACAddress   string
+	Gateway      string
+	IPv4Address  string
+	IPv4PingTime *float64
+	IPv6Address  string
+	IPv6PingTime *float64
+}
+
+type ContainerHealthDTO struct {
+	Status        string
+	FailingStreak int
+	LastOutput    string
+}
+
+type PingStatsDTO struct {
+	PacketsSent       int
+	PacketsReceived   int
+	PacketLossPercent float64
+	MinRTT            float64
+	AvgRTT            float64
+	MaxRTT            float64
+	StdDevRTT         float64
 }
 
 type ContainerStatusFilter struct {
-	ContainerID  *string
-	IPAddress    *string
-	Name         *string
-	Status       *string
-	PingTimeMin  *float64
-	PingTimeMax  *float64
-	CreatedAtGte *time.Time
-	CreatedAtLte *time.Time
-	UpdatedAtGte *time.Time
-	UpdatedAtLte *time.Time
-	Limit        *int
+	ContainerID           *string
+	ContainerIDs          []string
+	HostID                *string
+	IPAddress             *PrefixMatch
+	Name                  *PatternMatch
+	Status                *SetMatch
+	Health                *SetMatch
+	PingTimeMin           *float64
+	PingTimeMax           *float64
+	LastSuccessfulPingGte *time.Time
+	LastSuccessfulPingLte *time.Time
+	CreatedAtGte          *time.Time
+	CreatedAtLte          *time.Time
+	UpdatedAtGte          *time.Time
+	UpdatedAtLte          *time.Time
+	Limit                 *int
+	Sort                  *ContainerStatusSort
+	After                 *ContainerStatusCursor
+}
+
+// Matches reports whether status satisfies the filter, with the same semantics
+// as the repository query. Limit, Sort and After only apply to queries and are
+// ignored here.
+func (f *ContainerStatusFilter) Matches(status *ContainerStatusDTO) bool {
+	if status == nil {
+		return false
+	}
+
+	switch {
+	case f.ContainerID != nil && *f.ContainerID != status.ContainerID,
+		f.ContainerIDs != nil && !slices.Contains(f.ContainerIDs, status.ContainerID),
+		f.HostID != nil && *f.HostID != status.HostID,
+		f.IPAddress != nil && !f.IPAddress.Matches(status.IPAddress),
+		f.Name != nil && !f.Name.Matches(status.Name),
+		f.Status != nil && !f.Status.Matches(status.Status),
+		f.Health != nil && !f.Health.Matches(healthStatus(status.Health)),
+		f.PingTimeMin != nil && status.PingTime < *f.PingTimeMin,
+		f.PingTimeMax != nil && status.PingTime > *f.PingTimeMax,
+		f.LastSuccessfulPingGte != nil && status.LastSuccessfulPing.Before(*f.LastSuccessfulPingGte),
+		f.LastSuccessfulPingLte != nil && status.LastSuccessfulPing.After(*f.LastSuccessfulPingLte),
+		f.CreatedAtGte != nil && status.CreatedAt.Before(*f.CreatedAtGte),
+		f.CreatedAtLte != nil && status.CreatedAt.After(*f.CreatedAtLte),
+		f.UpdatedAtGte != nil && status.UpdatedAt.Before(*f.UpdatedAtGte),
+		f.UpdatedAtLte != nil && status.UpdatedAt.After(*f.UpdatedAtLte):
+		return false
+	}
+
+	return true
+}
+
+// healthStatus is the stored health of a status, which is none unless a
+// health was reported.
+func healthStatus(health *ContainerHealthDTO) string {
+	if health == nil {
+		return domain.HealthStatusNone
+	}
+
+	return health.Status
 }
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// PatternMatch matches text against a glob pattern, where * stands for any
+// run of characters and ? for a single character. A pattern without
+// wildcards is an exact match.
+type PatternMatch struct {
+	Pattern string
+	Negate  bool
+}
+
+func (m *PatternMatch) Matches(value string) bool {
+	return matchGlob([]rune(m.Pattern), []rune(value)) != m.Negate
+}
+
+func (m *PatternMatch) String() string {
+	return negationPrefix(m.Negate) + m.Pattern
+}
+
+// SetMatch matches text equal to any of the values.
+type SetMatch struct {
+	Values []string
+	Negate bool
+}
+
+func (m *SetMatch) Matches(value string) bool {
+	for _, candidate := range m.Values {
+		if candidate == value {
+			return !m.Negate
+		}
+	}
+
+	return m.Negate
+}
+
+func (m *SetMatch) String() string {
+	return negationPrefix(m.Negate) + strings.Join(m.Values, ",")
+}
+
+// PrefixMatch matches IP addresses inside a network. A single address is
+// matched as a network of one.
+type PrefixMatch struct {
+	Prefix netip.Prefix
+	Negate bool
+}
+
+func (m *PrefixMatch) Matches(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return m.Negate
+	}
+
+	return m.Prefix.Contains(addr.Unmap()) != m.Negate
+}
+
+func (m *PrefixMatch) String() string {
+	if m.Prefix.IsSingleIP() {
+		return negationPrefix(m.Negate) + m.Prefix.Addr().String()
+	}
+
+	return negationPrefix(m.Negate) + m.Prefix.String()
+}
+
+func negationPrefix(negate bool) string {
+	if negate {
+		return "!"
+	}
+
+	return ""
+}
+
+// matchGlob reports whether value matches pattern. On a mismatch after a *
+// it retries with the * consuming one more character.
+func matchGlob(pattern, value []rune) bool {
+	p, v := 0, 0
+	star, retry := -1, 0
+
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, retry = p, v
+			p++
+		case star >= 0:
+			p = star + 1
+			retry++
+			v = retry
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
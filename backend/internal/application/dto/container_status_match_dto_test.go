@@ -0,0 +1,64 @@
+package dto_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+)
+
+func TestPatternMatch_Matches(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"web", "web", true},
+		{"web", "web-1", false},
+		{"web*", "web-1", true},
+		{"*db*", "orders-db-replica", true},
+		{"*db", "orders-db-replica", false},
+		{"web-?", "web-1", true},
+		{"web-?", "web-10", false},
+		{"a*b*c", "axxbyybzc", true},
+		{"a*b*c", "axxbyy", false},
+		{"*", "", true},
+	} {
+		match := &dto.PatternMatch{Pattern: tc.pattern}
+		assert.Equal(t, tc.want, match.Matches(tc.value), "%q against %q", tc.pattern, tc.value)
+
+		match.Negate = true
+		assert.Equal(t, !tc.want, match.Matches(tc.value), "!%q against %q", tc.pattern, tc.value)
+	}
+}
+
+func TestSetMatch_Matches(t *testing.T) {
+	match := &dto.SetMatch{Values: []string{"running", "paused"}}
+
+	assert.True(t, match.Matches("paused"))
+	assert.False(t, match.Matches("exited"))
+	assert.Equal(t, "running,paused", match.String())
+
+	match.Negate = true
+
+	assert.False(t, match.Matches("paused"))
+	assert.True(t, match.Matches("exited"))
+	assert.Equal(t, "!running,paused", match.String())
+}
+
+func TestPrefixMatch_Matches(t *testing.T) {
+	network := &dto.PrefixMatch{Prefix: netip.MustParsePrefix("172.18.0.0/16")}
+
+	assert.True(t, network.Matches("172.18.4.2"))
+	assert.False(t, network.Matches("10.0.0.1"))
+	assert.False(t, network.Matches("not an ip"))
+	assert.Equal(t, "172.18.0.0/16", network.String())
+
+	single := &dto.PrefixMatch{Prefix: netip.MustParsePrefix("10.0.0.1/32"), Negate: true}
+
+	assert.False(t, single.Matches("10.0.0.1"))
+	assert.True(t, single.Matches("10.0.0.2"))
+	assert.Equal(t, "!10.0.0.1", single.String())
+}
@@ -0,0 +1,44 @@
+package dto
+
+const (
+	SortFieldContainerID        = "container_id"
+	SortFieldHostID             = "host_id"
+	SortFieldIPAddress          = "ip_address"
+	SortFieldName               = "name"
+	SortFieldStatus             = "status"
+	SortFieldPingTime           = "ping_time"
+	SortFieldLastSuccessfulPing = "last_successful_ping"
+	SortFieldCreatedAt          = "created_at"
+	SortFieldUpdatedAt          = "updated_at"
+)
+
+func IsValidSortField(field string) bool {
+	switch field {
+	case SortFieldContainerID, SortFieldHostID, SortFieldIPAddress, SortFieldName, SortFieldStatus,
+		SortFieldPingTime, SortFieldLastSuccessfulPing, SortFieldCreatedAt, SortFieldUpdatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContainerStatusSort orders a listing by a single column. Ties are broken by
+// container_id in the same direction, so the order is always total.
+type ContainerStatusSort struct {
+	Field      string
+	Descending bool
+}
+
+// ContainerStatusCursor points after the last row of a page. It is only valid
+// together with the sort it was created for.
+type ContainerStatusCursor struct {
+	Sort        ContainerStatusSort
+	Value       any
+	ContainerID string
+}
+
+type ContainerStatusPageDTO struct {
+	Items      []*ContainerStatusDTO
+	Total      int
+	NextCursor *ContainerStatusCursor
+}
@@ -0,0 +1,7 @@
+package dto
+
+type RetentionReport struct {
+	RolledUpBuckets  int64
+	PurgedRawSamples int64
+	PurgedRollups    int64
+}
@@ -0,0 +1,15 @@
+package notifiers
+
+import (
+	"context"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+// Notifier delivers a notification over a single channel. Implementations
+// make one delivery attempt per call; retries are up to the caller.
+type Notifier interface {
+	Name() string
+	Accepts(notificationType string) bool
+	Notify(ctx context.Context, notification *domain.Notification) error
+}
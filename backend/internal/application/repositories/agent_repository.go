@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+
+type AgentRepository interface {
+	// Register stores the agent, replacing a previous registration with the
+	// same ID.
+	Register(agent *domain.Agent) error
+	// Heartbeat updates a registered agent. It reports false when no agent
+	// with the ID is registered, in which case nothing is stored.
+	Heartbeat(agent *domain.Agent) (bool, error)
+	Find() ([]*domain.Agent, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+type AlertRepository interface {
+	FindFiring(containerID string) ([]*domain.Alert, error)
+	// Fire opens a new alert. It reports false when the same rule is already
+	// firing for the container, in which case nothing is stored.
+	Fire(alert *domain.Alert) (bool, error)
+	// Resolve resolves a firing alert. It reports false when the alert is no
+	// longer firing, e.g. it was resolved concurrently, in which case nothing
+	// is changed.
+	Resolve(alert *domain.Alert, resolvedAt time.Time) (bool, error)
+	Find(filter *dto.AlertFilter) ([]*domain.Alert, error)
+}
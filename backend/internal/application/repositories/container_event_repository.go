@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+type ContainerEventRepository interface {
+	Create(event *domain.ContainerEvent) error
+	Find(filter *dto.ContainerEventFilter) ([]*domain.ContainerEvent, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+type ContainerPingHistoryRepository interface {
+	Create(sample *domain.ContainerPingSample) error
+	Find(filter *dto.ContainerPingHistoryFilter) ([]*domain.ContainerPingSample, error)
+	Aggregate(filter *dto.ContainerPingAggregateFilter) ([]*domain.ContainerPingAggregate, error)
+}
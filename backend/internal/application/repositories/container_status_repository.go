@@ -1,13 +1,29 @@
 package repositories
 
 import (
+	"errors"
+
 	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
 )
 
+// ErrHostMismatch is returned for a write of a status stored for another
+// host. A status without host is taken over by the first host writing it.
+var ErrHostMismatch = errors.New("container status belongs to another host")
+
 type ContainerStatusRepository interface {
 	Find(filter *dto.ContainerStatusFilter) ([]*domain.ContainerStatus, error)
+	Count(filter *dto.ContainerStatusFilter) (int, error)
 	Create(status *domain.ContainerStatus) error
 	Update(status *domain.ContainerStatus) error
+	// Upsert creates the status or overwrites the stored one in a single
+	// statement. It reports whether the status was created, and fails with
+	// ErrHostMismatch when the stored one is of another host. An empty host
+	// keeps the stored one.
+	Upsert(status *domain.ContainerStatus) (bool, error)
 	DeleteByContainerID(containerID string) error
+	// ApplyBatch upserts statuses and deletes the statuses of deleteIDs in a
+	// single transaction. Statuses stored for another host are left as they
+	// are, their container IDs are returned.
+	ApplyBatch(statuses []*domain.ContainerStatus, deleteIDs []string) ([]string, error)
 }
@@ -0,0 +1,7 @@
+package repositories
+
+import "github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+
+type NotificationDeliveryRepository interface {
+	Create(delivery *domain.NotificationDelivery) error
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "time"
+
+type PingRetentionRepository interface {
+	RollupHourly(until time.Time) (int64, error)
+	DeleteRawBefore(before time.Time) (int64, error)
+	DeleteRollupsBefore(before time.Time) (int64, error)
+}
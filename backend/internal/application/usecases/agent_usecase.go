@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// ErrAgentNotFound is returned for a heartbeat of an agent that is not
+// registered, e.g. after the agents table was cleared. The agent is expected
+// to register again.
+var ErrAgentNotFound = errors.New("agent not found")
+
+type AgentUseCaseInterface interface {
+	RegisterAgent(agentDTO *dto.AgentDTO) (*dto.AgentDTO, error)
+	RecordHeartbeat(agentDTO *dto.AgentDTO) error
+	FindAgents() ([]*dto.AgentDTO, error)
+	HeartbeatInterval() time.Duration
+}
+
+// AgentUseCase keeps track of the pingers. An agent is stale once it has
+// missed missedHeartbeats heartbeats in a row; its state is derived from the
+// time of the last heartbeat whenever agents are read.
+type AgentUseCase struct {
+	repo              repositories.AgentRepository
+	heartbeatInterval time.Duration
+	missedHeartbeats  int
+	logger            utils.LoggerInterface
+}
+
+func NewAgentUseCase(
+	repo repositories.AgentRepository,
+	heartbeatInterval time.Duration,
+	missedHeartbeats int,
+	logger utils.LoggerInterface,
+) *AgentUseCase {
+	return &AgentUseCase{
+		repo:              repo,
+		heartbeatInterval: heartbeatInterval,
+		missedHeartbeats:  missedHeartbeats,
+		logger:            logger,
+	}
+}
+
+func (uc *AgentUseCase) HeartbeatInterval() time.Duration {
+	return uc.heartbeatInterval
+}
+
+func (uc *AgentUseCase) RegisterAgent(agentDTO *dto.AgentDTO) (*dto.AgentDTO, error) {
+	uc.logger.Debugf("USECASES: registering agent: %+v", agentDTO)
+
+	now := time.Now()
+	agent := mapAgentDTOToDomain(agentDTO)
+	agent.RegisteredAt = now
+	agent.LastHeartbeatAt = now
+
+	if err := uc.repo.Register(agent); err != nil {
+		uc.logger.Errorf("USECASES: failed to register agent %s: %v", agent.ID, err)
+		return nil, fmt.Errorf("failed to register agent: %w", err)
+	}
+
+	uc.logger.Infof("USECASES: agent %s registered from host %s, version %s", agent.ID, agent.HostName, agent.Version)
+
+	return uc.mapAgentToDTO(agent, now), nil
+}
+
+func (uc *AgentUseCase) RecordHeartbeat(agentDTO *dto.AgentDTO) error {
+	uc.logger.Debugf("USECASES: recording heartbeat: %+v", agentDTO)
+
+	agent := mapAgentDTOToDomain(agentDTO)
+	agent.LastHeartbeatAt = time.Now()
+
+	found, err := uc.repo.Heartbeat(agent)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to record heartbeat of agent %s: %v", agent.ID, err)
+		return fmt.Errorf("failed to record agent heartbeat: %w", err)
+	}
+
+	if !found {
+		uc.logger.Warnf("USECASES: heartbeat of unregistered agent %s", agent.ID)
+		return fmt.Errorf("agent %s: %w", agent.ID, ErrAgentNotFound)
+	}
+
+	return nil
+}
+
+func (uc *AgentUseCase) FindAgents() ([]*dto.AgentDTO, error) {
+	uc.logger.Debugf("USECASES: finding agents")
+
+	agents, err := uc.repo.Find()
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch agents: %v", err)
+		return nil, fmt.Errorf("failed to fetch agents: %w", err)
+	}
+
+	now := time.Now()
+	var dtos = make([]*dto.AgentDTO, 0, len(agents))
+	for _, agent := range agents {
+		dtos = append(dtos, uc.mapAgentToDTO(agent, now))
+	}
+
+	uc.logger.Debugf("USECASES: found %d agents", len(dtos))
+
+	return dtos, nil
+}
+
+func (uc *AgentUseCase) mapAgentToDTO(agent *domain.Agent, now time.Time) *dto.AgentDTO {
+	state := domain.AgentStateOnline
+	if now.Sub(agent.LastHeartbeatAt) > uc.heartbeatInterval*time.Duration(uc.missedHeartbeats) {
+		state = domain.AgentStateStale
+	}
+
+	return &dto.AgentDTO{
+		ID:              agent.ID,
+		HostName:        agent.HostName,
+		Version:         agent.Version,
+		DockerVersion:   agent.DockerVersion,
+		ContainerCount:  agent.ContainerCount,
+		CycleDuration:   agent.CycleDuration,
+		PingInterval:    agent.PingInterval,
+		OverrunCycles:   agent.OverrunCycles,
+		State:           state,
+		RegisteredAt:    agent.RegisteredAt,
+		LastHeartbeatAt: agent.LastHeartbeatAt,
+	}
+}
+
+func mapAgentDTOToDomain(agentDTO *dto.AgentDTO) *domain.Agent {
+	return &domain.Agent{
+		ID:             agentDTO.ID,
+		HostName:       agentDTO.HostName,
+		Version:        agentDTO.Version,
+		DockerVersion:  agentDTO.DockerVersion,
+		ContainerCount: agentDTO.ContainerCount,
+		CycleDuration:  agentDTO.CycleDuration,
+		PingInterval:   agentDTO.PingInterval,
+		OverrunCycles:  agentDTO.OverrunCycles,
+	}
+}
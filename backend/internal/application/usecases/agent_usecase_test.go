@@ -0,0 +1,107 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+const (
+	testAgentID           = "node-1"
+	testHeartbeatInterval = 30 * time.Second
+	testMissedHeartbeats  = 3
+)
+
+func TestRegisterAgent_Success(t *testing.T) {
+	mockRepo := new(mocks.AgentRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAgentUseCase(mockRepo, testHeartbeatInterval, testMissedHeartbeats, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Register", mock.MatchedBy(func(agent *domain.Agent) bool {
+		return agent.ID == testAgentID && agent.Version == "1.2.0" &&
+			!agent.RegisteredAt.IsZero() && agent.LastHeartbeatAt.Equal(agent.RegisteredAt)
+	})).Return(nil)
+
+	result, err := useCase.RegisterAgent(&dto.AgentDTO{ID: testAgentID, HostName: "docker-1", Version: "1.2.0"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testAgentID, result.ID)
+	assert.Equal(t, domain.AgentStateOnline, result.State)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestRecordHeartbeat_UnknownAgent_ReturnsNotFound(t *testing.T) {
+	mockRepo := new(mocks.AgentRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAgentUseCase(mockRepo, testHeartbeatInterval, testMissedHeartbeats, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", "USECASES: heartbeat of unregistered agent %s", testAgentID).Return()
+	mockRepo.On("Heartbeat", mock.MatchedBy(func(agent *domain.Agent) bool {
+		return agent.ID == testAgentID && agent.ContainerCount == 12 && !agent.LastHeartbeatAt.IsZero()
+	})).Return(false, nil)
+
+	err := useCase.RecordHeartbeat(&dto.AgentDTO{ID: testAgentID, ContainerCount: 12})
+
+	assert.ErrorIs(t, err, usecases.ErrAgentNotFound)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestRecordHeartbeat_Error(t *testing.T) {
+	mockRepo := new(mocks.AgentRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAgentUseCase(mockRepo, testHeartbeatInterval, testMissedHeartbeats, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, testAgentID, mock.Anything).Return()
+	mockRepo.On("Heartbeat", mock.Anything).Return(false, fmt.Errorf("db error"))
+
+	err := useCase.RecordHeartbeat(&dto.AgentDTO{ID: testAgentID})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, usecases.ErrAgentNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindAgents_MarksStaleAgents(t *testing.T) {
+	mockRepo := new(mocks.AgentRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAgentUseCase(mockRepo, testHeartbeatInterval, testMissedHeartbeats, mockLogger)
+
+	agents := []*domain.Agent{
+		{ID: "node-1", LastHeartbeatAt: time.Now().Add(-testHeartbeatInterval)},
+		{ID: "node-2", LastHeartbeatAt: time.Now().Add(-testMissedHeartbeats*testHeartbeatInterval - time.Second)},
+	}
+
+	mockLogger.On("Debugf", mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find").Return(agents, nil)
+
+	result, err := useCase.FindAgents()
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, domain.AgentStateOnline, result[0].State)
+	assert.Equal(t, domain.AgentStateStale, result[1].State)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,343 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// AlertEvaluator is used by ContainerStatusUseCase to react to stored status
+// updates. Failures are logged by the evaluator and never fail the update.
+type AlertEvaluator interface {
+	Evaluate(status *domain.ContainerStatus, now time.Time)
+	ResolveContainer(containerID string, now time.Time)
+}
+
+type AlertUseCaseInterface interface {
+	FindAlerts(filter *dto.AlertFilter) ([]*dto.AlertDTO, error)
+	Rules() []domain.AlertRule
+	CheckNoSuccessfulPing(now time.Time) error
+}
+
+const defaultAlertsLimit = 100
+
+type AlertUseCase struct {
+	rules         []domain.AlertRule
+	repo          repositories.AlertRepository
+	historyRepo   repositories.ContainerPingHistoryRepository
+	statusRepo    repositories.ContainerStatusRepository
+	notifications NotificationDispatcher
+	logger        utils.LoggerInterface
+}
+
+func NewAlertUseCase(
+	rules []domain.AlertRule,
+	repo repositories.AlertRepository,
+	historyRepo repositories.ContainerPingHistoryRepository,
+	statusRepo repositories.ContainerStatusRepository,
+	notifications NotificationDispatcher,
+	logger utils.LoggerInterface,
+) *AlertUseCase {
+	return &AlertUseCase{
+		rules:         rules,
+		repo:          repo,
+		historyRepo:   historyRepo,
+		statusRepo:    statusRepo,
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+func (uc *AlertUseCase) Rules() []domain.AlertRule {
+	return uc.rules
+}
+
+// Evaluate checks every rule against the stored status. A breached rule opens
+// an alert unless one is already firing; a firing alert whose rule holds
+// again is resolved.
+func (uc *AlertUseCase) Evaluate(status *domain.ContainerStatus, now time.Time) {
+	if len(uc.rules) == 0 {
+		return
+	}
+
+	uc.logger.Debugf("USECASES: evaluating %d alert rules for container ID %s", len(uc.rules), status.ContainerID)
+
+	firing, err := uc.firingByRule(status.ContainerID)
+	if err != nil {
+		return
+	}
+
+	samples, err := uc.recentSamples(status.ContainerID)
+	if err != nil {
+		return
+	}
+
+	for _, rule := range uc.rules {
+		message, breached := checkRule(rule, status, samples, now)
+
+		alert, isFiring := firing[rule.Name]
+		switch {
+		case breached && !isFiring:
+			uc.fire(rule, status.ContainerID, message, now)
+		case !breached && isFiring:
+			uc.resolve(alert, now)
+		}
+	}
+}
+
+// ResolveContainer resolves every firing alert of a container, e.g. once the
+// container has been removed.
+func (uc *AlertUseCase) ResolveContainer(containerID string, now time.Time) {
+	firing, err := uc.firingByRule(containerID)
+	if err != nil {
+		return
+	}
+
+	for _, alert := range firing {
+		uc.resolve(alert, now)
+	}
+}
+
+// CheckNoSuccessfulPing checks the no_successful_ping rules against every
+// stored status. Evaluate only runs when a pinger reports, so a container
+// whose pinger stopped reporting is caught here. The alert is resolved by the
+// next report of a successful ping.
+func (uc *AlertUseCase) CheckNoSuccessfulPing(now time.Time) error {
+	var rules []domain.AlertRule
+	for _, rule := range uc.rules {
+		if rule.Metric == domain.AlertMetricNoSuccessfulPing {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	statuses, err := uc.statusRepo.Find(&dto.ContainerStatusFilter{})
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch container statuses for alert rules: %v", err)
+		return fmt.Errorf("failed to fetch container statuses: %w", err)
+	}
+
+	uc.logger.Debugf("USECASES: checking %d no_successful_ping rules for %d containers", len(rules), len(statuses))
+
+	for _, status := range statuses {
+		for _, rule := range rules {
+			if message, breached := checkRule(rule, status, nil, now); breached {
+				uc.fire(rule, status.ContainerID, message, now)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *AlertUseCase) FindAlerts(filter *dto.AlertFilter) ([]*dto.AlertDTO, error) {
+	uc.logger.Debugf("USECASES: finding alerts with filter: %+v", filter)
+
+	if filter.Limit == nil {
+		limit := defaultAlertsLimit
+		filter.Limit = &limit
+	}
+
+	alerts, err := uc.repo.Find(filter)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch alerts: %v", err)
+		return nil, fmt.Errorf("failed to fetch alerts: %w", err)
+	}
+
+	var dtos = make([]*dto.AlertDTO, 0, len(alerts))
+	for _, alert := range alerts {
+		dtos = append(dtos, mapAlertToDTO(alert))
+	}
+
+	uc.logger.Debugf("USECASES: found %d alerts", len(dtos))
+
+	return dtos, nil
+}
+
+func (uc *AlertUseCase) firingByRule(containerID string) (map[string]*domain.Alert, error) {
+	alerts, err := uc.repo.FindFiring(containerID)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch firing alerts for container ID %s: %v", containerID, err)
+		return nil, err
+	}
+
+	firing := make(map[string]*domain.Alert, len(alerts))
+	for _, alert := range alerts {
+		firing[alert.RuleName] = alert
+	}
+
+	return firing, nil
+}
+
+// recentSamples loads as many of the latest samples as the most demanding
+// ping_time rule needs, newest first.
+func (uc *AlertUseCase) recentSamples(containerID string) ([]*domain.ContainerPingSample, error) {
+	limit := 0
+	for _, rule := range uc.rules {
+		if rule.Metric == domain.AlertMetricPingTime && rule.Consecutive > limit {
+			limit = rule.Consecutive
+		}
+	}
+
+	if limit == 0 {
+		return nil, nil
+	}
+
+	samples, err := uc.historyRepo.Find(&dto.ContainerPingHistoryFilter{ContainerID: containerID, Limit: &limit})
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch ping samples for alert rules of container ID %s: %v", containerID, err)
+		return nil, err
+	}
+
+	for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+		samples[i], samples[j] = samples[j], samples[i]
+	}
+
+	return samples, nil
+}
+
+func (uc *AlertUseCase) fire(rule domain.AlertRule, containerID, message string, now time.Time) {
+	alert := &domain.Alert{
+		RuleName:    rule.Name,
+		ContainerID: containerID,
+		Severity:    rule.Severity,
+		Message:     message,
+		FiredAt:     now,
+	}
+
+	fired, err := uc.repo.Fire(alert)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fire alert %s for container ID %s: %v", rule.Name, containerID, err)
+		return
+	}
+
+	if !fired {
+		return
+	}
+
+	uc.logger.Infof("USECASES: alert %s is firing for container ID %s: %s", rule.Name, containerID, message)
+	uc.notifications.Dispatch(newAlertNotification(alert, domain.NotificationTypeAlertFiring, now))
+}
+
+func (uc *AlertUseCase) resolve(alert *domain.Alert, now time.Time) {
+	resolved, err := uc.repo.Resolve(alert, now)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to resolve alert %s for container ID %s: %v", alert.RuleName, alert.ContainerID, err)
+		return
+	}
+
+	// Another update may have resolved it first and notified already.
+	if !resolved {
+		return
+	}
+
+	uc.logger.Infof("USECASES: alert %s resolved for container ID %s", alert.RuleName, alert.ContainerID)
+	uc.notifications.Dispatch(newAlertNotification(alert, domain.NotificationTypeAlertResolved, now))
+}
+
+func newAlertNotification(alert *domain.Alert, notificationType string, now time.Time) *domain.Notification {
+	summary := fmt.Sprintf("alert %s is firing: %s", alert.RuleName, alert.Message)
+	if notificationType == domain.NotificationTypeAlertResolved {
+		summary = fmt.Sprintf("alert %s resolved", alert.RuleName)
+	}
+
+	return &domain.Notification{
+		Type:        notificationType,
+		ContainerID: alert.ContainerID,
+		Severity:    alert.Severity,
+		Summary:     summary,
+		OccurredAt:  now,
+	}
+}
+
+// checkRule reports whether the rule is breached and describes why. samples
+// must be ordered newest first.
+func checkRule(
+	rule domain.AlertRule,
+	status *domain.ContainerStatus,
+	samples []*domain.ContainerPingSample,
+	now time.Time,
+) (string, bool) {
+	switch rule.Metric {
+	case domain.AlertMetricPingTime:
+		if len(samples) < rule.Consecutive {
+			return "", false
+		}
+
+		for _, sample := range samples[:rule.Consecutive] {
+			if sample.PingTime == nil || !compareFloat(*sample.PingTime, rule.Operator, rule.Threshold) {
+				return "", false
+			}
+		}
+
+		return fmt.Sprintf("ping time %s %gms for %d consecutive samples, latest %gms",
+			rule.Operator, rule.Threshold, rule.Consecutive, *samples[0].PingTime), true
+
+	case domain.AlertMetricPacketLoss:
+		// A status without packets sent has no stats reported yet.
+		stats := status.PingStats
+		if stats.PacketsSent == 0 || !compareFloat(stats.PacketLossPercent, rule.Operator, rule.Threshold) {
+			return "", false
+		}
+
+		return fmt.Sprintf("packet loss %s %g%%, latest %g%% of %d packets",
+			rule.Operator, rule.Threshold, stats.PacketLossPercent, stats.PacketsSent), true
+
+	case domain.AlertMetricNoSuccessfulPing:
+		since := status.LastSuccessfulPing
+		if since.IsZero() {
+			since = status.CreatedAt
+		}
+
+		if now.Sub(since) < rule.For {
+			return "", false
+		}
+
+		return fmt.Sprintf("no successful ping since %s", since.UTC().Format(time.RFC3339)), true
+
+	case domain.AlertMetricStatus:
+		if (status.Status == rule.Value) != (rule.Operator == "==") {
+			return "", false
+		}
+
+		return fmt.Sprintf("status is %s", status.Status), true
+
+	default:
+		return "", false
+	}
+}
+
+func compareFloat(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	default:
+		return false
+	}
+}
+
+func mapAlertToDTO(alert *domain.Alert) *dto.AlertDTO {
+	return &dto.AlertDTO{
+		ID:          alert.ID,
+		RuleName:    alert.RuleName,
+		ContainerID: alert.ContainerID,
+		Severity:    alert.Severity,
+		State:       alert.State,
+		Message:     alert.Message,
+		FiredAt:     alert.FiredAt,
+		ResolvedAt:  alert.ResolvedAt,
+	}
+}
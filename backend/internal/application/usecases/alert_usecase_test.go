@@ -0,0 +1,433 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+var (
+	slowPingRule = domain.AlertRule{
+		Name:        "slow_ping",
+		Metric:      domain.AlertMetricPingTime,
+		Operator:    ">",
+		Threshold:   50,
+		Consecutive: 3,
+		Severity:    "warning",
+	}
+	unreachableRule = domain.AlertRule{
+		Name:     "unreachable",
+		Metric:   domain.AlertMetricNoSuccessfulPing,
+		For:      2 * time.Minute,
+		Severity: "critical",
+	}
+	packetLossRule = domain.AlertRule{
+		Name:      "packet_loss",
+		Metric:    domain.AlertMetricPacketLoss,
+		Operator:  ">=",
+		Threshold: 50,
+		Severity:  "warning",
+	}
+	exitedRule = domain.AlertRule{
+		Name:     "exited",
+		Metric:   domain.AlertMetricStatus,
+		Operator: "==",
+		Value:    "exited",
+		Severity: "critical",
+	}
+)
+
+// pingSamples builds history samples in ascending time order, as returned by
+// the history repository; nil stands for a failed ping.
+func pingSamples(pingTimes ...*float64) []*domain.ContainerPingSample {
+	samples := make([]*domain.ContainerPingSample, 0, len(pingTimes))
+	for _, pingTime := range pingTimes {
+		samples = append(samples, &domain.ContainerPingSample{
+			ContainerID: testContainerIDStr,
+			PingTime:    pingTime,
+			Success:     pingTime != nil,
+		})
+	}
+
+	return samples
+}
+
+func ms(value float64) *float64 {
+	return &value
+}
+
+func TestEvaluate_FiresBreachedRules(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{slowPingRule, unreachableRule, exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	now := time.Now()
+	status := &domain.ContainerStatus{
+		ContainerID:        testContainerIDStr,
+		Status:             "exited",
+		LastSuccessfulPing: now.Add(-5 * time.Minute),
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return([]*domain.Alert{}, nil)
+	mockHistoryRepo.On("Find", mock.MatchedBy(func(filter *dto.ContainerPingHistoryFilter) bool {
+		return filter.ContainerID == testContainerIDStr && *filter.Limit == 3
+	})).Return(pingSamples(ms(60), ms(70), ms(80)), nil)
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "slow_ping" && alert.Severity == "warning" && alert.FiredAt.Equal(now)
+	})).Return(true, nil).Once()
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "unreachable" && alert.Severity == "critical"
+	})).Return(true, nil).Once()
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "exited" && alert.Message == "status is exited"
+	})).Return(true, nil).Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.NotificationTypeAlertFiring
+	})).Return().Times(3)
+
+	useCase.Evaluate(status, now)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockNotifications.AssertExpectations(t)
+}
+
+func TestEvaluate_DoesNotFireWithoutEnoughConsecutiveSamples(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{slowPingRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	status := &domain.ContainerStatus{ContainerID: testContainerIDStr, Status: "running"}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return([]*domain.Alert{}, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return(pingSamples(ms(60), nil, ms(80)), nil)
+
+	useCase.Evaluate(status, time.Now())
+
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestEvaluate_FiresPacketLossRule(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{packetLossRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	status := &domain.ContainerStatus{
+		ContainerID: testContainerIDStr,
+		Status:      "running",
+		PingStats:   domain.PingStats{PacketsSent: 4, PacketsReceived: 2, PacketLossPercent: 50},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return([]*domain.Alert{}, nil)
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "packet_loss" && alert.Message == "packet loss >= 50%, latest 50% of 4 packets"
+	})).Return(true, nil).Once()
+	mockNotifications.On("Dispatch", mock.Anything).Return().Once()
+
+	useCase.Evaluate(status, time.Now())
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertNotCalled(t, "Find", mock.Anything)
+	mockNotifications.AssertExpectations(t)
+}
+
+func TestEvaluate_DoesNotFirePacketLossRuleWithoutStats(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{{Name: "any_loss", Metric: domain.AlertMetricPacketLoss, Operator: ">=", Threshold: 0}}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	status := &domain.ContainerStatus{ContainerID: testContainerIDStr, Status: "running"}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return([]*domain.Alert{}, nil)
+
+	useCase.Evaluate(status, time.Now())
+
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+}
+
+func TestEvaluate_DeduplicatesFiringAlerts(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	status := &domain.ContainerStatus{ContainerID: testContainerIDStr, Status: "exited"}
+	firing := []*domain.Alert{{ID: 7, RuleName: "exited", ContainerID: testContainerIDStr, State: domain.AlertStateFiring}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return(firing, nil)
+
+	useCase.Evaluate(status, time.Now())
+
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+	mockRepo.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+	mockHistoryRepo.AssertNotCalled(t, "Find", mock.Anything)
+}
+
+func TestEvaluate_ResolvesRecoveredAlerts(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{unreachableRule, exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	now := time.Now()
+	status := &domain.ContainerStatus{
+		ContainerID:        testContainerIDStr,
+		Status:             "running",
+		LastSuccessfulPing: now,
+	}
+	firing := []*domain.Alert{
+		{ID: 7, RuleName: "exited", ContainerID: testContainerIDStr, State: domain.AlertStateFiring},
+		{ID: 8, RuleName: "unreachable", ContainerID: testContainerIDStr, State: domain.AlertStateFiring},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return(firing, nil)
+	mockRepo.On("Resolve", firing[0], now).Return(true, nil).Once()
+	mockRepo.On("Resolve", firing[1], now).Return(true, nil).Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.NotificationTypeAlertResolved
+	})).Return().Twice()
+
+	useCase.Evaluate(status, now)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+	mockNotifications.AssertExpectations(t)
+}
+
+func TestEvaluate_DoesNotNotifyAlertResolvedElsewhere(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	now := time.Now()
+	status := &domain.ContainerStatus{ContainerID: testContainerIDStr, Status: "running"}
+	firing := []*domain.Alert{{ID: 7, RuleName: "exited", ContainerID: testContainerIDStr, State: domain.AlertStateFiring}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return(firing, nil)
+	mockRepo.On("Resolve", firing[0], now).Return(false, nil).Once()
+
+	useCase.Evaluate(status, now)
+
+	mockRepo.AssertExpectations(t)
+	mockNotifications.AssertNotCalled(t, "Dispatch", mock.Anything)
+}
+
+func TestEvaluate_FindFiringErrorSkipsEvaluation(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	status := &domain.ContainerStatus{ContainerID: testContainerIDStr, Status: "exited"}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", "USECASES: failed to fetch firing alerts for container ID %s: %v", testContainerIDStr, mock.Anything).
+		Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return(nil, fmt.Errorf("db error"))
+
+	useCase.Evaluate(status, time.Now())
+
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestResolveContainer_ResolvesAllFiringAlerts(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	now := time.Now()
+	firing := []*domain.Alert{{ID: 7, RuleName: "exited", ContainerID: testContainerIDStr, State: domain.AlertStateFiring}}
+
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("FindFiring", testContainerIDStr).Return(firing, nil)
+	mockRepo.On("Resolve", firing[0], now).Return(true, nil)
+	mockNotifications.On("Dispatch", mock.Anything).Return()
+
+	useCase.ResolveContainer(testContainerIDStr, now)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCheckNoSuccessfulPing_FiresForSilentContainers(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{slowPingRule, unreachableRule, exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	now := time.Now()
+	statuses := []*domain.ContainerStatus{
+		{ContainerID: "silent", Status: "exited", LastSuccessfulPing: now.Add(-10 * time.Minute)},
+		{ContainerID: "never-reached", CreatedAt: now.Add(-3 * time.Minute)},
+		{ContainerID: "healthy", LastSuccessfulPing: now.Add(-time.Minute)},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockStatusRepo.On("Find", &dto.ContainerStatusFilter{}).Return(statuses, nil)
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "unreachable" && alert.ContainerID == "silent"
+	})).Return(true, nil).Once()
+	mockRepo.On("Fire", mock.MatchedBy(func(alert *domain.Alert) bool {
+		return alert.RuleName == "unreachable" && alert.ContainerID == "never-reached"
+	})).Return(false, nil).Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.NotificationTypeAlertFiring && notification.ContainerID == "silent"
+	})).Return().Once()
+
+	err := useCase.CheckNoSuccessfulPing(now)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "FindFiring", mock.Anything)
+	mockHistoryRepo.AssertNotCalled(t, "Find", mock.Anything)
+	mockNotifications.AssertExpectations(t)
+}
+
+func TestCheckNoSuccessfulPing_WithoutRulesSkipsStatuses(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{slowPingRule, exitedRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	err := useCase.CheckNoSuccessfulPing(time.Now())
+
+	assert.NoError(t, err)
+	mockStatusRepo.AssertNotCalled(t, "Find", mock.Anything)
+}
+
+func TestCheckNoSuccessfulPing_FindError(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	rules := []domain.AlertRule{unreachableRule}
+	useCase := usecases.NewAlertUseCase(rules, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	mockLogger.On("Errorf", "USECASES: failed to fetch container statuses for alert rules: %v", mock.Anything).Return()
+	mockStatusRepo.On("Find", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	err := useCase.CheckNoSuccessfulPing(time.Now())
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Fire", mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestFindAlerts_AppliesDefaultLimit(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAlertUseCase(nil, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	alerts := []*domain.Alert{{ID: 1, RuleName: "exited", ContainerID: testContainerIDStr, State: domain.AlertStateFiring}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", mock.MatchedBy(func(filter *dto.AlertFilter) bool {
+		return filter.Limit != nil && *filter.Limit == 100
+	})).Return(alerts, nil)
+
+	result, err := useCase.FindAlerts(&dto.AlertFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "exited", result[0].RuleName)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindAlerts_Error(t *testing.T) {
+	mockRepo := new(mocks.AlertRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockStatusRepo := new(mocks.ContainerStatusRepository)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewAlertUseCase(nil, mockRepo, mockHistoryRepo, mockStatusRepo, mockNotifications, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", "USECASES: failed to fetch alerts: %v", mock.Anything).Return()
+	mockRepo.On("Find", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	result, err := useCase.FindAlerts(&dto.AlertFilter{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type ContainerEventUseCaseInterface interface {
+	FindEvents(filter *dto.ContainerEventFilter) ([]*dto.ContainerEventDTO, error)
+}
+
+const defaultEventsLimit = 100
+
+type ContainerEventUseCase struct {
+	repo   repositories.ContainerEventRepository
+	logger utils.LoggerInterface
+}
+
+func NewContainerEventUseCase(
+	repo repositories.ContainerEventRepository,
+	logger utils.LoggerInterface,
+) *ContainerEventUseCase {
+	return &ContainerEventUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (uc *ContainerEventUseCase) FindEvents(filter *dto.ContainerEventFilter) ([]*dto.ContainerEventDTO, error) {
+	uc.logger.Debugf("USECASES: finding container events with filter: %+v", filter)
+
+	if filter.Limit == nil {
+		limit := defaultEventsLimit
+		filter.Limit = &limit
+	}
+
+	events, err := uc.repo.Find(filter)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch container events: %v", err)
+		return nil, fmt.Errorf("failed to fetch container events: %w", err)
+	}
+
+	var dtos = make([]*dto.ContainerEventDTO, 0, len(events))
+	for _, event := range events {
+		dtos = append(dtos, mapEventToDTO(event))
+	}
+
+	uc.logger.Debugf("USECASES: found %d container events", len(dtos))
+
+	return dtos, nil
+}
+
+func mapEventToDTO(event *domain.ContainerEvent) *dto.ContainerEventDTO {
+	return &dto.ContainerEventDTO{
+		ID:                 event.ID,
+		ContainerID:        event.ContainerID,
+		Name:               event.Name,
+		EventType:          event.EventType,
+		PreviousStatus:     event.PreviousStatus,
+		CurrentStatus:      event.CurrentStatus,
+		LastSuccessfulPing: event.LastSuccessfulPing,
+		OccurredAt:         event.OccurredAt,
+	}
+}
@@ -0,0 +1,67 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+func TestFindEvents_AppliesDefaultLimit(t *testing.T) {
+	mockRepo := new(mocks.ContainerEventRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerEventUseCase(mockRepo, mockLogger)
+
+	lastSuccessfulPing := time.Now().Add(-time.Minute)
+	events := []*domain.ContainerEvent{
+		{
+			ID:                 1,
+			ContainerID:        testContainerIDStr,
+			EventType:          domain.EventTypeUnreachable,
+			LastSuccessfulPing: &lastSuccessfulPing,
+			OccurredAt:         time.Now(),
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", mock.MatchedBy(func(filter *dto.ContainerEventFilter) bool {
+		return filter.Limit != nil && *filter.Limit == 100
+	})).Return(events, nil)
+
+	result, err := useCase.FindEvents(&dto.ContainerEventFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, domain.EventTypeUnreachable, result[0].EventType)
+	assert.Equal(t, &lastSuccessfulPing, result[0].LastSuccessfulPing)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestFindEvents_Error(t *testing.T) {
+	mockRepo := new(mocks.ContainerEventRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerEventUseCase(mockRepo, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", "USECASES: failed to fetch container events: %v", mock.Anything).Return()
+	mockRepo.On("Find", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	result, err := useCase.FindEvents(&dto.ContainerEventFilter{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
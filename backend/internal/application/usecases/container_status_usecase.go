@@ -2,8 +2,10 @@ package usecases
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/broker"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
@@ -12,23 +14,53 @@ import (
 
 type ContainerStatusUseCaseInterface interface {
 	FindContainerStatuses(filter *dto.ContainerStatusFilter) ([]*dto.ContainerStatusDTO, error)
+	FindContainerStatusPage(filter *dto.ContainerStatusFilter) (*dto.ContainerStatusPageDTO, error)
 	CreateContainerStatus(statusDTO *dto.ContainerStatusDTO) (*dto.ContainerStatusDTO, error)
 	UpdateContainerStatus(containerID string, statusDTO *dto.ContainerStatusDTO) error
+	UpsertContainerStatus(containerID string, statusDTO *dto.ContainerStatusDTO) (*dto.ContainerStatusDTO, bool, error)
 	DeleteContainerStatusByContainerID(containerID string) error
+	ApplyContainerStatusBatch(batch *dto.ContainerStatusBatchDTO) (*dto.ContainerStatusBatchResultDTO, error)
+	FindContainerPingHistory(filter *dto.ContainerPingHistoryFilter) ([]*dto.ContainerPingSampleDTO, error)
+	AggregateContainerPingHistory(filter *dto.ContainerPingAggregateFilter) ([]*dto.ContainerPingAggregateDTO, error)
 }
 
+const defaultPingHistoryLimit = 1000
+
+// ErrHostMismatch is returned for a change of a container status that
+// names another host than the stored one.
+var ErrHostMismatch = repositories.ErrHostMismatch
+
+// defaultContainerStatusSort keeps pages stable when the client does not ask
+// for an order.
+var defaultContainerStatusSort = dto.ContainerStatusSort{Field: dto.SortFieldContainerID}
+
 type ContainerStatusUseCase struct {
-	repo   repositories.ContainerStatusRepository
-	logger utils.LoggerInterface
+	repo          repositories.ContainerStatusRepository
+	historyRepo   repositories.ContainerPingHistoryRepository
+	eventRepo     repositories.ContainerEventRepository
+	alerts        AlertEvaluator
+	notifications NotificationDispatcher
+	publisher     broker.ContainerStatusPublisher
+	logger        utils.LoggerInterface
 }
 
 func NewContainerStatusUseCase(
 	repo repositories.ContainerStatusRepository,
+	historyRepo repositories.ContainerPingHistoryRepository,
+	eventRepo repositories.ContainerEventRepository,
+	alerts AlertEvaluator,
+	notifications NotificationDispatcher,
+	publisher broker.ContainerStatusPublisher,
 	logger utils.LoggerInterface,
 ) *ContainerStatusUseCase {
 	return &ContainerStatusUseCase{
-		repo:   repo,
-		logger: logger,
+		repo:          repo,
+		historyRepo:   historyRepo,
+		eventRepo:     eventRepo,
+		alerts:        alerts,
+		notifications: notifications,
+		publisher:     publisher,
+		logger:        logger,
 	}
 }
 
@@ -53,6 +85,60 @@ func (uc *ContainerStatusUseCase) FindContainerStatuses(
 	return dtos, nil
 }
 
+// FindContainerStatusPage returns one page of statuses together with the total
+// number of statuses matching the filter. When the filter has a limit and more
+// statuses follow, the page carries a cursor to continue after its last item.
+func (uc *ContainerStatusUseCase) FindContainerStatusPage(
+	filter *dto.ContainerStatusFilter,
+) (*dto.ContainerStatusPageDTO, error) {
+	uc.logger.Debugf("USECASES: finding container status page with filter: %+v", filter)
+
+	query := *filter
+	if query.Sort == nil {
+		query.Sort = &defaultContainerStatusSort
+	}
+
+	paginated := filter.Limit != nil && *filter.Limit > 0
+	if paginated {
+		// One extra row tells whether there is a next page.
+		limit := *filter.Limit + 1
+		query.Limit = &limit
+	}
+
+	statuses, err := uc.repo.Find(&query)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch container statuses: %v", err)
+		return nil, fmt.Errorf("failed to fetch container statuses: %w", err)
+	}
+
+	total, err := uc.repo.Count(filter)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to count container statuses: %v", err)
+		return nil, fmt.Errorf("failed to count container statuses: %w", err)
+	}
+
+	page := &dto.ContainerStatusPageDTO{Total: total}
+
+	if paginated && len(statuses) > *filter.Limit {
+		statuses = statuses[:*filter.Limit]
+		last := statuses[len(statuses)-1]
+		page.NextCursor = &dto.ContainerStatusCursor{
+			Sort:        *query.Sort,
+			Value:       sortFieldValue(last, query.Sort.Field),
+			ContainerID: last.ContainerID,
+		}
+	}
+
+	page.Items = make([]*dto.ContainerStatusDTO, 0, len(statuses))
+	for _, status := range statuses {
+		page.Items = append(page.Items, mapDomainToDTO(status))
+	}
+
+	uc.logger.Debugf("USECASES: found %d of %d container statuses", len(page.Items), total)
+
+	return page, nil
+}
+
 func (uc *ContainerStatusUseCase) CreateContainerStatus(
 	statusDTO *dto.ContainerStatusDTO,
 ) (*dto.ContainerStatusDTO, error) {
@@ -60,11 +146,15 @@ func (uc *ContainerStatusUseCase) CreateContainerStatus(
 
 	newStatus := &domain.ContainerStatus{
 		ContainerID:        statusDTO.ContainerID,
+		HostID:             statusDTO.HostID,
 		Name:               statusDTO.Name,
 		IPAddress:          statusDTO.IPAddress,
 		Status:             statusDTO.Status,
 		PingTime:           statusDTO.PingTime,
 		LastSuccessfulPing: statusDTO.LastSuccessfulPing,
+		ProbeType:          probeTypeOrDefault(statusDTO.ProbeType),
+		Health:             healthOrDefault(statusDTO.Health),
+		PingStats:          mapPingStatsDTOToDomain(statusDTO.PingStats),
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
 	}
@@ -77,6 +167,10 @@ func (uc *ContainerStatusUseCase) CreateContainerStatus(
 
 	uc.logger.Debugf("Created container status record")
 
+	uc.recordPingSample(newStatus, statusDTO)
+	uc.alerts.Evaluate(newStatus, newStatus.UpdatedAt)
+	uc.publish(dto.ChangeTypeCreated, newStatus)
+
 	return mapDomainToDTO(newStatus), nil
 }
 
@@ -98,20 +192,13 @@ func (uc *ContainerStatusUseCase) UpdateContainerStatus(
 	}
 
 	status := existing[0]
-
-	if statusDTO.PingTime != 0 {
-		status.PingTime = statusDTO.PingTime
-	}
-	if !statusDTO.LastSuccessfulPing.IsZero() {
-		status.LastSuccessfulPing = statusDTO.LastSuccessfulPing
-	}
-	if statusDTO.Status != "" {
-		status.Status = statusDTO.Status
-	}
-	if statusDTO.Name != "" {
-		status.Name = statusDTO.Name
+	if hostMismatch(status.HostID, statusDTO.HostID) {
+		uc.logger.Warnf("USECASES: container ID %s belongs to host %s, not %s", containerID, status.HostID, statusDTO.HostID)
+		return fmt.Errorf("container %s: %w", containerID, ErrHostMismatch)
 	}
+	previous := *status
 
+	mergeStatus(status, statusDTO)
 	status.UpdatedAt = time.Now()
 
 	err = uc.repo.Update(status)
@@ -122,9 +209,85 @@ func (uc *ContainerStatusUseCase) UpdateContainerStatus(
 
 	uc.logger.Debugf("Successfully updated container status for container ID: %s", containerID)
 
+	wasReachable, known := uc.lastSampleSuccess(containerID)
+	uc.recordPingSample(status, statusDTO)
+	uc.recordTransitions(&previous, status, wasReachable, known, !statusDTO.LastSuccessfulPing.IsZero())
+	uc.alerts.Evaluate(status, status.UpdatedAt)
+	uc.publish(dto.ChangeTypeUpdated, status)
+
 	return nil
 }
 
+// UpsertContainerStatus stores the full state of a container whether or not
+// it is known yet. The write is a single statement, so concurrent reports of
+// the same container cannot fail each other. It reports whether the status
+// was created.
+func (uc *ContainerStatusUseCase) UpsertContainerStatus(
+	containerID string,
+	statusDTO *dto.ContainerStatusDTO,
+) (*dto.ContainerStatusDTO, bool, error) {
+	uc.logger.Debugf("USECASES: upserting container status for container ID: %s with data: %+v", containerID, statusDTO)
+
+	// The previous state is only used to record the transitions.
+	existing, err := uc.repo.Find(&dto.ContainerStatusFilter{ContainerID: &containerID})
+	if err != nil {
+		uc.logger.Errorf("USECASES: error fetching container status for container ID %s: %v", containerID, err)
+		return nil, false, fmt.Errorf("error fetching container status: %w", err)
+	}
+	if len(existing) > 0 && hostMismatch(existing[0].HostID, statusDTO.HostID) {
+		uc.logger.Warnf("USECASES: container ID %s belongs to host %s, not %s", containerID, existing[0].HostID, statusDTO.HostID)
+		return nil, false, fmt.Errorf("container %s: %w", containerID, ErrHostMismatch)
+	}
+
+	now := time.Now()
+	status := &domain.ContainerStatus{
+		ContainerID:        containerID,
+		HostID:             statusDTO.HostID,
+		Name:               statusDTO.Name,
+		IPAddress:          statusDTO.IPAddress,
+		Status:             statusDTO.Status,
+		PingTime:           statusDTO.PingTime,
+		LastSuccessfulPing: statusDTO.LastSuccessfulPing,
+		ProbeType:          statusDTO.ProbeType,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		Networks:           mapNetworkDTOsToDomain(statusDTO.Networks),
+	}
+	// An empty health status keeps the stored health, and stats without
+	// packets sent the stored stats.
+	if statusDTO.Health != nil {
+		status.Health = domain.ContainerHealth(*statusDTO.Health)
+	}
+	status.PingStats = mapPingStatsDTOToDomain(statusDTO.PingStats)
+
+	var wasReachable, reachabilityKnown bool
+	if len(existing) > 0 {
+		wasReachable, reachabilityKnown = uc.lastSampleSuccess(containerID)
+	}
+
+	created, err := uc.repo.Upsert(status)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to upsert container status for container ID %s: %v", containerID, err)
+		return nil, false, fmt.Errorf("failed to upsert container status: %w", err)
+	}
+
+	uc.logger.Debugf("USECASES: upserted container status for container ID: %s, created: %t", containerID, created)
+
+	uc.recordPingSample(status, statusDTO)
+	if !created && len(existing) > 0 {
+		uc.recordTransitions(existing[0], status, wasReachable, reachabilityKnown, !statusDTO.LastSuccessfulPing.IsZero())
+	}
+	uc.alerts.Evaluate(status, status.UpdatedAt)
+
+	if created {
+		uc.publish(dto.ChangeTypeCreated, status)
+	} else {
+		uc.publish(dto.ChangeTypeUpdated, status)
+	}
+
+	return mapDomainToDTO(status), created, nil
+}
+
 func (uc *ContainerStatusUseCase) DeleteContainerStatusByContainerID(containerID string) error {
 	uc.logger.Debugf("USECASES: deleting container status for container_id: %s", containerID)
 
@@ -146,18 +309,534 @@ func (uc *ContainerStatusUseCase) DeleteContainerStatusByContainerID(containerID
 	}
 
 	uc.logger.Debugf("USECASES: successfully deleted container status for container_id: %s", containerID)
+
+	uc.recordRemoval(existing[0], time.Now())
+
 	return nil
 }
 
+// ApplyContainerStatusBatch stores the results of a ping cycle of one agent in
+// a single transaction. Reported statuses are created or updated like with
+// CreateContainerStatus and UpdateContainerStatus, and the statuses of the
+// host missing from the live containers are deleted, unless the batch is
+// replayed. A status stored for another host is skipped, also when the other
+// host stores it while the batch is applied, and a status stored without host
+// is taken over. The statuses, samples and events are
+// stamped with the CheckedAt of the batch when it is set.
+func (uc *ContainerStatusUseCase) ApplyContainerStatusBatch(
+	batch *dto.ContainerStatusBatchDTO,
+) (*dto.ContainerStatusBatchResultDTO, error) {
+	uc.logger.Debugf(
+		"USECASES: applying batch of %d container statuses for host %s",
+		len(batch.Statuses),
+		batch.HostID,
+	)
+
+	existing, err := uc.repo.Find(&dto.ContainerStatusFilter{HostID: &batch.HostID})
+	if err != nil {
+		uc.logger.Errorf("USECASES: error fetching container statuses of host %s: %v", batch.HostID, err)
+		return nil, fmt.Errorf("error fetching container statuses: %w", err)
+	}
+
+	previous := make(map[string]domain.ContainerStatus, len(existing))
+	for _, status := range existing {
+		previous[status.ContainerID] = *status
+	}
+
+	others, err := uc.findUnknownStatuses(batch, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]bool, len(batch.LiveContainerIDs)+len(batch.Statuses))
+	for _, containerID := range batch.LiveContainerIDs {
+		live[containerID] = true
+	}
+
+	result := &dto.ContainerStatusBatchResultDTO{}
+	now := time.Now()
+	if !batch.CheckedAt.IsZero() {
+		now = batch.CheckedAt
+	}
+
+	upserts := make([]*domain.ContainerStatus, 0, len(batch.Statuses))
+	reported := make([]*dto.ContainerStatusDTO, 0, len(batch.Statuses))
+	for _, statusDTO := range batch.Statuses {
+		live[statusDTO.ContainerID] = true
+
+		if other, ok := others[statusDTO.ContainerID]; ok {
+			if other.HostID != "" {
+				uc.logger.Warnf("USECASES: skipping container %s of host %s reported by host %s",
+					statusDTO.ContainerID, other.HostID, batch.HostID)
+				result.Skipped++
+				continue
+			}
+			previous[statusDTO.ContainerID] = *other
+		}
+
+		var status *domain.ContainerStatus
+		if prev, ok := previous[statusDTO.ContainerID]; ok {
+			status = &prev
+			mergeStatus(status, statusDTO)
+		} else {
+			if statusDTO.IPAddress == "" {
+				uc.logger.Warnf("USECASES: skipping new container %s without IP address", statusDTO.ContainerID)
+				result.Skipped++
+				continue
+			}
+
+			status = &domain.ContainerStatus{
+				ContainerID:        statusDTO.ContainerID,
+				Name:               statusDTO.Name,
+				IPAddress:          statusDTO.IPAddress,
+				Status:             statusDTO.Status,
+				PingTime:           statusDTO.PingTime,
+				LastSuccessfulPing: statusDTO.LastSuccessfulPing,
+				ProbeType:          probeTypeOrDefault(statusDTO.ProbeType),
+				Health:             healthOrDefault(statusDTO.Health),
+				PingStats:          mapPingStatsDTOToDomain(statusDTO.PingStats),
+				CreatedAt:          now,
+				Networks:           mapNetworkDTOsToDomain(statusDTO.Networks),
+			}
+		}
+
+		status.HostID = batch.HostID
+		status.UpdatedAt = now
+		upserts = append(upserts, status)
+		reported = append(reported, statusDTO)
+	}
+
+	// The live containers of a replayed batch are those of its cycle; the
+	// containers created since are missing from it and must not be deleted.
+	var removed []*domain.ContainerStatus
+	var deleteIDs []string
+	for _, status := range existing {
+		if !batch.Replayed && !live[status.ContainerID] {
+			removed = append(removed, status)
+			deleteIDs = append(deleteIDs, status.ContainerID)
+		}
+	}
+
+	taken, err := uc.repo.ApplyBatch(upserts, deleteIDs)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to apply batch for host %s: %v", batch.HostID, err)
+		return nil, fmt.Errorf("failed to apply container status batch: %w", err)
+	}
+
+	for i, status := range upserts {
+		// Another host stored the container since it was looked up.
+		if slices.Contains(taken, status.ContainerID) {
+			uc.logger.Warnf("USECASES: skipping container %s taken by another host than %s",
+				status.ContainerID, batch.HostID)
+			result.Skipped++
+			continue
+		}
+
+		prev, known := previous[status.ContainerID]
+		if !known {
+			uc.recordPingSample(status, reported[i])
+			uc.alerts.Evaluate(status, now)
+			uc.publish(dto.ChangeTypeCreated, status)
+			result.Created++
+			continue
+		}
+
+		wasReachable, reachabilityKnown := uc.lastSampleSuccess(status.ContainerID)
+		uc.recordPingSample(status, reported[i])
+		uc.recordTransitions(&prev, status, wasReachable, reachabilityKnown, !reported[i].LastSuccessfulPing.IsZero())
+		uc.alerts.Evaluate(status, now)
+		uc.publish(dto.ChangeTypeUpdated, status)
+		result.Updated++
+	}
+
+	for _, status := range removed {
+		uc.recordRemoval(status, now)
+	}
+	result.Deleted = len(removed)
+
+	uc.logger.Debugf("USECASES: applied batch for host %s: %+v", batch.HostID, result)
+
+	return result, nil
+}
+
+// findUnknownStatuses returns the stored statuses of the containers in batch
+// that are not among the statuses of its host, by container ID.
+func (uc *ContainerStatusUseCase) findUnknownStatuses(
+	batch *dto.ContainerStatusBatchDTO,
+	known map[string]domain.ContainerStatus,
+) (map[string]*domain.ContainerStatus, error) {
+	var unknownIDs []string
+	for _, statusDTO := range batch.Statuses {
+		if _, ok := known[statusDTO.ContainerID]; !ok {
+			unknownIDs = append(unknownIDs, statusDTO.ContainerID)
+		}
+	}
+	if len(unknownIDs) == 0 {
+		return nil, nil
+	}
+
+	statuses, err := uc.repo.Find(&dto.ContainerStatusFilter{ContainerIDs: unknownIDs})
+	if err != nil {
+		uc.logger.Errorf("USECASES: error fetching container statuses reported by host %s: %v", batch.HostID, err)
+		return nil, fmt.Errorf("error fetching container statuses: %w", err)
+	}
+
+	found := make(map[string]*domain.ContainerStatus, len(statuses))
+	for _, status := range statuses {
+		found[status.ContainerID] = status
+	}
+
+	return found, nil
+}
+
+func (uc *ContainerStatusUseCase) FindContainerPingHistory(
+	filter *dto.ContainerPingHistoryFilter,
+) ([]*dto.ContainerPingSampleDTO, error) {
+	uc.logger.Debugf("USECASES: finding ping history with filter: %+v", filter)
+
+	if filter.Limit == nil {
+		limit := defaultPingHistoryLimit
+		filter.Limit = &limit
+	}
+
+	samples, err := uc.historyRepo.Find(filter)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch ping history for container ID %s: %v", filter.ContainerID, err)
+		return nil, fmt.Errorf("failed to fetch ping history: %w", err)
+	}
+
+	var dtos = make([]*dto.ContainerPingSampleDTO, 0, len(samples))
+	for _, sample := range samples {
+		dtos = append(dtos, mapSampleToDTO(sample))
+	}
+
+	uc.logger.Debugf("USECASES: found %d ping history samples", len(dtos))
+
+	return dtos, nil
+}
+
+func (uc *ContainerStatusUseCase) AggregateContainerPingHistory(
+	filter *dto.ContainerPingAggregateFilter,
+) ([]*dto.ContainerPingAggregateDTO, error) {
+	uc.logger.Debugf("USECASES: aggregating ping history with filter: %+v", filter)
+
+	aggregates, err := uc.historyRepo.Aggregate(filter)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to aggregate ping history for container ID %s: %v", filter.ContainerID, err)
+		return nil, fmt.Errorf("failed to aggregate ping history: %w", err)
+	}
+
+	var dtos = make([]*dto.ContainerPingAggregateDTO, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		dtos = append(dtos, &dto.ContainerPingAggregateDTO{
+			BucketStart:  aggregate.BucketStart,
+			MinPingTime:  aggregate.MinPingTime,
+			AvgPingTime:  aggregate.AvgPingTime,
+			MaxPingTime:  aggregate.MaxPingTime,
+			P95PingTime:  aggregate.P95PingTime,
+			SampleCount:  aggregate.SampleCount,
+			SuccessRatio: aggregate.SuccessRatio,
+		})
+	}
+
+	uc.logger.Debugf("USECASES: aggregated ping history into %d buckets", len(dtos))
+
+	return dtos, nil
+}
+
+// hostMismatch reports whether a change naming reported may not be applied
+// to a status stored for stored. An empty host matches any.
+func hostMismatch(stored, reported string) bool {
+	return stored != "" && reported != "" && stored != reported
+}
+
+// mergeStatus applies the fields set in statusDTO to status. Empty fields, a
+// zero ping time, nil health, nil ping stats and nil networks keep the
+// stored values.
+
+func mergeStatus(status *domain.ContainerStatus, statusDTO *dto.ContainerStatusDTO) {
+	if statusDTO.PingTime != 0 {
+		status.PingTime = statusDTO.PingTime
+	}
+	if !statusDTO.LastSuccessfulPing.IsZero() {
+		status.LastSuccessfulPing = statusDTO.LastSuccessfulPing
+	}
+	if statusDTO.Status != "" {
+		status.Status = statusDTO.Status
+	}
+	if statusDTO.Name != "" {
+		status.Name = statusDTO.Name
+	}
+	if statusDTO.HostID != "" {
+		status.HostID = statusDTO.HostID
+	}
+	if statusDTO.IPAddress != "" {
+		status.IPAddress = statusDTO.IPAddress
+	}
+	if statusDTO.ProbeType != "" {
+		status.ProbeType = statusDTO.ProbeType
+	}
+	if statusDTO.Health != nil {
+		status.Health = domain.ContainerHealth(*statusDTO.Health)
+	}
+	if statusDTO.PingStats != nil {
+		status.PingStats = domain.PingStats(*statusDTO.PingStats)
+	}
+	if statusDTO.Networks != nil {
+		status.Networks = mapNetworkDTOsToDomain(statusDTO.Networks)
+	}
+}
+
+// probeTypeOrDefault is the probe type of a new status, reporters that
+// predate the probe types only ping.
+func probeTypeOrDefault(probeType string) string {
+	if probeType == "" {
+		return domain.ProbeTypeICMP
+	}
+
+	return probeType
+}
+
+// healthOrDefault is the health of a new status, which has none until it is
+// reported.
+func healthOrDefault(health *dto.ContainerHealthDTO) domain.ContainerHealth {
+	if health == nil {
+		return domain.ContainerHealth{Status: domain.HealthStatusNone}
+	}
+
+	return domain.ContainerHealth(*health)
+}
+
+// recordRemoval reacts to a status deleted at removedAt: it records the
+// removal, resolves the firing alerts of the container and publishes the
+// deletion.
+func (uc *ContainerStatusUseCase) recordRemoval(status *domain.ContainerStatus, removedAt time.Time) {
+	uc.recordEvent(newEvent(status, domain.EventTypeRemoved, status.Status, "", removedAt))
+	uc.alerts.ResolveContainer(status.ContainerID, removedAt)
+	uc.publish(dto.ChangeTypeDeleted, status)
+}
+
+// recordPingSample appends the reported sample to the ping history. A failure
+// here is logged only, the current status has already been stored.
+func (uc *ContainerStatusUseCase) recordPingSample(status *domain.ContainerStatus, statusDTO *dto.ContainerStatusDTO) {
+	sample := &domain.ContainerPingSample{
+		ContainerID: status.ContainerID,
+		IPAddress:   status.IPAddress,
+		Status:      status.Status,
+		Success:     !statusDTO.LastSuccessfulPing.IsZero(),
+		RecordedAt:  status.UpdatedAt,
+	}
+
+	if sample.Success {
+		pingTime := statusDTO.PingTime
+		sample.PingTime = &pingTime
+	}
+
+	if err := uc.historyRepo.Create(sample); err != nil {
+		uc.logger.Errorf("USECASES: failed to record ping sample for container ID %s: %v", status.ContainerID, err)
+	}
+}
+
+// lastSampleSuccess reports whether the latest recorded ping of the container
+// succeeded. known is false when there is no usable history yet.
+func (uc *ContainerStatusUseCase) lastSampleSuccess(containerID string) (success, known bool) {
+	limit := 1
+
+	samples, err := uc.historyRepo.Find(&dto.ContainerPingHistoryFilter{ContainerID: containerID, Limit: &limit})
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to fetch latest ping sample for container ID %s: %v", containerID, err)
+		return false, false
+	}
+
+	if len(samples) == 0 {
+		return false, false
+	}
+
+	return samples[0].Success, true
+}
+
+// recordTransitions stores an event for every Docker status change and for
+// every flip between a successful and a failed ping, at the time the current
+// status was checked.
+func (uc *ContainerStatusUseCase) recordTransitions(
+	previous, current *domain.ContainerStatus,
+	wasReachable, reachabilityKnown, isReachable bool,
+) {
+	if previous.Status != current.Status {
+		uc.recordEvent(newEvent(current, domain.EventTypeStatusChanged, previous.Status, current.Status, current.UpdatedAt))
+	}
+
+	if !reachabilityKnown || wasReachable == isReachable {
+		return
+	}
+
+	eventType := domain.EventTypeUnreachable
+	if isReachable {
+		eventType = domain.EventTypeReachable
+	}
+
+	uc.recordEvent(newEvent(current, eventType, previous.Status, current.Status, current.UpdatedAt))
+}
+
+func (uc *ContainerStatusUseCase) recordEvent(event *domain.ContainerEvent) {
+	uc.logger.Debugf("USECASES: recording %s event for container ID %s", event.EventType, event.ContainerID)
+
+	if err := uc.eventRepo.Create(event); err != nil {
+		uc.logger.Errorf("USECASES: failed to record %s event for container ID %s: %v", event.EventType, event.ContainerID, err)
+	}
+
+	uc.notifications.Dispatch(newEventNotification(event))
+}
+
+func (uc *ContainerStatusUseCase) publish(changeType string, status *domain.ContainerStatus) {
+	uc.publisher.Publish(&dto.ContainerStatusChangeDTO{
+		Type:        changeType,
+		ContainerID: status.ContainerID,
+		Status:      mapDomainToDTO(status),
+	})
+}
+
+func newEventNotification(event *domain.ContainerEvent) *domain.Notification {
+	notification := &domain.Notification{
+		Type:        event.EventType,
+		ContainerID: event.ContainerID,
+		Name:        event.Name,
+		Severity:    "info",
+		OccurredAt:  event.OccurredAt,
+	}
+
+	switch event.EventType {
+	case domain.EventTypeStatusChanged:
+		notification.Severity = "warning"
+		notification.Summary = fmt.Sprintf("status changed from %s to %s", event.PreviousStatus, event.CurrentStatus)
+	case domain.EventTypeUnreachable:
+		notification.Severity = "critical"
+		notification.Summary = "container stopped answering pings"
+	case domain.EventTypeReachable:
+		notification.Summary = "container answers pings again"
+	case domain.EventTypeRemoved:
+		notification.Summary = "container was removed"
+	}
+
+	return notification
+}
+
+func newEvent(
+	status *domain.ContainerStatus,
+	eventType, previousStatus, currentStatus string,
+	occurredAt time.Time,
+) *domain.ContainerEvent {
+	event := &domain.ContainerEvent{
+		ContainerID:    status.ContainerID,
+		Name:           status.Name,
+		EventType:      eventType,
+		PreviousStatus: previousStatus,
+		CurrentStatus:  currentStatus,
+		OccurredAt:     occurredAt,
+	}
+
+	if !status.LastSuccessfulPing.IsZero() {
+		lastSuccessfulPing := status.LastSuccessfulPing
+		event.LastSuccessfulPing = &lastSuccessfulPing
+	}
+
+	return event
+}
+
 func mapDomainToDTO(status *domain.ContainerStatus) *dto.ContainerStatusDTO {
 	return &dto.ContainerStatusDTO{
 		ContainerID:        status.ContainerID,
+		HostID:             status.HostID,
 		Name:               status.Name,
 		IPAddress:          status.IPAddress,
 		Status:             status.Status,
 		PingTime:           status.PingTime,
 		LastSuccessfulPing: status.LastSuccessfulPing,
+		ProbeType:          status.ProbeType,
+		Health:             mapHealthToDTO(status.Health),
+		PingStats:          mapPingStatsToDTO(status.PingStats),
 		UpdatedAt:          status.UpdatedAt,
 		CreatedAt:          status.CreatedAt,
+		Networks:           mapNetworksToDTO(status.Networks),
+	}
+}
+
+func mapHealthToDTO(health domain.ContainerHealth) *dto.ContainerHealthDTO {
+	healthDTO := dto.ContainerHealthDTO(health)
+	return &healthDTO
+}
+
+func mapPingStatsToDTO(stats domain.PingStats) *dto.PingStatsDTO {
+	statsDTO := dto.PingStatsDTO(stats)
+	return &statsDTO
+}
+
+// mapPingStatsDTOToDomain maps stats that were not reported to zero stats,
+// which have no packets sent.
+func mapPingStatsDTOToDomain(statsDTO *dto.PingStatsDTO) domain.PingStats {
+	if statsDTO == nil {
+		return domain.PingStats{}
+	}
+
+	return domain.PingStats(*statsDTO)
+}
+
+func mapNetworksToDTO(networks []domain.ContainerNetwork) []dto.ContainerNetworkDTO {
+	if networks == nil {
+		return nil
+	}
+
+	dtos := make([]dto.ContainerNetworkDTO, 0, len(networks))
+	for _, network := range networks {
+		dtos = append(dtos, dto.ContainerNetworkDTO(network))
+	}
+
+	return dtos
+}
+
+func mapNetworkDTOsToDomain(dtos []dto.ContainerNetworkDTO) []domain.ContainerNetwork {
+	if dtos == nil {
+		return nil
+	}
+
+	networks := make([]domain.ContainerNetwork, 0, len(dtos))
+	for _, network := range dtos {
+		networks = append(networks, domain.ContainerNetwork(network))
+	}
+
+	return networks
+}
+
+func mapSampleToDTO(sample *domain.ContainerPingSample) *dto.ContainerPingSampleDTO {
+	return &dto.ContainerPingSampleDTO{
+		ContainerID: sample.ContainerID,
+		IPAddress:   sample.IPAddress,
+		Status:      sample.Status,
+		PingTime:    sample.PingTime,
+		Success:     sample.Success,
+		RecordedAt:  sample.RecordedAt,
+	}
+}
+
+func sortFieldValue(status *domain.ContainerStatus, field string) any {
+	switch field {
+	case dto.SortFieldHostID:
+		return status.HostID
+	case dto.SortFieldIPAddress:
+		return status.IPAddress
+	case dto.SortFieldName:
+		return status.Name
+	case dto.SortFieldStatus:
+		return status.Status
+	case dto.SortFieldPingTime:
+		return status.PingTime
+	case dto.SortFieldLastSuccessfulPing:
+		return status.LastSuccessfulPing
+	case dto.SortFieldCreatedAt:
+		return status.CreatedAt
+	case dto.SortFieldUpdatedAt:
+		return status.UpdatedAt
+	default:
+		return status.ContainerID
 	}
 }
@@ -24,9 +24,14 @@ const (
 
 func TestFindContainerStatuses_Success(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockFilter := &dto.ContainerStatusFilter{
 		ContainerID: new(string),
@@ -57,9 +62,14 @@ func TestFindContainerStatuses_Success(t *testing.T) {
 
 func TestFindContainerStatuses_Error(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockFilter := &dto.ContainerStatusFilter{}
 
@@ -76,11 +86,85 @@ func TestFindContainerStatuses_Error(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestFindContainerStatusPage_ReturnsCursorWhenMoreFollow(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	limit := 2
+	filter := &dto.ContainerStatusFilter{
+		Limit: &limit,
+		Sort:  &dto.ContainerStatusSort{Field: dto.SortFieldPingTime, Descending: true},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", mock.MatchedBy(func(query *dto.ContainerStatusFilter) bool {
+		return *query.Limit == limit+1 && query.Sort == filter.Sort
+	})).Return([]*domain.ContainerStatus{
+		{ContainerID: "c", PingTime: 30},
+		{ContainerID: "b", PingTime: 20},
+		{ContainerID: "a", PingTime: 10},
+	}, nil)
+	mockRepo.On("Count", filter).Return(5, nil)
+
+	page, err := useCase.FindContainerStatusPage(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, limit, *filter.Limit)
+	assert.Equal(t, &dto.ContainerStatusCursor{Sort: *filter.Sort, Value: 20.0, ContainerID: "b"}, page.NextCursor)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindContainerStatusPage_DefaultsSortWithoutCursorOnLastPage(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	filter := &dto.ContainerStatusFilter{}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", mock.MatchedBy(func(query *dto.ContainerStatusFilter) bool {
+		return query.Limit == nil && query.Sort.Field == dto.SortFieldContainerID && !query.Sort.Descending
+	})).Return([]*domain.ContainerStatus{{ContainerID: "a"}}, nil)
+	mockRepo.On("Count", filter).Return(1, nil)
+
+	page, err := useCase.FindContainerStatusPage(filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Nil(t, page.NextCursor)
+	assert.Nil(t, filter.Sort)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCreateContainerStatus_Success(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockDTO := &dto.ContainerStatusDTO{
 		ContainerID: testContainerIDStr,
@@ -90,6 +174,13 @@ func TestCreateContainerStatus_Success(t *testing.T) {
 
 	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
 	mockRepo.On("Create", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Create", mock.MatchedBy(func(sample *domain.ContainerPingSample) bool {
+		return sample.ContainerID == testContainerIDStr && !sample.Success && sample.PingTime == nil
+	})).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeCreated && change.Status.ContainerID == testContainerIDStr
+	})).Return()
 
 	result, err := useCase.CreateContainerStatus(mockDTO)
 
@@ -99,14 +190,20 @@ func TestCreateContainerStatus_Success(t *testing.T) {
 	assert.Equal(t, testContainerIDStr, result.ContainerID)
 
 	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
 
 func TestCreateContainerStatus_Error(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockDTO := &dto.ContainerStatusDTO{
 		ContainerID: testContainerIDStr,
@@ -129,9 +226,14 @@ func TestCreateContainerStatus_Error(t *testing.T) {
 
 func TestUpdateContainerStatus_Success(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	mockDTO := &dto.ContainerStatusDTO{
@@ -149,20 +251,247 @@ func TestUpdateContainerStatus_Success(t *testing.T) {
 	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
 	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
 	mockRepo.On("Update", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.MatchedBy(func(sample *domain.ContainerPingSample) bool {
+		return sample.ContainerID == mockContainerID && sample.Success && *sample.PingTime == testPingTimeUpdated
+	})).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeUpdated && change.Status.PingTime == testPingTimeUpdated
+	})).Return()
+
+	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestUpdateContainerStatus_HistoryErrorIsNotFatal(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{PingTime: testPingTimeUpdated}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			IPAddress:   testContainerIP,
+			PingTime:    testPingTimeDefault,
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+	mockRepo.On("Update", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(fmt.Errorf("insert failed"))
+	mockLogger.On("Errorf", "USECASES: failed to record ping sample for container ID %s: %v", mockContainerID, mock.Anything).
+		Return()
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
 
 	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
 
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
 
+func TestUpdateContainerStatus_RejectsOtherHost(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{HostID: "node-2", PingTime: testPingTimeUpdated}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			HostID:      "node-1",
+			IPAddress:   testContainerIP,
+			PingTime:    testPingTimeDefault,
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, mockContainerID, "node-1", "node-2").Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+
+	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
+
+	assert.ErrorIs(t, err, usecases.ErrHostMismatch)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestUpdateContainerStatus_TakesOverStatusWithoutHost(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{HostID: "node-2", PingTime: testPingTimeUpdated}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			IPAddress:   testContainerIP,
+			PingTime:    testPingTimeDefault,
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(status *domain.ContainerStatus) bool {
+		return status.HostID == "node-2" && status.PingTime == testPingTimeUpdated
+	})).Return(nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Status.HostID == "node-2"
+	})).Return()
+
+	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUpdateContainerStatus_RecordsTransitions(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{Status: "exited"}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			IPAddress:   testContainerIP,
+			Status:      "running",
+			PingTime:    testPingTimeDefault,
+		},
+	}
+	lastSample := []*domain.ContainerPingSample{{ContainerID: mockContainerID, Success: true}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+	mockRepo.On("Update", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Find", mock.MatchedBy(func(filter *dto.ContainerPingHistoryFilter) bool {
+		return filter.ContainerID == mockContainerID && *filter.Limit == 1
+	})).Return(lastSample, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.EventType == domain.EventTypeStatusChanged &&
+			event.PreviousStatus == "running" && event.CurrentStatus == "exited"
+	})).Return(nil).Once()
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.EventType == domain.EventTypeUnreachable && event.LastSuccessfulPing == nil
+	})).Return(nil).Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.EventTypeStatusChanged &&
+			notification.Summary == "status changed from running to exited"
+	})).Return().Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.EventTypeUnreachable && notification.Severity == "critical"
+	})).Return().Once()
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockNotifications.AssertExpectations(t)
+}
+
+func TestUpdateContainerStatus_NoTransitionWithoutHistory(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{PingTime: testPingTimeUpdated, LastSuccessfulPing: time.Now()}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			IPAddress:   testContainerIP,
+			Status:      "running",
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+	mockRepo.On("Update", mock.Anything).Return(nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return(nil, fmt.Errorf("db error"))
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	err := useCase.UpdateContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+
+	mockHistoryRepo.AssertExpectations(t)
+	mockEventRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
 func TestUpdateContainerStatus_ErrorFetching(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	mockDTO := &dto.ContainerStatusDTO{PingTime: testPingTimeUpdated}
@@ -182,9 +511,14 @@ func TestUpdateContainerStatus_ErrorFetching(t *testing.T) {
 
 func TestUpdateContainerStatus_NotFound(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	mockDTO := &dto.ContainerStatusDTO{PingTime: testPingTimeUpdated}
@@ -207,9 +541,14 @@ func TestUpdateContainerStatus_NotFound(t *testing.T) {
 
 func TestUpdateContainerStatus_UpdateError(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	mockDTO := &dto.ContainerStatusDTO{PingTime: testPingTimeUpdated}
@@ -237,11 +576,178 @@ func TestUpdateContainerStatus_UpdateError(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestUpsertContainerStatus_CreatesUnknownContainer(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{
+		HostID:             "node-1",
+		IPAddress:          testContainerIP,
+		Name:               "web",
+		Status:             "running",
+		PingTime:           testPingTimeDefault,
+		LastSuccessfulPing: time.Now(),
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("Upsert", mock.MatchedBy(func(status *domain.ContainerStatus) bool {
+		return status.ContainerID == mockContainerID && status.HostID == "node-1" &&
+			status.IPAddress == testContainerIP && status.Status == "running" && !status.CreatedAt.IsZero()
+	})).Return(true, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeCreated && change.ContainerID == mockContainerID
+	})).Return().Once()
+
+	result, created, err := useCase.UpsertContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, mockContainerID, result.ContainerID)
+	assert.Equal(t, "node-1", result.HostID)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockHistoryRepo.AssertNotCalled(t, "Find", mock.Anything)
+	mockEventRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpsertContainerStatus_ReplacesKnownContainer(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{
+		IPAddress:          testContainerIP,
+		Status:             "paused",
+		PingTime:           testPingTimeUpdated,
+		LastSuccessfulPing: time.Now(),
+	}
+	existingStatus := []*domain.ContainerStatus{
+		{
+			ContainerID: mockContainerID,
+			IPAddress:   testContainerIP,
+			Status:      "running",
+		},
+	}
+	lastSample := []*domain.ContainerPingSample{{ContainerID: mockContainerID, Success: true}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existingStatus, nil)
+	mockRepo.On("Upsert", mock.Anything).Return(false, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return(lastSample, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.EventType == domain.EventTypeStatusChanged &&
+			event.PreviousStatus == "running" && event.CurrentStatus == "paused"
+	})).Return(nil).Once()
+	mockNotifications.On("Dispatch", mock.Anything).Return().Once()
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeUpdated && change.ContainerID == mockContainerID
+	})).Return().Once()
+
+	_, created, err := useCase.UpsertContainerStatus(mockContainerID, mockDTO)
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockNotifications.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestUpsertContainerStatus_UpsertError(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{IPAddress: testContainerIP, Status: "running"}
+
+	mockLogger.On("Debugf", "USECASES: upserting container status for container ID: %s with data: %+v", mockContainerID, mock.Anything).
+		Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("Upsert", mock.Anything).Return(false, fmt.Errorf("upsert failed"))
+	mockLogger.On("Errorf", "USECASES: failed to upsert container status for container ID %s: %v", mockContainerID, mock.Anything).
+		Return()
+
+	result, created, err := useCase.UpsertContainerStatus(mockContainerID, mockDTO)
+
+	assert.ErrorContains(t, err, "failed to upsert container status: upsert failed")
+	assert.Nil(t, result)
+	assert.False(t, created)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+	mockHistoryRepo.AssertNotCalled(t, "Create", mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestUpsertContainerStatus_RejectsOtherHost(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockContainerID := testContainerIDStr
+	mockDTO := &dto.ContainerStatusDTO{HostID: "node-2", IPAddress: testContainerIP, Status: "running"}
+	existing := []*domain.ContainerStatus{{ContainerID: mockContainerID, HostID: "node-1", IPAddress: testContainerIP, Status: "running"}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, mockContainerID, "node-1", "node-2").Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerID: &mockContainerID}).Return(existing, nil)
+
+	result, created, err := useCase.UpsertContainerStatus(mockContainerID, mockDTO)
+
+	assert.ErrorIs(t, err, usecases.ErrHostMismatch)
+	assert.Nil(t, result)
+	assert.False(t, created)
+
+	mockRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
 func TestDeleteContainerStatusByContainerID_ErrorFetching(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 
@@ -260,9 +766,14 @@ func TestDeleteContainerStatusByContainerID_ErrorFetching(t *testing.T) {
 
 func TestDeleteContainerStatusByContainerID_NotFound(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 
@@ -281,9 +792,14 @@ func TestDeleteContainerStatusByContainerID_NotFound(t *testing.T) {
 
 func TestDeleteContainerStatusByContainerID_ErrorDeleting(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	existingStatus := []*domain.ContainerStatus{
@@ -309,9 +825,14 @@ func TestDeleteContainerStatusByContainerID_ErrorDeleting(t *testing.T) {
 
 func TestDeleteContainerStatusByContainerID_Success(t *testing.T) {
 	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
 	mockLogger := new(mocks.LoggerInterface)
 
-	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockLogger)
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
 
 	mockContainerID := testContainerIDStr
 	existingStatus := []*domain.ContainerStatus{
@@ -327,11 +848,659 @@ func TestDeleteContainerStatusByContainerID_Success(t *testing.T) {
 	mockRepo.On("DeleteByContainerID", mockContainerID).Return(nil)
 	mockLogger.On("Debugf", "USECASES: successfully deleted container status for container_id: %s", mockContainerID).
 		Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.ContainerID == mockContainerID && event.EventType == domain.EventTypeRemoved
+	})).Return(nil)
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.Type == domain.EventTypeRemoved
+	})).Return()
+	mockAlerts.On("ResolveContainer", testContainerIDStr, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeDeleted && change.ContainerID == mockContainerID
+	})).Return()
 
 	err := useCase.DeleteContainerStatusByContainerID(mockContainerID)
 
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_UpsertsAndDeletesMissing(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	createdAt := time.Now().Add(-time.Hour)
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running", CreatedAt: createdAt},
+		{ContainerID: "idle", HostID: hostID, IPAddress: "192.168.1.102", Status: "exited"},
+		{ContainerID: "gone", HostID: hostID, IPAddress: "192.168.1.103", Status: "running"},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "exited"},
+			{ContainerID: "db", IPAddress: "192.168.1.104", Status: "running", PingTime: testPingTimeDefault, LastSuccessfulPing: time.Now()},
+			{ContainerID: "job", Status: "created"},
+		},
+		LiveContainerIDs: []string{"web", "idle", "db", "job"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", "USECASES: skipping new container %s without IP address", "job").Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db", "job"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 2 &&
+			statuses[0].ContainerID == "web" && statuses[0].Status == "exited" &&
+			statuses[0].IPAddress == testContainerIP && statuses[0].CreatedAt.Equal(createdAt) &&
+			statuses[1].ContainerID == "db" && statuses[1].HostID == hostID && !statuses[1].CreatedAt.IsZero()
+	}), []string{"gone"}).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.ContainerID == "web" && event.EventType == domain.EventTypeStatusChanged
+	})).Return(nil).Once()
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.ContainerID == "gone" && event.EventType == domain.EventTypeRemoved
+	})).Return(nil).Once()
+	mockNotifications.On("Dispatch", mock.Anything).Return()
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockAlerts.On("ResolveContainer", "gone", mock.Anything).Return().Once()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeUpdated && change.ContainerID == "web"
+	})).Return().Once()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeCreated && change.ContainerID == "db"
+	})).Return().Once()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeDeleted && change.ContainerID == "gone"
+	})).Return().Once()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 1, Updated: 1, Deleted: 1, Skipped: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_StampsCheckedAt(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	checkedAt := time.Date(2025, 2, 9, 12, 0, 0, 0, time.UTC)
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID:    hostID,
+		CheckedAt: checkedAt,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "db", IPAddress: testContainerIP, Status: "running", PingTime: testPingTimeDefault, LastSuccessfulPing: checkedAt},
+		},
+		LiveContainerIDs: []string{"db"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 1 && statuses[0].UpdatedAt.Equal(checkedAt) && statuses[0].CreatedAt.Equal(checkedAt)
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Create", mock.MatchedBy(func(sample *domain.ContainerPingSample) bool {
+		return sample.RecordedAt.Equal(checkedAt)
+	})).Return(nil).Once()
+	mockAlerts.On("Evaluate", mock.Anything, checkedAt).Return().Once()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	_, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_ReplayedKeepsMissingAndStampsEvents(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	checkedAt := time.Date(2025, 2, 9, 12, 0, 0, 0, time.UTC)
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running"},
+		{ContainerID: "new", HostID: hostID, IPAddress: "192.168.1.105", Status: "running"},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID:    hostID,
+		CheckedAt: checkedAt,
+		Replayed:  true,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "exited"},
+		},
+		LiveContainerIDs: []string{"web"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("ApplyBatch", mock.Anything, []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockEventRepo.On("Create", mock.MatchedBy(func(event *domain.ContainerEvent) bool {
+		return event.ContainerID == "web" && event.EventType == domain.EventTypeStatusChanged &&
+			event.OccurredAt.Equal(checkedAt)
+	})).Return(nil).Once()
+	mockNotifications.On("Dispatch", mock.MatchedBy(func(notification *domain.Notification) bool {
+		return notification.OccurredAt.Equal(checkedAt)
+	})).Return().Once()
+	mockAlerts.On("Evaluate", mock.Anything, checkedAt).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Updated: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockNotifications.AssertExpectations(t)
+	mockAlerts.AssertNotCalled(t, "ResolveContainer", mock.Anything, mock.Anything)
+}
+
+func TestApplyContainerStatusBatch_KeepsNetworksNotReported(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	pingTime := testPingTimeDefault
+	storedNetworks := []domain.ContainerNetwork{{NetworkName: "frontend", IPv4Address: testContainerIP, IPv4PingTime: &pingTime}}
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running", Networks: storedNetworks},
+	}
+	reportedNetworks := []dto.ContainerNetworkDTO{
+		{NetworkName: "backend", IPv4Address: "172.18.0.3", IPv4PingTime: &pingTime},
+		{NetworkName: "frontend", IPv4Address: "172.19.0.3"},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "running"},
+			{ContainerID: "db", IPAddress: "172.18.0.3", Status: "running", PingTime: pingTime, Networks: reportedNetworks},
+		},
+		LiveContainerIDs: []string{"web", "db"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 2 &&
+			assert.ObjectsAreEqual(storedNetworks, statuses[0].Networks) &&
+			len(statuses[1].Networks) == 2 &&
+			statuses[1].Networks[0].NetworkName == "backend" &&
+			statuses[1].Networks[1].IPv4PingTime == nil
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 1, Updated: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_DefaultsProbeType(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running", ProbeType: "http"},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "running"},
+			{ContainerID: "db", IPAddress: "172.18.0.3", Status: "running", ProbeType: "tcp"},
+			{ContainerID: "cache", IPAddress: "172.18.0.4", Status: "running"},
+		},
+		LiveContainerIDs: []string{"web", "db", "cache"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db", "cache"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 3 &&
+			statuses[0].ProbeType == "http" &&
+			statuses[1].ProbeType == "tcp" &&
+			statuses[2].ProbeType == domain.ProbeTypeICMP
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 2, Updated: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_KeepsHealthNotReported(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	storedHealth := domain.ContainerHealth{Status: domain.HealthStatusUnhealthy, FailingStreak: 4, LastOutput: "timeout"}
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running", Health: storedHealth},
+		{ContainerID: "api", HostID: hostID, IPAddress: "172.18.0.5", Status: "running", Health: storedHealth},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "running"},
+			{ContainerID: "api", Status: "running", Health: &dto.ContainerHealthDTO{Status: domain.HealthStatusHealthy}},
+			{ContainerID: "db", IPAddress: "172.18.0.3", Status: "running"},
+		},
+		LiveContainerIDs: []string{"web", "api", "db"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 3 &&
+			statuses[0].Health == storedHealth &&
+			statuses[1].Health == domain.ContainerHealth{Status: domain.HealthStatusHealthy} &&
+			statuses[2].Health == domain.ContainerHealth{Status: domain.HealthStatusNone}
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 1, Updated: 2}, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_KeepsPingStatsNotReported(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	storedStats := domain.PingStats{PacketsSent: 3, PacketsReceived: 3, MinRTT: 0.4, AvgRTT: 0.5, MaxRTT: 0.7, StdDevRTT: 0.1}
+	reportedStats := dto.PingStatsDTO{PacketsSent: 4, PacketsReceived: 1, PacketLossPercent: 75, MinRTT: 1.2, AvgRTT: 1.2, MaxRTT: 1.2}
+	existing := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: hostID, IPAddress: testContainerIP, Status: "running", PingStats: storedStats},
+		{ContainerID: "api", HostID: hostID, IPAddress: "172.18.0.5", Status: "running", PingStats: storedStats},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", Status: "running"},
+			{ContainerID: "api", Status: "running", PingStats: &reportedStats},
+			{ContainerID: "db", IPAddress: "172.18.0.3", Status: "running"},
+		},
+		LiveContainerIDs: []string{"web", "api", "db"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"db"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 3 &&
+			statuses[0].PingStats == storedStats &&
+			statuses[1].PingStats == domain.PingStats(reportedStats) &&
+			statuses[2].PingStats == domain.PingStats{}
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.Anything).Return()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 1, Updated: 2}, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_ApplyError(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-1"
+	existing := []*domain.ContainerStatus{{ContainerID: "gone", HostID: hostID, Status: "running"}}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", "USECASES: failed to apply batch for host %s: %v", hostID, mock.Anything).Return()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return(existing, nil)
+	mockRepo.On("ApplyBatch", mock.Anything, []string{"gone"}).Return(nil, fmt.Errorf("db error"))
+
+	result, err := useCase.ApplyContainerStatusBatch(&dto.ContainerStatusBatchDTO{HostID: hostID, LiveContainerIDs: []string{}})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+	mockAlerts.AssertNotCalled(t, "ResolveContainer", mock.Anything, mock.Anything)
+}
+
+func TestApplyContainerStatusBatch_SkipsOtherHostAndTakesOverUnassigned(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-2"
+	createdAt := time.Now().Add(-time.Hour)
+	others := []*domain.ContainerStatus{
+		{ContainerID: "web", HostID: "node-1", IPAddress: testContainerIP, Status: "running"},
+		{ContainerID: "legacy", IPAddress: "192.168.1.106", Status: "running", CreatedAt: createdAt},
+	}
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", IPAddress: testContainerIP, Status: "exited"},
+			{ContainerID: "legacy", Status: "running", PingTime: testPingTimeDefault},
+		},
+		LiveContainerIDs: []string{"web", "legacy"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", "USECASES: skipping container %s of host %s reported by host %s", "web", "node-1", hostID).Return().Once()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"web", "legacy"}}).Return(others, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 1 && statuses[0].ContainerID == "legacy" && statuses[0].HostID == hostID &&
+			statuses[0].IPAddress == "192.168.1.106" && statuses[0].CreatedAt.Equal(createdAt)
+	}), []string(nil)).Return(nil, nil)
+	mockHistoryRepo.On("Find", mock.Anything).Return([]*domain.ContainerPingSample{}, nil)
+	mockHistoryRepo.On("Create", mock.Anything).Return(nil)
+	mockAlerts.On("Evaluate", mock.Anything, mock.Anything).Return()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeUpdated && change.ContainerID == "legacy"
+	})).Return().Once()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Updated: 1, Skipped: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_SkipsStatusTakenWhileApplying(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	hostID := "node-2"
+	batch := &dto.ContainerStatusBatchDTO{
+		HostID: hostID,
+		Statuses: []*dto.ContainerStatusDTO{
+			{ContainerID: "web", IPAddress: testContainerIP, Status: "running"},
+			{ContainerID: "db", IPAddress: "192.168.1.106", Status: "running"},
+		},
+		LiveContainerIDs: []string{"web", "db"},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", "USECASES: skipping container %s taken by another host than %s", "web", hostID).Return().Once()
+	mockRepo.On("Find", &dto.ContainerStatusFilter{HostID: &hostID}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("Find", &dto.ContainerStatusFilter{ContainerIDs: []string{"web", "db"}}).Return([]*domain.ContainerStatus{}, nil)
+	mockRepo.On("ApplyBatch", mock.MatchedBy(func(statuses []*domain.ContainerStatus) bool {
+		return len(statuses) == 2
+	}), []string(nil)).Return([]string{"web"}, nil)
+	mockHistoryRepo.On("Create", mock.MatchedBy(func(sample *domain.ContainerPingSample) bool {
+		return sample.ContainerID == "db"
+	})).Return(nil).Once()
+	mockAlerts.On("Evaluate", mock.MatchedBy(func(status *domain.ContainerStatus) bool {
+		return status.ContainerID == "db"
+	}), mock.Anything).Return().Once()
+	mockPublisher.On("Publish", mock.MatchedBy(func(change *dto.ContainerStatusChangeDTO) bool {
+		return change.Type == dto.ChangeTypeCreated && change.ContainerID == "db"
+	})).Return().Once()
+
+	result, err := useCase.ApplyContainerStatusBatch(batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ContainerStatusBatchResultDTO{Created: 1, Skipped: 1}, result)
+
+	mockRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestFindContainerPingHistory_AppliesDefaultLimit(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	pingTime := testPingTimeDefault
+	mockFilter := &dto.ContainerPingHistoryFilter{ContainerID: testContainerIDStr}
+	mockResult := []*domain.ContainerPingSample{
+		{
+			ContainerID: testContainerIDStr,
+			IPAddress:   testContainerIP,
+			PingTime:    &pingTime,
+			Success:     true,
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockHistoryRepo.On("Find", mock.MatchedBy(func(filter *dto.ContainerPingHistoryFilter) bool {
+		return filter.Limit != nil && *filter.Limit == 1000
+	})).Return(mockResult, nil)
+
+	result, err := useCase.FindContainerPingHistory(mockFilter)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, testContainerIDStr, result[0].ContainerID)
+	assert.Equal(t, testPingTimeDefault, *result[0].PingTime)
+
+	mockHistoryRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestFindContainerPingHistory_Error(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockFilter := &dto.ContainerPingHistoryFilter{ContainerID: testContainerIDStr}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockHistoryRepo.On("Find", mockFilter).Return(nil, fmt.Errorf("database error"))
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	result, err := useCase.FindContainerPingHistory(mockFilter)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockHistoryRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestAggregateContainerPingHistory_Success(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	avgPingTime := testPingTimeDefault
+	bucketStart := time.Date(2025, 2, 9, 12, 0, 0, 0, time.UTC)
+	mockFilter := &dto.ContainerPingAggregateFilter{
+		ContainerID: testContainerIDStr,
+		Bucket:      5 * time.Minute,
+		From:        bucketStart,
+		To:          bucketStart.Add(time.Hour),
+	}
+	mockResult := []*domain.ContainerPingAggregate{
+		{
+			BucketStart:  bucketStart,
+			AvgPingTime:  &avgPingTime,
+			SampleCount:  4,
+			SuccessRatio: 0.75,
+		},
+	}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockHistoryRepo.On("Aggregate", mockFilter).Return(mockResult, nil)
+
+	result, err := useCase.AggregateContainerPingHistory(mockFilter)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, bucketStart, result[0].BucketStart)
+	assert.Equal(t, testPingTimeDefault, *result[0].AvgPingTime)
+	assert.Equal(t, int64(4), result[0].SampleCount)
+	assert.InDelta(t, 0.75, result[0].SuccessRatio, 0.0001)
+
+	mockHistoryRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestAggregateContainerPingHistory_Error(t *testing.T) {
+	mockRepo := new(mocks.ContainerStatusRepository)
+	mockHistoryRepo := new(mocks.ContainerPingHistoryRepository)
+	mockEventRepo := new(mocks.ContainerEventRepository)
+	mockAlerts := new(mocks.AlertEvaluator)
+	mockNotifications := new(mocks.NotificationDispatcher)
+	mockPublisher := new(mocks.ContainerStatusPublisher)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewContainerStatusUseCase(mockRepo, mockHistoryRepo, mockEventRepo, mockAlerts, mockNotifications, mockPublisher, mockLogger)
+
+	mockFilter := &dto.ContainerPingAggregateFilter{ContainerID: testContainerIDStr, Bucket: time.Minute}
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockHistoryRepo.On("Aggregate", mockFilter).Return(nil, fmt.Errorf("database error"))
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	result, err := useCase.AggregateContainerPingHistory(mockFilter)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockHistoryRepo.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// NotificationDispatcher hands notifications over for delivery without
+// blocking the caller.
+type NotificationDispatcher interface {
+	Dispatch(notification *domain.Notification)
+}
+
+type NotificationUseCaseInterface interface {
+	Deliver(ctx context.Context, notification *domain.Notification)
+}
+
+// RetryPolicy describes how often and how patiently a failed delivery is
+// retried. The backoff doubles after every failed attempt up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type NotificationUseCase struct {
+	notifiers    []notifiers.Notifier
+	deliveryRepo repositories.NotificationDeliveryRepository
+	retry        RetryPolicy
+	logger       utils.LoggerInterface
+}
+
+func NewNotificationUseCase(
+	notifiers []notifiers.Notifier,
+	deliveryRepo repositories.NotificationDeliveryRepository,
+	retry RetryPolicy,
+	logger utils.LoggerInterface,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notifiers:    notifiers,
+		deliveryRepo: deliveryRepo,
+		retry:        retry,
+		logger:       logger,
+	}
+}
+
+// Deliver sends the notification through every notifier accepting its type
+// in parallel and records the outcome of each delivery.
+func (uc *NotificationUseCase) Deliver(ctx context.Context, notification *domain.Notification) {
+	var wg sync.WaitGroup
+
+	for _, notifier := range uc.notifiers {
+		if !notifier.Accepts(notification.Type) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(notifier notifiers.Notifier) {
+			defer wg.Done()
+			uc.deliverWithRetry(ctx, notifier, notification)
+		}(notifier)
+	}
+
+	wg.Wait()
+}
+
+func (uc *NotificationUseCase) deliverWithRetry(
+	ctx context.Context,
+	notifier notifiers.Notifier,
+	notification *domain.Notification,
+) {
+	delivery := &domain.NotificationDelivery{
+		Notifier:         notifier.Name(),
+		NotificationType: notification.Type,
+		ContainerID:      notification.ContainerID,
+	}
+
+	backoff := uc.retry.InitialBackoff
+	for {
+		delivery.Attempts++
+
+		err := notifier.Notify(ctx, notification)
+		if err == nil {
+			delivery.Success = true
+			delivery.Error = ""
+			break
+		}
+
+		delivery.Error = err.Error()
+		uc.logger.Warnf("USECASES: attempt %d of %s notification via %s failed: %v",
+			delivery.Attempts, notification.Type, notifier.Name(), err)
+
+		if delivery.Attempts >= uc.retry.MaxAttempts || !sleepContext(ctx, backoff) {
+			break
+		}
+
+		backoff = min(backoff*2, uc.retry.MaxBackoff)
+	}
+
+	if !delivery.Success {
+		uc.logger.Errorf("USECASES: giving up on %s notification via %s for container ID %s after %d attempts",
+			notification.Type, notifier.Name(), notification.ContainerID, delivery.Attempts)
+	}
+
+	delivery.CreatedAt = time.Now()
+	if err := uc.deliveryRepo.Create(delivery); err != nil {
+		uc.logger.Errorf("USECASES: failed to record delivery via %s: %v", notifier.Name(), err)
+	}
+}
+
+// sleepContext waits for the given duration and reports false if the context
+// got cancelled first.
+func sleepContext(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
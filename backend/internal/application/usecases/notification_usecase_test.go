@@ -0,0 +1,140 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+var testRetryPolicy = usecases.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     2 * time.Millisecond,
+}
+
+func newTestNotification() *domain.Notification {
+	return &domain.Notification{
+		Type:        domain.EventTypeUnreachable,
+		ContainerID: testContainerIDStr,
+		Severity:    "critical",
+		Summary:     "container stopped answering pings",
+		OccurredAt:  time.Now(),
+	}
+}
+
+func TestDeliver_RetriesUntilSuccess(t *testing.T) {
+	mockNotifier := new(mocks.Notifier)
+	mockDeliveryRepo := new(mocks.NotificationDeliveryRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewNotificationUseCase(
+		[]notifiers.Notifier{mockNotifier},
+		mockDeliveryRepo,
+		testRetryPolicy,
+		mockLogger,
+	)
+
+	notification := newTestNotification()
+
+	mockNotifier.On("Name").Return("ops-webhook")
+	mockNotifier.On("Accepts", domain.EventTypeUnreachable).Return(true)
+	mockNotifier.On("Notify", mock.Anything, notification).Return(fmt.Errorf("connection refused")).Once()
+	mockNotifier.On("Notify", mock.Anything, notification).Return(nil).Once()
+	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockDeliveryRepo.On("Create", mock.MatchedBy(func(delivery *domain.NotificationDelivery) bool {
+		return delivery.Notifier == "ops-webhook" && delivery.Attempts == 2 && delivery.Success && delivery.Error == ""
+	})).Return(nil)
+
+	useCase.Deliver(context.Background(), notification)
+
+	mockNotifier.AssertExpectations(t)
+	mockDeliveryRepo.AssertExpectations(t)
+}
+
+func TestDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	mockNotifier := new(mocks.Notifier)
+	mockDeliveryRepo := new(mocks.NotificationDeliveryRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewNotificationUseCase(
+		[]notifiers.Notifier{mockNotifier},
+		mockDeliveryRepo,
+		testRetryPolicy,
+		mockLogger,
+	)
+
+	notification := newTestNotification()
+
+	mockNotifier.On("Name").Return("ops-webhook")
+	mockNotifier.On("Accepts", domain.EventTypeUnreachable).Return(true)
+	mockNotifier.On("Notify", mock.Anything, notification).Return(fmt.Errorf("502 Bad Gateway")).Times(3)
+	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockDeliveryRepo.On("Create", mock.MatchedBy(func(delivery *domain.NotificationDelivery) bool {
+		return delivery.Attempts == 3 && !delivery.Success && delivery.Error == "502 Bad Gateway"
+	})).Return(nil)
+
+	useCase.Deliver(context.Background(), notification)
+
+	mockNotifier.AssertExpectations(t)
+	mockDeliveryRepo.AssertExpectations(t)
+}
+
+func TestDeliver_StopsRetryingWhenCancelled(t *testing.T) {
+	mockNotifier := new(mocks.Notifier)
+	mockDeliveryRepo := new(mocks.NotificationDeliveryRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewNotificationUseCase(
+		[]notifiers.Notifier{mockNotifier},
+		mockDeliveryRepo,
+		usecases.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour},
+		mockLogger,
+	)
+
+	notification := newTestNotification()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockNotifier.On("Name").Return("ops-webhook")
+	mockNotifier.On("Accepts", domain.EventTypeUnreachable).Return(true)
+	mockNotifier.On("Notify", mock.Anything, notification).Return(fmt.Errorf("timeout")).Once()
+	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockDeliveryRepo.On("Create", mock.MatchedBy(func(delivery *domain.NotificationDelivery) bool {
+		return delivery.Attempts == 1 && !delivery.Success
+	})).Return(nil)
+
+	useCase.Deliver(ctx, notification)
+
+	mockNotifier.AssertExpectations(t)
+	mockDeliveryRepo.AssertExpectations(t)
+}
+
+func TestDeliver_SkipsNotifiersNotAcceptingType(t *testing.T) {
+	mockNotifier := new(mocks.Notifier)
+	mockDeliveryRepo := new(mocks.NotificationDeliveryRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewNotificationUseCase(
+		[]notifiers.Notifier{mockNotifier},
+		mockDeliveryRepo,
+		testRetryPolicy,
+		mockLogger,
+	)
+
+	mockNotifier.On("Accepts", domain.EventTypeUnreachable).Return(false)
+
+	useCase.Deliver(context.Background(), newTestNotification())
+
+	mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	mockDeliveryRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type RetentionUseCaseInterface interface {
+	Compact(now time.Time) (*dto.RetentionReport, error)
+}
+
+type RetentionUseCase struct {
+	repo            repositories.PingRetentionRepository
+	rawRetention    time.Duration
+	rollupRetention time.Duration
+	logger          utils.LoggerInterface
+}
+
+func NewRetentionUseCase(
+	repo repositories.PingRetentionRepository,
+	rawRetention time.Duration,
+	rollupRetention time.Duration,
+	logger utils.LoggerInterface,
+) *RetentionUseCase {
+	return &RetentionUseCase{
+		repo:            repo,
+		rawRetention:    rawRetention,
+		rollupRetention: rollupRetention,
+		logger:          logger,
+	}
+}
+
+// Compact rolls raw samples up into hourly buckets and only then purges
+// expired rows, so no sample is deleted before it has been rolled up.
+func (uc *RetentionUseCase) Compact(now time.Time) (*dto.RetentionReport, error) {
+	uc.logger.Debugf("USECASES: compacting ping data at: %s", now)
+
+	report := &dto.RetentionReport{}
+
+	rolledUp, err := uc.repo.RollupHourly(now)
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to roll up ping history: %v", err)
+		return nil, fmt.Errorf("failed to roll up ping history: %w", err)
+	}
+	report.RolledUpBuckets = rolledUp
+
+	purgedRaw, err := uc.repo.DeleteRawBefore(now.Add(-uc.rawRetention))
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to purge expired ping history: %v", err)
+		return nil, fmt.Errorf("failed to purge expired ping history: %w", err)
+	}
+	report.PurgedRawSamples = purgedRaw
+
+	purgedRollups, err := uc.repo.DeleteRollupsBefore(now.Add(-uc.rollupRetention))
+	if err != nil {
+		uc.logger.Errorf("USECASES: failed to purge expired hourly rollups: %v", err)
+		return nil, fmt.Errorf("failed to purge expired hourly rollups: %w", err)
+	}
+	report.PurgedRollups = purgedRollups
+
+	uc.logger.Debugf("USECASES: compaction finished: %+v", report)
+
+	return report, nil
+}
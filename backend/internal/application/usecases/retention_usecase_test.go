@@ -0,0 +1,86 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+const (
+	testRawRetention    = 7 * 24 * time.Hour
+	testRollupRetention = 90 * 24 * time.Hour
+)
+
+func TestCompact_Success(t *testing.T) {
+	mockRepo := new(mocks.PingRetentionRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewRetentionUseCase(mockRepo, testRawRetention, testRollupRetention, mockLogger)
+
+	now := time.Date(2025, 2, 9, 12, 34, 56, 0, time.UTC)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	rollup := mockRepo.On("RollupHourly", now).Return(int64(3), nil)
+	deleteRaw := mockRepo.On("DeleteRawBefore", now.Add(-testRawRetention)).Return(int64(120), nil).NotBefore(rollup)
+	mockRepo.On("DeleteRollupsBefore", now.Add(-testRollupRetention)).Return(int64(2), nil).NotBefore(deleteRaw)
+
+	report, err := useCase.Compact(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), report.RolledUpBuckets)
+	assert.Equal(t, int64(120), report.PurgedRawSamples)
+	assert.Equal(t, int64(2), report.PurgedRollups)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestCompact_RollupErrorKeepsRawSamples(t *testing.T) {
+	mockRepo := new(mocks.PingRetentionRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewRetentionUseCase(mockRepo, testRawRetention, testRollupRetention, mockLogger)
+
+	now := time.Now()
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("RollupHourly", now).Return(int64(0), fmt.Errorf("database error"))
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	report, err := useCase.Compact(now)
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "DeleteRawBefore", mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestCompact_DeleteRawError(t *testing.T) {
+	mockRepo := new(mocks.PingRetentionRepository)
+	mockLogger := new(mocks.LoggerInterface)
+
+	useCase := usecases.NewRetentionUseCase(mockRepo, testRawRetention, testRollupRetention, mockLogger)
+
+	now := time.Now()
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockRepo.On("RollupHourly", now).Return(int64(1), nil)
+	mockRepo.On("DeleteRawBefore", now.Add(-testRawRetention)).Return(int64(0), fmt.Errorf("database error"))
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	report, err := useCase.Compact(now)
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+
+	mockRepo.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	AgentStateOnline = "online"
+	AgentStateStale  = "stale"
+)
+
+// Agent is a pinger registered with the backend. Its ID is the host_id of the
+// container statuses it reports. CycleDuration is the length of the last ping
+// cycle and PingInterval the time between two cycles, both in milliseconds.
+// OverrunCycles counts the cycles since the start of the pinger that took
+// longer than the interval.
+type Agent struct {
+	ID              string    `db:"id"`
+	HostName        string    `db:"host_name"`
+	Version         string    `db:"version"`
+	DockerVersion   string    `db:"docker_version"`
+	ContainerCount  int       `db:"container_count"`
+	CycleDuration   float64   `db:"cycle_duration"`
+	PingInterval    float64   `db:"ping_interval"`
+	OverrunCycles   int       `db:"overrun_cycles"`
+	RegisteredAt    time.Time `db:"registered_at"`
+	LastHeartbeatAt time.Time `db:"last_heartbeat_at"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+const (
+	AlertMetricPingTime         = "ping_time"
+	AlertMetricPacketLoss       = "packet_loss"
+	AlertMetricNoSuccessfulPing = "no_successful_ping"
+	AlertMetricStatus           = "status"
+)
+
+const (
+	AlertStateFiring   = "firing"
+	AlertStateResolved = "resolved"
+)
+
+// AlertRule describes a condition checked against every incoming status
+// update. Which fields are used depends on the metric:
+//   - ping_time: Operator, Threshold (ms) and Consecutive samples;
+//   - packet_loss: Operator and Threshold (percent) of the last check;
+//   - no_successful_ping: For, the longest allowed time without a successful ping;
+//   - status: Operator ("==" or "!=") and Value.
+type AlertRule struct {
+	Name        string
+	Metric      string
+	Operator    string
+	Threshold   float64
+	Value       string
+	Consecutive int
+	For         time.Duration
+	Severity    string
+}
+
+type Alert struct {
+	ID          int64      `db:"id"`
+	RuleName    string     `db:"rule_name"`
+	ContainerID string     `db:"container_id"`
+	Severity    string     `db:"severity"`
+	State       string     `db:"state"`
+	Message     string     `db:"message"`
+	FiredAt     time.Time  `db:"fired_at"`
+	ResolvedAt  *time.Time `db:"resolved_at"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	EventTypeStatusChanged = "status_changed"
+	EventTypeUnreachable   = "unreachable"
+	EventTypeReachable     = "reachable"
+	EventTypeRemoved       = "removed"
+)
+
+type ContainerEvent struct {
+	ID                 int64      `db:"id"`
+	ContainerID        string     `db:"container_id"`
+	Name               string     `db:"name"`
+	EventType          string     `db:"event_type"`
+	PreviousStatus     string     `db:"previous_status"`
+	CurrentStatus      string     `db:"current_status"`
+	LastSuccessfulPing *time.Time `db:"last_successful_ping"`
+	OccurredAt         time.Time  `db:"occurred_at"`
+}
+
+func IsValidEventType(eventType string) bool {
+	switch eventType {
+	case EventTypeStatusChanged, EventTypeUnreachable, EventTypeReachable, EventTypeRemoved:
+		return true
+	default:
+		return false
+	}
+}
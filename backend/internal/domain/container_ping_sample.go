@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type ContainerPingSample struct {
+	ID          int64     `db:"id"`
+	ContainerID string    `db:"container_id"`
+	IPAddress   string    `db:"ip_address"`
+	Status      string    `db:"status"`
+	PingTime    *float64  `db:"ping_time"`
+	Success     bool      `db:"success"`
+	RecordedAt  time.Time `db:"recorded_at"`
+}
+
+type ContainerPingAggregate struct {
+	BucketStart  time.Time `db:"bucket_start"`
+	MinPingTime  *float64  `db:"min_ping_time"`
+	AvgPingTime  *float64  `db:"avg_ping_time"`
+	MaxPingTime  *float64  `db:"max_ping_time"`
+	P95PingTime  *float64  `db:"p95_ping_time"`
+	SampleCount  int64     `db:"sample_count"`
+	SuccessRatio float64   `db:"success_ratio"`
+}
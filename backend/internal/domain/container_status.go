@@ -2,13 +2,97 @@ package domain
 
 import "time"
 
+// Container states as reported by Docker.
+const (
+	ContainerStateCreated    = "created"
+	ContainerStateRestarting = "restarting"
+	ContainerStateRunning    = "running"
+	ContainerStateRemoving   = "removing"
+	ContainerStatePaused     = "paused"
+	ContainerStateExited     = "exited"
+	ContainerStateDead       = "dead"
+)
+
+// Health states of a container HEALTHCHECK. HealthStatusNone is stored for a
+// container whose image defines none, or that is not running.
+const (
+	HealthStatusNone      = "none"
+	HealthStatusStarting  = "starting"
+	HealthStatusHealthy   = "healthy"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// ProbeTypeICMP is the probe of the statuses reported without a probe type.
+const ProbeTypeICMP = "icmp"
+
 type ContainerStatus struct {
 	ContainerID        string    `db:"container_id"`
+	HostID             string    `db:"host_id"`
 	Name               string    `db:"name"`
 	IPAddress          string    `db:"ip_address"`
 	Status             string    `db:"status"`
 	PingTime           float64   `db:"ping_time"`
 	LastSuccessfulPing time.Time `db:"last_successful_ping"`
+	ProbeType          string    `db:"probe_type"`
+	Health             ContainerHealth
+	PingStats          PingStats
 	UpdatedAt          time.Time `db:"updated_at"`
 	CreatedAt          time.Time `db:"created_at"`
+	// Networks lists every network the container is attached to. IPAddress
+	// is the address of one of them, chosen by the pinger.
+	Networks []ContainerNetwork `db:"-"`
+}
+
+// ContainerNetwork is a network attachment of a container. Each address is
+// pinged on its own; a nil ping time means the address did not answer or is
+// not assigned.
+type ContainerNetwork struct {
+	NetworkName  string   `db:"network_name"`
+	MACAddress   string   `db:"mac_address"`
+	Gateway      string   `db:"gateway"`
+	IPv4Address  string   `db:"ipv4_address"`
+	IPv4PingTime *float64 `db:"ipv4_ping_time"`
+	IPv6Address  string   `db:"ipv6_address"`
+	IPv6PingTime *float64 `db:"ipv6_ping_time"`
+}
+
+// ContainerHealth is the state Docker keeps of the HEALTHCHECK of a
+// container, LastOutput is the output of the latest check.
+type ContainerHealth struct {
+	Status        string `db:"health_status"`
+	FailingStreak int    `db:"health_failing_streak"`
+	LastOutput    string `db:"health_last_output"`
+}
+
+// PingStats sums up the attempts of the last check of a container: the echo
+// requests of an ICMP probe, or the single attempt of another probe. The
+// round trip times are in milliseconds, like the ping time of the status.
+// PacketsSent is 0 until reported.
+type PingStats struct {
+	PacketsSent       int     `db:"packets_sent"`
+	PacketsReceived   int     `db:"packets_received"`
+	PacketLossPercent float64 `db:"packet_loss_percent"`
+	MinRTT            float64 `db:"rtt_min_ms"`
+	AvgRTT            float64 `db:"rtt_avg_ms"`
+	MaxRTT            float64 `db:"rtt_max_ms"`
+	StdDevRTT         float64 `db:"rtt_stddev_ms"`
+}
+
+func IsValidContainerState(state string) bool {
+	switch state {
+	case ContainerStateCreated, ContainerStateRestarting, ContainerStateRunning, ContainerStateRemoving,
+		ContainerStatePaused, ContainerStateExited, ContainerStateDead:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidHealthStatus(status string) bool {
+	switch status {
+	case HealthStatusNone, HealthStatusStarting, HealthStatusHealthy, HealthStatusUnhealthy:
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	NotificationTypeAlertFiring   = "alert_firing"
+	NotificationTypeAlertResolved = "alert_resolved"
+)
+
+// Notification is what notifiers send out. Type is either one of the
+// container event types or an alert notification type.
+type Notification struct {
+	Type        string    `json:"type"`
+	ContainerID string    `json:"container_id"`
+	Name        string    `json:"name"`
+	Severity    string    `json:"severity"`
+	Summary     string    `json:"summary"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type NotificationDelivery struct {
+	ID               int64     `db:"id"`
+	Notifier         string    `db:"notifier"`
+	NotificationType string    `db:"notification_type"`
+	ContainerID      string    `db:"container_id"`
+	Attempts         int       `db:"attempts"`
+	Success          bool      `db:"success"`
+	Error            string    `db:"error"`
+	CreatedAt        time.Time `db:"created_at"`
+}
@@ -0,0 +1,74 @@
+package broker
+
+import (
+	"sync"
+
+	appBroker "github.com/repyg/DockerMonitoringApp/backend/internal/application/broker"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// ContainerStatusBroker fans container status changes out to in-process
+// subscribers. Publishing never blocks: a subscriber whose buffer is full is
+// dropped, so it can reconnect and resync instead of silently missing changes.
+type ContainerStatusBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan *dto.ContainerStatusChangeDTO]struct{}
+	bufferSize  int
+	logger      utils.LoggerInterface
+}
+
+var (
+	_ appBroker.ContainerStatusPublisher  = (*ContainerStatusBroker)(nil)
+	_ appBroker.ContainerStatusSubscriber = (*ContainerStatusBroker)(nil)
+)
+
+func NewContainerStatusBroker(bufferSize int, logger utils.LoggerInterface) *ContainerStatusBroker {
+	return &ContainerStatusBroker{
+		subscribers: make(map[chan *dto.ContainerStatusChangeDTO]struct{}),
+		bufferSize:  bufferSize,
+		logger:      logger,
+	}
+}
+
+func (b *ContainerStatusBroker) Subscribe() (<-chan *dto.ContainerStatusChangeDTO, func()) {
+	ch := make(chan *dto.ContainerStatusChangeDTO, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	count := len(b.subscribers)
+	b.mu.Unlock()
+
+	b.logger.Debugf("BROKER: subscriber added, %d active", count)
+
+	return ch, func() { b.remove(ch) }
+}
+
+func (b *ContainerStatusBroker) Publish(change *dto.ContainerStatusChangeDTO) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- change:
+		default:
+			b.logger.Warnf("BROKER: dropping slow subscriber")
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *ContainerStatusBroker) remove(ch chan *dto.ContainerStatusChangeDTO) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+
+	delete(b.subscribers, ch)
+	close(ch)
+
+	b.logger.Debugf("BROKER: subscriber removed, %d active", len(b.subscribers))
+}
@@ -0,0 +1,66 @@
+package broker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/broker"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+func newTestLogger() *mocks.LoggerInterface {
+	logger := new(mocks.LoggerInterface)
+	logger.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Warnf", mock.Anything).Maybe().Return()
+
+	return logger
+}
+
+func TestContainerStatusBroker_FansOutToSubscribers(t *testing.T) {
+	statusBroker := broker.NewContainerStatusBroker(1, newTestLogger())
+
+	first, unsubscribeFirst := statusBroker.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := statusBroker.Subscribe()
+	defer unsubscribeSecond()
+
+	change := &dto.ContainerStatusChangeDTO{Type: dto.ChangeTypeUpdated, ContainerID: "container123"}
+	statusBroker.Publish(change)
+
+	assert.Same(t, change, <-first)
+	assert.Same(t, change, <-second)
+}
+
+func TestContainerStatusBroker_DropsSlowSubscriber(t *testing.T) {
+	statusBroker := broker.NewContainerStatusBroker(1, newTestLogger())
+
+	changes, unsubscribe := statusBroker.Subscribe()
+
+	statusBroker.Publish(&dto.ContainerStatusChangeDTO{Type: dto.ChangeTypeUpdated})
+	statusBroker.Publish(&dto.ContainerStatusChangeDTO{Type: dto.ChangeTypeDeleted})
+
+	change, ok := <-changes
+	assert.True(t, ok)
+	assert.Equal(t, dto.ChangeTypeUpdated, change.Type)
+
+	_, ok = <-changes
+	assert.False(t, ok)
+
+	assert.NotPanics(t, unsubscribe)
+}
+
+func TestContainerStatusBroker_UnsubscribeClosesChannel(t *testing.T) {
+	statusBroker := broker.NewContainerStatusBroker(1, newTestLogger())
+
+	changes, unsubscribe := statusBroker.Subscribe()
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-changes
+	assert.False(t, ok)
+
+	assert.NotPanics(t, func() { statusBroker.Publish(&dto.ContainerStatusChangeDTO{}) })
+}
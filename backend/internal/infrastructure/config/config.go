@@ -2,16 +2,26 @@ package config
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server           *ServerConfig     `mapstructure:"server"     validate:"required"`
-	DB               *DBConfig         `mapstructure:"db"         validate:"required"`
-	MigrationsConfig *MigrationsConfig `mapstructure:"migrations" validate:"required"`
-	AuthAPI          *AuthAPIConfig    `mapstructure:"auth_api"   validate:"required"`
+	Server           *ServerConfig        `mapstructure:"server"        validate:"required"`
+	DB               *DBConfig            `mapstructure:"db"            validate:"required"`
+	MigrationsConfig *MigrationsConfig    `mapstructure:"migrations"    validate:"required"`
+	AuthAPI          *AuthAPIConfig       `mapstructure:"auth_api"      validate:"required"`
+	Retention        *RetentionConfig     `mapstructure:"retention"     validate:"required"`
+	Alerts           *AlertsConfig        `mapstructure:"alerts"        validate:"required"`
+	Notifications    *NotificationsConfig `mapstructure:"notifications" validate:"required"`
+	Agents           *AgentsConfig        `mapstructure:"agents"        validate:"required"`
+	Queue            *QueueConfig         `mapstructure:"queue"         validate:"required"`
 }
 
 type ServerConfig struct {
@@ -35,6 +45,90 @@ type AuthAPIConfig struct {
 	APIKey string `mapstructure:"api_key" validate:"required"`
 }
 
+// RetentionConfig controls how long ping data is kept. Raw samples are rolled
+// up into hourly buckets before they expire, so Raw must be at least an hour.
+type RetentionConfig struct {
+	Interval time.Duration `mapstructure:"interval"  validate:"required,gt=0"`
+	Raw      time.Duration `mapstructure:"raw"       validate:"required,gte=1h"`
+	Rollup1h time.Duration `mapstructure:"rollup_1h" validate:"required,gtefield=Raw"`
+}
+
+// AlertsConfig lists the alert rules. Interval is how often no_successful_ping
+// rules are checked for containers that are no longer reported.
+type AlertsConfig struct {
+	Interval time.Duration      `mapstructure:"interval" validate:"required,gt=0"`
+	Rules    []*AlertRuleConfig `mapstructure:"rules"    validate:"unique=Name,dive,required"`
+}
+
+// AlertRuleConfig is a single alert rule. ping_time rules compare the last
+// Consecutive samples against Threshold (ms), packet_loss rules compare the
+// packet loss of the last check against Threshold (percent),
+// no_successful_ping rules fire after For without a successful ping and
+// status rules compare against Value.
+type AlertRuleConfig struct {
+	Name        string        `mapstructure:"name"        validate:"required"`
+	Metric      string        `mapstructure:"metric"      validate:"required,oneof=ping_time packet_loss no_successful_ping status"`
+	Operator    string        `mapstructure:"operator"    validate:"required_unless=Metric no_successful_ping,omitempty,oneof=> >= < <= == !="`
+	Threshold   float64       `mapstructure:"threshold"   validate:"gte=0"`
+	Value       string        `mapstructure:"value"       validate:"required_if=Metric status"`
+	Consecutive int           `mapstructure:"consecutive" validate:"gte=0"`
+	For         time.Duration `mapstructure:"for"         validate:"required_if=Metric no_successful_ping,gte=0"`
+	Severity    string        `mapstructure:"severity"    validate:"omitempty,oneof=info warning critical"`
+}
+
+// NotificationsConfig lists the notification targets. Every failed delivery is
+// retried up to MaxAttempts times, doubling the backoff after each attempt.
+type NotificationsConfig struct {
+	QueueSize      int                      `mapstructure:"queue_size"      validate:"required,gt=0"`
+	Timeout        time.Duration            `mapstructure:"timeout"         validate:"required,gt=0"`
+	MaxAttempts    int                      `mapstructure:"max_attempts"    validate:"required,gt=0"`
+	InitialBackoff time.Duration            `mapstructure:"initial_backoff" validate:"required,gt=0"`
+	MaxBackoff     time.Duration            `mapstructure:"max_backoff"     validate:"required,gtefield=InitialBackoff"`
+	Webhooks       []*WebhookNotifierConfig `mapstructure:"webhooks"        validate:"dive,required"`
+	Slack          []*SlackNotifierConfig   `mapstructure:"slack"           validate:"dive,required"`
+	SMTP           []*SMTPNotifierConfig    `mapstructure:"smtp"            validate:"dive,required"`
+}
+
+// AgentsConfig tells the pingers how often to send heartbeats. An agent that
+// misses MissedHeartbeats heartbeats in a row is reported as stale.
+type AgentsConfig struct {
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval" validate:"required,gt=0"`
+	MissedHeartbeats  int           `mapstructure:"missed_heartbeats"  validate:"required,gt=0"`
+}
+
+// QueueConfig enables receiving the pinger batches from NATS next to the HTTP
+// API. Backends sharing the Group split the batches between them.
+type QueueConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	URL     string `mapstructure:"url"     validate:"required_if=Enabled true,omitempty,url"`
+	Subject string `mapstructure:"subject" validate:"required_if=Enabled true"`
+	Group   string `mapstructure:"group"   validate:"required_if=Enabled true"`
+}
+
+type WebhookNotifierConfig struct {
+	Name   string   `mapstructure:"name"   validate:"required"`
+	URL    string   `mapstructure:"url"    validate:"required,url"`
+	Secret string   `mapstructure:"secret"`
+	Types  []string `mapstructure:"types"  validate:"dive,notification_type"`
+}
+
+type SlackNotifierConfig struct {
+	Name  string   `mapstructure:"name"  validate:"required"`
+	URL   string   `mapstructure:"url"   validate:"required,url"`
+	Types []string `mapstructure:"types" validate:"dive,notification_type"`
+}
+
+type SMTPNotifierConfig struct {
+	Name     string   `mapstructure:"name"     validate:"required"`
+	Host     string   `mapstructure:"host"     validate:"required"`
+	Port     uint16   `mapstructure:"port"     validate:"required,gt=0"`
+	Username string   `mapstructure:"username"`
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"     validate:"required,email"`
+	To       []string `mapstructure:"to"       validate:"required,min=1,dive,email"`
+	Types    []string `mapstructure:"types"    validate:"dive,notification_type"`
+}
+
 func LoadConfig(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
 	viper.SetConfigType("json")
@@ -44,14 +138,76 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 
 	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		stringToDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+	if err := viper.Unmarshal(&config, decodeHook); err != nil {
 		return nil, fmt.Errorf("unable to decode into struct: %w", err)
 	}
 
 	validate := validator.New()
+	if err := validate.RegisterValidation("notification_type", validateNotificationType); err != nil {
+		return nil, fmt.Errorf("failed to register config validation: %w", err)
+	}
+
 	if err := validate.Struct(&config); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	if err := validateAlertRules(config.Alerts.Rules); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
 	return &config, nil
 }
+
+// stringToDurationHookFunc extends time.ParseDuration with a "d" (day) suffix,
+// so retention periods can be written as "7d" instead of "168h".
+func stringToDurationHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeOf(time.Duration(0)) {
+			return data, nil
+		}
+
+		value, _ := data.(string)
+		if days, found := strings.CutSuffix(value, "d"); found {
+			count, err := strconv.ParseFloat(days, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration %q: %w", value, err)
+			}
+
+			return time.Duration(count * float64(24*time.Hour)), nil
+		}
+
+		return time.ParseDuration(value)
+	}
+}
+
+// validateAlertRules checks that every operator makes sense for its metric,
+// which the struct tags alone cannot express.
+func validateAlertRules(rules []*AlertRuleConfig) error {
+	for _, rule := range rules {
+		switch rule.Metric {
+		case "ping_time", "packet_loss":
+			if rule.Operator == "==" || rule.Operator == "!=" {
+				return fmt.Errorf("alert rule %s: operator %s is not supported for %s", rule.Name, rule.Operator, rule.Metric)
+			}
+		case "status":
+			if rule.Operator != "==" && rule.Operator != "!=" {
+				return fmt.Errorf("alert rule %s: operator %s is not supported for status", rule.Name, rule.Operator)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNotificationType(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case "status_changed", "unreachable", "reachable", "removed", "alert_firing", "alert_resolved":
+		return true
+	default:
+		return false
+	}
+}
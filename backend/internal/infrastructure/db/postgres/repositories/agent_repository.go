@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+const agentColumns = "id, host_name, version, docker_version, container_count, cycle_duration, ping_interval, overrun_cycles, " +
+	"registered_at, last_heartbeat_at"
+
+type AgentRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewAgentRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.AgentRepository {
+	return &AgentRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AgentRepositoryImpl) Register(agent *domain.Agent) error {
+	r.logger.Debugf("REPOSITORIES: registering agent: %+v", agent)
+
+	query := fmt.Sprintf(`
+		INSERT INTO agents (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			host_name = EXCLUDED.host_name,
+			version = EXCLUDED.version,
+			docker_version = EXCLUDED.docker_version,
+			container_count = EXCLUDED.container_count,
+			cycle_duration = EXCLUDED.cycle_duration,
+			ping_interval = EXCLUDED.ping_interval,
+			overrun_cycles = EXCLUDED.overrun_cycles,
+			registered_at = EXCLUDED.registered_at,
+			last_heartbeat_at = EXCLUDED.last_heartbeat_at
+	`, agentColumns)
+
+	_, err := r.db.Exec(query,
+		agent.ID,
+		agent.HostName,
+		agent.Version,
+		agent.DockerVersion,
+		agent.ContainerCount,
+		agent.CycleDuration,
+		agent.PingInterval,
+		agent.OverrunCycles,
+		agent.RegisteredAt,
+		agent.LastHeartbeatAt,
+	)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to register agent %s: %v", agent.ID, err)
+		return fmt.Errorf("failed to register agent: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: agent %s registered successfully", agent.ID)
+
+	return nil
+}
+
+func (r *AgentRepositoryImpl) Heartbeat(agent *domain.Agent) (bool, error) {
+	r.logger.Debugf("REPOSITORIES: recording heartbeat of agent: %+v", agent)
+
+	query := `
+		UPDATE agents
+		SET host_name = $1, version = $2, docker_version = $3, container_count = $4, cycle_duration = $5,
+			ping_interval = $6, overrun_cycles = $7, last_heartbeat_at = $8
+		WHERE id = $9
+	`
+
+	result, err := r.db.Exec(query,
+		agent.HostName,
+		agent.Version,
+		agent.DockerVersion,
+		agent.ContainerCount,
+		agent.CycleDuration,
+		agent.PingInterval,
+		agent.OverrunCycles,
+		agent.LastHeartbeatAt,
+		agent.ID,
+	)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to record heartbeat of agent %s: %v", agent.ID, err)
+		return false, fmt.Errorf("failed to record agent heartbeat: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read affected rows: %v", err)
+		return false, fmt.Errorf("failed to record agent heartbeat: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *AgentRepositoryImpl) Find() ([]*domain.Agent, error) {
+	r.logger.Debugf("REPOSITORIES: executing agents Find")
+
+	query := fmt.Sprintf("SELECT %s FROM agents ORDER BY id", agentColumns)
+
+	var results []*domain.Agent
+	if err := r.db.Select(&results, query); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d agents", len(results))
+
+	return results, nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+const alertColumns = "id, rule_name, container_id, severity, state, message, fired_at, resolved_at"
+
+type AlertRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewAlertRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.AlertRepository {
+	return &AlertRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AlertRepositoryImpl) FindFiring(containerID string) ([]*domain.Alert, error) {
+	r.logger.Debugf("REPOSITORIES: finding firing alerts for container ID: %s", containerID)
+
+	query := fmt.Sprintf("SELECT %s FROM alerts WHERE container_id = $1 AND state = $2", alertColumns)
+
+	var results []*domain.Alert
+	if err := r.db.Select(&results, query, containerID, domain.AlertStateFiring); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+
+	return results, nil
+}
+
+// Fire relies on the partial unique index over firing alerts, so concurrent
+// updates of the same container cannot open the same alert twice.
+func (r *AlertRepositoryImpl) Fire(alert *domain.Alert) (bool, error) {
+	r.logger.Debugf("REPOSITORIES: firing alert: %+v", alert)
+
+	query := `
+		INSERT INTO alerts (rule_name, container_id, severity, state, message, fired_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (rule_name, container_id) WHERE state = 'firing' DO NOTHING
+		RETURNING id
+	`
+
+	err := r.db.QueryRowx(query,
+		alert.RuleName,
+		alert.ContainerID,
+		alert.Severity,
+		domain.AlertStateFiring,
+		alert.Message,
+		alert.FiredAt,
+	).Scan(&alert.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Debugf("REPOSITORIES: alert %s is already firing for container ID: %s", alert.RuleName, alert.ContainerID)
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to fire alert: %v", err)
+		return false, fmt.Errorf("failed to fire alert: %w", err)
+	}
+
+	alert.State = domain.AlertStateFiring
+
+	r.logger.Debugf("REPOSITORIES: alert fired with ID: %d", alert.ID)
+
+	return true, nil
+}
+
+func (r *AlertRepositoryImpl) Resolve(alert *domain.Alert, resolvedAt time.Time) (bool, error) {
+	r.logger.Debugf("REPOSITORIES: resolving alert with ID: %d", alert.ID)
+
+	query := "UPDATE alerts SET state = $1, resolved_at = $2 WHERE id = $3 AND state = $4"
+
+	result, err := r.db.Exec(query, domain.AlertStateResolved, resolvedAt, alert.ID, domain.AlertStateFiring)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to resolve alert with ID %d: %v", alert.ID, err)
+		return false, fmt.Errorf("failed to resolve alert: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read affected rows: %v", err)
+		return false, fmt.Errorf("failed to resolve alert: %w", err)
+	}
+
+	if affected == 0 {
+		r.logger.Debugf("REPOSITORIES: alert with ID %d is no longer firing", alert.ID)
+		return false, nil
+	}
+
+	alert.State = domain.AlertStateResolved
+	alert.ResolvedAt = &resolvedAt
+
+	return true, nil
+}
+
+// Find returns alerts newest first.
+func (r *AlertRepositoryImpl) Find(filter *dto.AlertFilter) ([]*domain.Alert, error) {
+	r.logger.Debugf("REPOSITORIES: executing Find alerts with filter: %+v", *filter)
+
+	query := fmt.Sprintf("SELECT %s FROM alerts", alertColumns)
+
+	var conditions []string
+	var args []interface{}
+	argCounter := 1
+
+	if filter.ContainerID != nil {
+		conditions = append(conditions, fmt.Sprintf("container_id = $%d", argCounter))
+		args = append(args, *filter.ContainerID)
+		argCounter++
+	}
+
+	if filter.RuleName != nil {
+		conditions = append(conditions, fmt.Sprintf("rule_name = $%d", argCounter))
+		args = append(args, *filter.RuleName)
+		argCounter++
+	}
+
+	if filter.State != nil {
+		conditions = append(conditions, fmt.Sprintf("state = $%d", argCounter))
+		args = append(args, *filter.State)
+		argCounter++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY fired_at DESC, id DESC"
+
+	if filter.Limit != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argCounter)
+		args = append(args, *filter.Limit)
+	}
+
+	r.logger.Debugf("REPOSITORIES: final Query: %s, Args: %+v", query, args)
+
+	var results []*domain.Alert
+	if err := r.db.Select(&results, query, args...); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d alerts", len(results))
+
+	return results, nil
+}
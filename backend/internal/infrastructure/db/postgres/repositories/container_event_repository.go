@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type ContainerEventRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewContainerEventRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.ContainerEventRepository {
+	return &ContainerEventRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ContainerEventRepositoryImpl) Create(event *domain.ContainerEvent) error {
+	r.logger.Debugf("REPOSITORIES: creating container event: %+v", event)
+
+	query := `
+		INSERT INTO container_events (container_id, name, event_type, previous_status, current_status, last_successful_ping, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowx(query,
+		event.ContainerID,
+		event.Name,
+		event.EventType,
+		event.PreviousStatus,
+		event.CurrentStatus,
+		event.LastSuccessfulPing,
+		event.OccurredAt,
+	).Scan(&event.ID)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to create container event: %v", err)
+		return fmt.Errorf("failed to create container event: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: container event created with ID: %d", event.ID)
+
+	return nil
+}
+
+// Find returns events newest first.
+func (r *ContainerEventRepositoryImpl) Find(filter *dto.ContainerEventFilter) ([]*domain.ContainerEvent, error) {
+	r.logger.Debugf("REPOSITORIES: executing Find events with filter: %+v", *filter)
+
+	query := `
+		SELECT id, container_id, name, event_type, previous_status, current_status, last_successful_ping, occurred_at
+		FROM container_events
+	`
+
+	var conditions []string
+	var args []interface{}
+	argCounter := 1
+
+	if filter.ContainerID != nil {
+		conditions = append(conditions, fmt.Sprintf("container_id = $%d", argCounter))
+		args = append(args, *filter.ContainerID)
+		argCounter++
+	}
+
+	if filter.EventType != nil {
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", argCounter))
+		args = append(args, *filter.EventType)
+		argCounter++
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", argCounter))
+		args = append(args, *filter.From)
+		argCounter++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("occurred_at <= $%d", argCounter))
+		args = append(args, *filter.To)
+		argCounter++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY occurred_at DESC, id DESC"
+
+	if filter.Limit != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argCounter)
+		args = append(args, *filter.Limit)
+	}
+
+	r.logger.Debugf("REPOSITORIES: final Query: %s, Args: %+v", query, args)
+
+	var results []*domain.ContainerEvent
+	if err := r.db.Select(&results, query, args...); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d events", len(results))
+
+	return results, nil
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+const pingHistoryColumns = "container_id, ip_address, status, ping_time, success, recorded_at"
+
+// pingHistoryOutputColumns reads a sample without address, recorded while
+// the container had none, with an empty one.
+const pingHistoryOutputColumns = "container_id, COALESCE(host(ip_address), '') AS ip_address, status, ping_time, success, recorded_at"
+
+type ContainerPingHistoryRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewContainerPingHistoryRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.ContainerPingHistoryRepository {
+	return &ContainerPingHistoryRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ContainerPingHistoryRepositoryImpl) Create(sample *domain.ContainerPingSample) error {
+	r.logger.Debugf("REPOSITORIES: creating ping history record: %+v", sample)
+
+	query := `
+		INSERT INTO container_ping_history (container_id, ip_address, status, ping_time, success, recorded_at)
+		VALUES ($1, NULLIF($2, '')::inet, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowx(query,
+		sample.ContainerID,
+		sample.IPAddress,
+		sample.Status,
+		sample.PingTime,
+		sample.Success,
+		sample.RecordedAt,
+	).Scan(&sample.ID)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to create ping history record: %v", err)
+		return fmt.Errorf("failed to create ping history record: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: ping history record created with ID: %d", sample.ID)
+
+	return nil
+}
+
+// Find returns samples in ascending time order. When a step is set, only the
+// latest sample of every step-sized bucket is kept; the limit always keeps the
+// most recent samples.
+func (r *ContainerPingHistoryRepositoryImpl) Find(
+	filter *dto.ContainerPingHistoryFilter,
+) ([]*domain.ContainerPingSample, error) {
+	r.logger.Debugf("REPOSITORIES: executing Find ping history with filter: %+v", *filter)
+
+	conditions := []string{"container_id = $1"}
+	args := []interface{}{filter.ContainerID}
+	argCounter := 2
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("recorded_at >= $%d", argCounter))
+		args = append(args, *filter.From)
+		argCounter++
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("recorded_at <= $%d", argCounter))
+		args = append(args, *filter.To)
+		argCounter++
+	}
+
+	samplesQuery := fmt.Sprintf(
+		"SELECT %s FROM container_ping_history WHERE %s",
+		pingHistoryColumns,
+		strings.Join(conditions, " AND "),
+	)
+
+	if filter.Step != nil {
+		bucket := fmt.Sprintf("date_bin(make_interval(secs => $%d), recorded_at, TIMESTAMP '2000-01-01')", argCounter)
+		args = append(args, filter.Step.Seconds())
+		argCounter++
+
+		samplesQuery = fmt.Sprintf(
+			"SELECT DISTINCT ON (%s) %s FROM container_ping_history WHERE %s ORDER BY %s, recorded_at DESC",
+			bucket,
+			pingHistoryColumns,
+			strings.Join(conditions, " AND "),
+			bucket,
+		)
+	}
+
+	latestQuery := fmt.Sprintf("SELECT %s FROM (%s) AS samples ORDER BY recorded_at DESC", pingHistoryColumns, samplesQuery)
+	if filter.Limit != nil {
+		latestQuery += fmt.Sprintf(" LIMIT $%d", argCounter)
+		args = append(args, *filter.Limit)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM (%s) AS latest ORDER BY recorded_at", pingHistoryOutputColumns, latestQuery)
+
+	r.logger.Debugf("REPOSITORIES: final Query: %s, Args: %+v", query, args)
+
+	rows, err := r.db.Queryx(query, args...)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.ContainerPingSample
+	for rows.Next() {
+		var sample domain.ContainerPingSample
+
+		err := rows.Scan(
+			&sample.ContainerID,
+			&sample.IPAddress,
+			&sample.Status,
+			&sample.PingTime,
+			&sample.Success,
+			&sample.RecordedAt,
+		)
+		if err != nil {
+			r.logger.Errorf("REPOSITORIES: failed to scan row: %v\n", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+
+		results = append(results, &sample)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to iterate rows: %v\n", err)
+		return nil, fmt.Errorf("database rows error: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d ping history records", len(results))
+
+	return results, nil
+}
+
+// Aggregate groups samples into bucket-sized intervals. Failed pings carry no
+// ping time, so they only affect the sample count and the success ratio. The
+// hours whose raw samples were purged are read from the hourly rollups, in
+// buckets of at least an hour; the p95 of such a bucket is the highest p95
+// of its hours.
+func (r *ContainerPingHistoryRepositoryImpl) Aggregate(
+	filter *dto.ContainerPingAggregateFilter,
+) ([]*domain.ContainerPingAggregate, error) {
+	r.logger.Debugf("REPOSITORIES: executing Aggregate ping history with filter: %+v", *filter)
+
+	query := `
+		WITH raw_since AS (
+			SELECT COALESCE(date_trunc('hour', MIN(recorded_at)), 'infinity'::timestamp) AS since
+			FROM container_ping_history
+			WHERE container_id = $1
+		), parts AS (
+			SELECT date_bin(make_interval(secs => $2), recorded_at, TIMESTAMP '2000-01-01') AS bucket_start,
+				MIN(ping_time) AS min_ping_time,
+				AVG(ping_time) AS avg_ping_time,
+				MAX(ping_time) AS max_ping_time,
+				percentile_cont(0.95) WITHIN GROUP (ORDER BY ping_time) AS p95_ping_time,
+				COUNT(*) AS sample_count,
+				COUNT(*) FILTER (WHERE success) AS success_count
+			FROM container_ping_history
+			WHERE container_id = $1 AND recorded_at >= $3 AND recorded_at <= $4
+			GROUP BY 1
+			UNION ALL
+			SELECT date_bin(make_interval(secs => $2), bucket_start, TIMESTAMP '2000-01-01'),
+				min_ping_time, avg_ping_time, max_ping_time, p95_ping_time, sample_count, success_count
+			FROM container_ping_rollup_1h, raw_since
+			WHERE container_id = $1
+				AND bucket_start >= date_trunc('hour', $3::timestamp) AND bucket_start <= $4
+				AND bucket_start < raw_since.since
+		)
+		SELECT bucket_start,
+			MIN(min_ping_time) AS min_ping_time,
+			SUM(avg_ping_time * success_count) /
+				NULLIF(SUM(success_count) FILTER (WHERE avg_ping_time IS NOT NULL), 0)::double precision AS avg_ping_time,
+			MAX(max_ping_time) AS max_ping_time,
+			MAX(p95_ping_time) AS p95_ping_time,
+			SUM(sample_count)::bigint AS sample_count,
+			SUM(success_count)::double precision / SUM(sample_count)::double precision AS success_ratio
+		FROM parts
+		GROUP BY bucket_start
+		ORDER BY bucket_start
+	`
+	args := []interface{}{filter.ContainerID, filter.Bucket.Seconds(), filter.From, filter.To}
+
+	r.logger.Debugf("REPOSITORIES: final Query: %s, Args: %+v", query, args)
+
+	rows, err := r.db.Queryx(query, args...)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to execute query: %v\n", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.ContainerPingAggregate
+	for rows.Next() {
+		var aggregate domain.ContainerPingAggregate
+
+		if err := rows.StructScan(&aggregate); err != nil {
+			r.logger.Errorf("REPOSITORIES: failed to scan row: %v\n", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+
+		results = append(results, &aggregate)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to iterate rows: %v\n", err)
+		return nil, fmt.Errorf("database rows error: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d buckets", len(results))
+
+	return results, nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -33,76 +35,50 @@ func (r *ContainerStatusRepositoryImpl) Find(
 	r.logger.Debugf("REPOSITORIES: executing Find with filter: %+v", *filter)
 
 	query := `
-		SELECT container_id, ip_address, name, status, ping_time, last_successful_ping, created_at, updated_at
+		SELECT container_id, host_id, ip_address, name, status, ping_time, last_successful_ping, probe_type,
+			health_status, health_failing_streak, health_last_output, packets_sent, packets_received, packet_loss_percent,
+			rtt_min_ms, rtt_avg_ms, rtt_max_ms, rtt_stddev_ms, created_at, updated_at
 		FROM container_status
 	`
 
-	var conditions []string
-	var args []interface{}
-	argCounter := 1
-
-	if filter.IPAddress != nil {
-		conditions = append(conditions, fmt.Sprintf("ip_address = $%d", argCounter))
-		args = append(args, *filter.IPAddress)
-		argCounter++
-	}
-
-	if filter.ContainerID != nil {
-		conditions = append(conditions, fmt.Sprintf("container_id = $%d", argCounter))
-		args = append(args, *filter.ContainerID)
-		argCounter++
-	}
-
-	if filter.Name != nil {
-		conditions = append(conditions, fmt.Sprintf("name = $%d", argCounter))
-		args = append(args, *filter.Name)
-		argCounter++
-	}
-
-	if filter.Status != nil {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argCounter))
-		args = append(args, *filter.Status)
-		argCounter++
-	}
+	conditions, args, argCounter := buildContainerStatusConditions(filter)
 
-	if filter.PingTimeMin != nil {
-		conditions = append(conditions, fmt.Sprintf("ping_time >= $%d", argCounter))
-		args = append(args, *filter.PingTimeMin)
-		argCounter++
-	}
+	if filter.After != nil {
+		if !dto.IsValidSortField(filter.After.Sort.Field) {
+			return nil, fmt.Errorf("invalid cursor sort field %q", filter.After.Sort.Field)
+		}
 
-	if filter.PingTimeMax != nil {
-		conditions = append(conditions, fmt.Sprintf("ping_time <= $%d", argCounter))
-		args = append(args, *filter.PingTimeMax)
-		argCounter++
-	}
+		operator := ">"
+		if filter.After.Sort.Descending {
+			operator = "<"
+		}
 
-	if filter.CreatedAtGte != nil {
-		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argCounter))
-		args = append(args, *filter.CreatedAtGte)
-		argCounter++
+		conditions = append(conditions, fmt.Sprintf(
+			"(%s, container_id) %s ($%d, $%d)",
+			filter.After.Sort.Field,
+			operator,
+			argCounter,
+			argCounter+1,
+		))
+		args = append(args, filter.After.Value, filter.After.ContainerID)
+		argCounter += 2
 	}
 
-	if filter.CreatedAtLte != nil {
-		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argCounter))
-		args = append(args, *filter.CreatedAtLte)
-		argCounter++
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	if filter.UpdatedAtGte != nil {
-		conditions = append(conditions, fmt.Sprintf("updated_at >= $%d", argCounter))
-		args = append(args, *filter.UpdatedAtGte)
-		argCounter++
-	}
+	if filter.Sort != nil {
+		if !dto.IsValidSortField(filter.Sort.Field) {
+			return nil, fmt.Errorf("invalid sort field %q", filter.Sort.Field)
+		}
 
-	if filter.UpdatedAtLte != nil {
-		conditions = append(conditions, fmt.Sprintf("updated_at <= $%d", argCounter))
-		args = append(args, *filter.UpdatedAtLte)
-		argCounter++
-	}
+		direction := "ASC"
+		if filter.Sort.Descending {
+			direction = "DESC"
+		}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+		query += fmt.Sprintf(" ORDER BY %s %s, container_id %s", filter.Sort.Field, direction, direction)
 	}
 
 	if filter.Limit != nil {
@@ -125,11 +101,23 @@ func (r *ContainerStatusRepositoryImpl) Find(
 
 		err := rows.Scan(
 			&status.ContainerID,
+			&status.HostID,
 			&status.IPAddress,
 			&status.Name,
 			&status.Status,
 			&pingTime,
 			&status.LastSuccessfulPing,
+			&status.ProbeType,
+			&status.Health.Status,
+			&status.Health.FailingStreak,
+			&status.Health.LastOutput,
+			&status.PingStats.PacketsSent,
+			&status.PingStats.PacketsReceived,
+			&status.PingStats.PacketLossPercent,
+			&status.PingStats.MinRTT,
+			&status.PingStats.AvgRTT,
+			&status.PingStats.MaxRTT,
+			&status.PingStats.StdDevRTT,
 			&status.CreatedAt,
 			&status.UpdatedAt,
 		)
@@ -142,27 +130,64 @@ func (r *ContainerStatusRepositoryImpl) Find(
 		results = append(results, &status)
 	}
 
+	if err := r.loadNetworks(results); err != nil {
+		return nil, err
+	}
+
 	r.logger.Debugf("REPOSITORIES: query executed successfully, found %d records", len(results))
 
 	return results, nil
 }
 
+func (r *ContainerStatusRepositoryImpl) Count(filter *dto.ContainerStatusFilter) (int, error) {
+	r.logger.Debugf("REPOSITORIES: executing Count with filter: %+v", *filter)
+
+	query := "SELECT COUNT(*) FROM container_status"
+
+	conditions, args, _ := buildContainerStatusConditions(filter)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.Get(&total, query, args...); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to count container statuses: %v", err)
+		return 0, fmt.Errorf("database query error: %w", err)
+	}
+
+	return total, nil
+}
+
 func (r *ContainerStatusRepositoryImpl) Create(status *domain.ContainerStatus) error {
 	r.logger.Debugf("REPOSITORIES: creating container status record: %+v", status)
 
 	query := `
-		INSERT INTO container_status (container_id, ip_address, name, status, ping_time, last_successful_ping, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO container_status (container_id, host_id, ip_address, name, status, ping_time, last_successful_ping, probe_type,
+			health_status, health_failing_streak, health_last_output, packets_sent, packets_received, packet_loss_percent,
+			rtt_min_ms, rtt_avg_ms, rtt_max_ms, rtt_stddev_ms, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 		RETURNING container_id
 	`
 
 	err := r.db.QueryRowx(query,
 		status.ContainerID,
+		status.HostID,
 		status.IPAddress,
 		status.Name,
 		status.Status,
 		status.PingTime,
 		status.LastSuccessfulPing,
+		status.ProbeType,
+		status.Health.Status,
+		status.Health.FailingStreak,
+		status.Health.LastOutput,
+		status.PingStats.PacketsSent,
+		status.PingStats.PacketsReceived,
+		status.PingStats.PacketLossPercent,
+		status.PingStats.MinRTT,
+		status.PingStats.AvgRTT,
+		status.PingStats.MaxRTT,
+		status.PingStats.StdDevRTT,
 		status.CreatedAt,
 		status.UpdatedAt,
 	).Scan(&status.ContainerID)
@@ -181,8 +206,11 @@ func (r *ContainerStatusRepositoryImpl) Update(status *domain.ContainerStatus) e
 
 	query := `
 		UPDATE container_status
-		SET name = $1, status = $2, ping_time = $3, last_successful_ping = $4, updated_at = $5, ip_address = $6
-		WHERE container_id = $7
+		SET name = $1, status = $2, ping_time = $3, last_successful_ping = $4, updated_at = $5, ip_address = $6, host_id = $7,
+			probe_type = $8, health_status = $9, health_failing_streak = $10, health_last_output = $11,
+			packets_sent = $12, packets_received = $13, packet_loss_percent = $14,
+			rtt_min_ms = $15, rtt_avg_ms = $16, rtt_max_ms = $17, rtt_stddev_ms = $18
+		WHERE container_id = $19
 	`
 
 	_, err := r.db.Exec(query,
@@ -192,6 +220,18 @@ func (r *ContainerStatusRepositoryImpl) Update(status *domain.ContainerStatus) e
 		status.LastSuccessfulPing,
 		status.UpdatedAt,
 		status.IPAddress,
+		status.HostID,
+		status.ProbeType,
+		status.Health.Status,
+		status.Health.FailingStreak,
+		status.Health.LastOutput,
+		status.PingStats.PacketsSent,
+		status.PingStats.PacketsReceived,
+		status.PingStats.PacketLossPercent,
+		status.PingStats.MinRTT,
+		status.PingStats.AvgRTT,
+		status.PingStats.MaxRTT,
+		status.PingStats.StdDevRTT,
 		status.ContainerID,
 	)
 	if err != nil {
@@ -213,6 +253,117 @@ func (r *ContainerStatusRepositoryImpl) Update(status *domain.ContainerStatus) e
 	return nil
 }
 
+// upsertContainerStatusQuery inserts a status or overwrites the stored one.
+// created_at is left out of the update, so a known container keeps it, and
+// last_successful_ping never moves back, so a late write of a failed ping
+// does not hide a newer success. An empty probe type or health status keeps
+// the stored one, or is ICMP and none for a new row, and so do ping stats
+// without packets sent, and an empty host. A row of another host is not
+// touched and no row is returned. inserted is true when the row is new.
+const upsertContainerStatusQuery = `
+	INSERT INTO container_status (container_id, host_id, ip_address, name, status, ping_time, last_successful_ping, probe_type,
+		health_status, health_failing_streak, health_last_output, packets_sent, packets_received, packet_loss_percent,
+		rtt_min_ms, rtt_avg_ms, rtt_max_ms, rtt_stddev_ms, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'icmp'), COALESCE(NULLIF($9, ''), 'none'), $10, $11,
+		$12, $13, $14, $15, $16, $17, $18, $19, $20)
+	ON CONFLICT (container_id) DO UPDATE SET
+		host_id = COALESCE(NULLIF(EXCLUDED.host_id, ''), container_status.host_id),
+		ip_address = EXCLUDED.ip_address,
+		name = EXCLUDED.name,
+		status = EXCLUDED.status,
+		ping_time = EXCLUDED.ping_time,
+		last_successful_ping = GREATEST(container_status.last_successful_ping, EXCLUDED.last_successful_ping),
+		probe_type = COALESCE(NULLIF($8, ''), container_status.probe_type),
+		health_status = COALESCE(NULLIF($9, ''), container_status.health_status),
+		health_failing_streak = CASE WHEN $9 = '' THEN container_status.health_failing_streak ELSE EXCLUDED.health_failing_streak END,
+		health_last_output = CASE WHEN $9 = '' THEN container_status.health_last_output ELSE EXCLUDED.health_last_output END,
+		packets_sent = CASE WHEN $12 = 0 THEN container_status.packets_sent ELSE EXCLUDED.packets_sent END,
+		packets_received = CASE WHEN $12 = 0 THEN container_status.packets_received ELSE EXCLUDED.packets_received END,
+		packet_loss_percent = CASE WHEN $12 = 0 THEN container_status.packet_loss_percent ELSE EXCLUDED.packet_loss_percent END,
+		rtt_min_ms = CASE WHEN $12 = 0 THEN container_status.rtt_min_ms ELSE EXCLUDED.rtt_min_ms END,
+		rtt_avg_ms = CASE WHEN $12 = 0 THEN container_status.rtt_avg_ms ELSE EXCLUDED.rtt_avg_ms END,
+		rtt_max_ms = CASE WHEN $12 = 0 THEN container_status.rtt_max_ms ELSE EXCLUDED.rtt_max_ms END,
+		rtt_stddev_ms = CASE WHEN $12 = 0 THEN container_status.rtt_stddev_ms ELSE EXCLUDED.rtt_stddev_ms END,
+		updated_at = EXCLUDED.updated_at
+	WHERE container_status.host_id IN ('', EXCLUDED.host_id) OR EXCLUDED.host_id = ''
+	RETURNING host_id, last_successful_ping, probe_type, health_status, health_failing_streak, health_last_output, packets_sent, packets_received,
+		packet_loss_percent, rtt_min_ms, rtt_avg_ms, rtt_max_ms, rtt_stddev_ms, created_at, (xmax = 0) AS inserted
+`
+
+func (r *ContainerStatusRepositoryImpl) Upsert(status *domain.ContainerStatus) (bool, error) {
+	r.logger.Debugf("REPOSITORIES: upserting container status record: %+v", status)
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to begin transaction: %v", err)
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var inserted bool
+	err = tx.QueryRowx(upsertContainerStatusQuery,
+		status.ContainerID,
+		status.HostID,
+		status.IPAddress,
+		status.Name,
+		status.Status,
+		status.PingTime,
+		status.LastSuccessfulPing,
+		status.ProbeType,
+		status.Health.Status,
+		status.Health.FailingStreak,
+		status.Health.LastOutput,
+		status.PingStats.PacketsSent,
+		status.PingStats.PacketsReceived,
+		status.PingStats.PacketLossPercent,
+		status.PingStats.MinRTT,
+		status.PingStats.AvgRTT,
+		status.PingStats.MaxRTT,
+		status.PingStats.StdDevRTT,
+		status.CreatedAt,
+		status.UpdatedAt,
+	).Scan(
+		&status.HostID,
+		&status.LastSuccessfulPing,
+		&status.ProbeType,
+		&status.Health.Status,
+		&status.Health.FailingStreak,
+		&status.Health.LastOutput,
+		&status.PingStats.PacketsSent,
+		&status.PingStats.PacketsReceived,
+		&status.PingStats.PacketLossPercent,
+		&status.PingStats.MinRTT,
+		&status.PingStats.AvgRTT,
+		&status.PingStats.MaxRTT,
+		&status.PingStats.StdDevRTT,
+		&status.CreatedAt,
+		&inserted,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Warnf("REPOSITORIES: container status for ID %s belongs to another host than %s", status.ContainerID, status.HostID)
+		return false, fmt.Errorf("container %s: %w", status.ContainerID, appRepo.ErrHostMismatch)
+	}
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to upsert container status for ID %s: %v", status.ContainerID, err)
+		return false, fmt.Errorf("failed to upsert container status: %w", err)
+	}
+
+	if err = r.replaceNetworks(tx, status); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to commit upsert of container status for ID %s: %v", status.ContainerID, err)
+		return false, fmt.Errorf("failed to commit upsert: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: container status for ID %s upserted, inserted: %t", status.ContainerID, inserted)
+
+	return inserted, nil
+}
+
 func (r *ContainerStatusRepositoryImpl) DeleteByContainerID(containerID string) error {
 	r.logger.Debugf("REPOSITORIES: deleting container status record for container id: %s", containerID)
 
@@ -235,3 +386,325 @@ func (r *ContainerStatusRepositoryImpl) DeleteByContainerID(containerID string)
 
 	return nil
 }
+
+func (r *ContainerStatusRepositoryImpl) ApplyBatch(
+	statuses []*domain.ContainerStatus,
+	deleteIDs []string,
+) ([]string, error) {
+	r.logger.Debugf("REPOSITORIES: applying batch of %d statuses and %d deletions", len(statuses), len(deleteIDs))
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to begin transaction: %v", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var skipped []string
+	for _, status := range statuses {
+		var rows sql.Result
+		rows, err = tx.Exec(upsertContainerStatusQuery,
+			status.ContainerID,
+			status.HostID,
+			status.IPAddress,
+			status.Name,
+			status.Status,
+			status.PingTime,
+			status.LastSuccessfulPing,
+			status.ProbeType,
+			status.Health.Status,
+			status.Health.FailingStreak,
+			status.Health.LastOutput,
+			status.PingStats.PacketsSent,
+			status.PingStats.PacketsReceived,
+			status.PingStats.PacketLossPercent,
+			status.PingStats.MinRTT,
+			status.PingStats.AvgRTT,
+			status.PingStats.MaxRTT,
+			status.PingStats.StdDevRTT,
+			status.CreatedAt,
+			status.UpdatedAt,
+		)
+		if err != nil {
+			r.logger.Errorf("REPOSITORIES: failed to upsert container status for ID %s: %v", status.ContainerID, err)
+			return nil, fmt.Errorf("failed to upsert container status %s: %w", status.ContainerID, err)
+		}
+		if affected, err := rows.RowsAffected(); err == nil && affected == 0 {
+			r.logger.Warnf("REPOSITORIES: container status for ID %s belongs to another host than %s", status.ContainerID, status.HostID)
+			skipped = append(skipped, status.ContainerID)
+			continue
+		}
+
+		if err = r.replaceNetworks(tx, status); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		if _, err = tx.Exec("DELETE FROM container_status WHERE container_id = ANY($1)", deleteIDs); err != nil {
+			r.logger.Errorf("REPOSITORIES: failed to delete container statuses: %v", err)
+			return nil, fmt.Errorf("failed to delete container statuses: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to commit batch: %v", err)
+		return nil, fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: batch applied successfully, %d statuses skipped", len(skipped))
+
+	return skipped, nil
+}
+
+// loadNetworks fills in the networks of statuses with a single query, ordered
+// by network name.
+func (r *ContainerStatusRepositoryImpl) loadNetworks(statuses []*domain.ContainerStatus) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.ContainerStatus, len(statuses))
+	containerIDs := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		status.Networks = []domain.ContainerNetwork{}
+		byID[status.ContainerID] = status
+		containerIDs = append(containerIDs, status.ContainerID)
+	}
+
+	query := `
+		SELECT container_id, network_name, mac_address,
+			COALESCE(host(gateway), '') AS gateway,
+			COALESCE(host(ipv4_address), '') AS ipv4_address, ipv4_ping_time,
+			COALESCE(host(ipv6_address), '') AS ipv6_address, ipv6_ping_time
+		FROM container_networks
+		WHERE container_id = ANY($1)
+		ORDER BY container_id, network_name
+	`
+
+	rows, err := r.db.Queryx(query, containerIDs)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to query container networks: %v", err)
+		return fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var containerID string
+		var network domain.ContainerNetwork
+
+		err := rows.Scan(
+			&containerID,
+			&network.NetworkName,
+			&network.MACAddress,
+			&network.Gateway,
+			&network.IPv4Address,
+			&network.IPv4PingTime,
+			&network.IPv6Address,
+			&network.IPv6PingTime,
+		)
+		if err != nil {
+			r.logger.Errorf("REPOSITORIES: failed to scan container network row: %v", err)
+			return fmt.Errorf("database scan error: %w", err)
+		}
+
+		byID[containerID].Networks = append(byID[containerID].Networks, network)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read container networks: %v", err)
+		return fmt.Errorf("database query error: %w", err)
+	}
+
+	return nil
+}
+
+// replaceNetworks overwrites the stored networks of status. Nil networks
+// were not reported and are left as they are.
+func (r *ContainerStatusRepositoryImpl) replaceNetworks(tx *sqlx.Tx, status *domain.ContainerStatus) error {
+	if status.Networks == nil {
+		return nil
+	}
+
+	if _, err := tx.Exec("DELETE FROM container_networks WHERE container_id = $1", status.ContainerID); err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to delete networks of container %s: %v", status.ContainerID, err)
+		return fmt.Errorf("failed to delete container networks: %w", err)
+	}
+
+	query := `
+		INSERT INTO container_networks (
+			container_id, network_name, mac_address, gateway, ipv4_address, ipv4_ping_time, ipv6_address, ipv6_ping_time
+		)
+		VALUES ($1, $2, $3, NULLIF($4, '')::inet, NULLIF($5, '')::inet, $6, NULLIF($7, '')::inet, $8)
+	`
+
+	for _, network := range status.Networks {
+		_, err := tx.Exec(query,
+			status.ContainerID,
+			network.NetworkName,
+			network.MACAddress,
+			network.Gateway,
+			network.IPv4Address,
+			network.IPv4PingTime,
+			network.IPv6Address,
+			network.IPv6PingTime,
+		)
+		if err != nil {
+			r.logger.Errorf(
+				"REPOSITORIES: failed to insert network %s of container %s: %v",
+				network.NetworkName,
+				status.ContainerID,
+				err,
+			)
+			return fmt.Errorf("failed to insert container network: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildContainerStatusConditions translates the filter fields into WHERE
+// conditions. Limit, Sort and After are left to the caller. It also returns
+// the number of the next placeholder.
+func buildContainerStatusConditions(filter *dto.ContainerStatusFilter) ([]string, []interface{}, int) {
+	var conditions []string
+	var args []interface{}
+	argCounter := 1
+
+	if filter.IPAddress != nil {
+		condition := fmt.Sprintf("ip_address <<= $%d::inet", argCounter)
+		conditions = append(conditions, negateCondition(condition, filter.IPAddress.Negate))
+		args = append(args, filter.IPAddress.Prefix.String())
+		argCounter++
+	}
+
+	if filter.ContainerID != nil {
+		conditions = append(conditions, fmt.Sprintf("container_id = $%d", argCounter))
+		args = append(args, *filter.ContainerID)
+		argCounter++
+	}
+
+	if filter.ContainerIDs != nil {
+		conditions = append(conditions, fmt.Sprintf("container_id = ANY($%d)", argCounter))
+		args = append(args, filter.ContainerIDs)
+		argCounter++
+	}
+
+	if filter.HostID != nil {
+		conditions = append(conditions, fmt.Sprintf("host_id = $%d", argCounter))
+		args = append(args, *filter.HostID)
+		argCounter++
+	}
+
+	if filter.Name != nil {
+		condition := fmt.Sprintf(`name LIKE $%d ESCAPE '\'`, argCounter)
+		conditions = append(conditions, negateCondition(condition, filter.Name.Negate))
+		args = append(args, globToLike(filter.Name.Pattern))
+		argCounter++
+	}
+
+	if filter.Status != nil && len(filter.Status.Values) > 0 {
+		placeholders := make([]string, 0, len(filter.Status.Values))
+		for _, value := range filter.Status.Values {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", argCounter))
+			args = append(args, value)
+			argCounter++
+		}
+
+		condition := fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", "))
+		conditions = append(conditions, negateCondition(condition, filter.Status.Negate))
+	}
+
+	if filter.Health != nil && len(filter.Health.Values) > 0 {
+		placeholders := make([]string, 0, len(filter.Health.Values))
+		for _, value := range filter.Health.Values {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", argCounter))
+			args = append(args, value)
+			argCounter++
+		}
+
+		condition := fmt.Sprintf("health_status IN (%s)", strings.Join(placeholders, ", "))
+		conditions = append(conditions, negateCondition(condition, filter.Health.Negate))
+	}
+
+	if filter.PingTimeMin != nil {
+		conditions = append(conditions, fmt.Sprintf("ping_time >= $%d", argCounter))
+		args = append(args, *filter.PingTimeMin)
+		argCounter++
+	}
+
+	if filter.PingTimeMax != nil {
+		conditions = append(conditions, fmt.Sprintf("ping_time <= $%d", argCounter))
+		args = append(args, *filter.PingTimeMax)
+		argCounter++
+	}
+
+	if filter.LastSuccessfulPingGte != nil {
+		conditions = append(conditions, fmt.Sprintf("last_successful_ping >= $%d", argCounter))
+		args = append(args, *filter.LastSuccessfulPingGte)
+		argCounter++
+	}
+
+	if filter.LastSuccessfulPingLte != nil {
+		conditions = append(conditions, fmt.Sprintf("last_successful_ping <= $%d", argCounter))
+		args = append(args, *filter.LastSuccessfulPingLte)
+		argCounter++
+	}
+
+	if filter.CreatedAtGte != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argCounter))
+		args = append(args, *filter.CreatedAtGte)
+		argCounter++
+	}
+
+	if filter.CreatedAtLte != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argCounter))
+		args = append(args, *filter.CreatedAtLte)
+		argCounter++
+	}
+
+	if filter.UpdatedAtGte != nil {
+		conditions = append(conditions, fmt.Sprintf("updated_at >= $%d", argCounter))
+		args = append(args, *filter.UpdatedAtGte)
+		argCounter++
+	}
+
+	if filter.UpdatedAtLte != nil {
+		conditions = append(conditions, fmt.Sprintf("updated_at <= $%d", argCounter))
+		args = append(args, *filter.UpdatedAtLte)
+		argCounter++
+	}
+
+	return conditions, args, argCounter
+}
+
+func negateCondition(condition string, negate bool) string {
+	if negate {
+		return "NOT (" + condition + ")"
+	}
+
+	return condition
+}
+
+// globToLike turns a glob pattern into a LIKE pattern, escaping the LIKE
+// wildcards so they are matched literally.
+func globToLike(pattern string) string {
+	var like strings.Builder
+	for _, char := range pattern {
+		switch char {
+		case '\\', '%', '_':
+			like.WriteRune('\\')
+			like.WriteRune(char)
+		case '*':
+			like.WriteRune('%')
+		case '?':
+			like.WriteRune('_')
+		default:
+			like.WriteRune(char)
+		}
+	}
+
+	return like.String()
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type NotificationDeliveryRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewNotificationDeliveryRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.NotificationDeliveryRepository {
+	return &NotificationDeliveryRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *NotificationDeliveryRepositoryImpl) Create(delivery *domain.NotificationDelivery) error {
+	r.logger.Debugf("REPOSITORIES: creating notification delivery record: %+v", delivery)
+
+	query := `
+		INSERT INTO notification_deliveries (notifier, notification_type, container_id, attempts, success, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowx(query,
+		delivery.Notifier,
+		delivery.NotificationType,
+		delivery.ContainerID,
+		delivery.Attempts,
+		delivery.Success,
+		delivery.Error,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to create notification delivery record: %v", err)
+		return fmt.Errorf("failed to create notification delivery record: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: notification delivery record created with ID: %d", delivery.ID)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	appRepo "github.com/repyg/DockerMonitoringApp/backend/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type PingRetentionRepositoryImpl struct {
+	db     *sqlx.DB
+	logger utils.LoggerInterface
+}
+
+func NewPingRetentionRepositoryImpl(
+	db *sqlx.DB,
+	logger utils.LoggerInterface,
+) appRepo.PingRetentionRepository {
+	return &PingRetentionRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RollupHourly aggregates the raw samples of every complete hour before until
+// into hourly buckets. An hour is computed again whenever it gained samples
+// since its rollup, so samples arriving late, e.g. replayed by a pinger or
+// from a host lagging behind, are included. Raw samples are only purged in
+// whole hours, so an hour with fewer samples than its rollup has lost its
+// raw data and keeps the rollup.
+func (r *PingRetentionRepositoryImpl) RollupHourly(until time.Time) (int64, error) {
+	r.logger.Debugf("REPOSITORIES: rolling up ping history until: %s", until)
+
+	query := `
+		WITH counts AS (
+			SELECT container_id, date_trunc('hour', recorded_at) AS bucket_start, COUNT(*) AS sample_count
+			FROM container_ping_history
+			WHERE recorded_at < date_trunc('hour', $1::timestamp)
+			GROUP BY container_id, bucket_start
+		), changed AS (
+			SELECT counts.container_id, counts.bucket_start
+			FROM counts
+			LEFT JOIN container_ping_rollup_1h rollup
+				ON rollup.container_id = counts.container_id AND rollup.bucket_start = counts.bucket_start
+			WHERE rollup.sample_count IS NULL OR counts.sample_count > rollup.sample_count
+		)
+		INSERT INTO container_ping_rollup_1h (
+			container_id, bucket_start, min_ping_time, avg_ping_time, max_ping_time, p95_ping_time, sample_count, success_count
+		)
+		SELECT history.container_id,
+			changed.bucket_start,
+			MIN(history.ping_time),
+			AVG(history.ping_time),
+			MAX(history.ping_time),
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY history.ping_time),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE history.success)
+		FROM changed
+		JOIN container_ping_history history
+			ON history.container_id = changed.container_id
+			AND history.recorded_at >= changed.bucket_start
+			AND history.recorded_at < changed.bucket_start + INTERVAL '1 hour'
+		GROUP BY history.container_id, changed.bucket_start
+		ON CONFLICT (container_id, bucket_start) DO UPDATE SET
+			min_ping_time = EXCLUDED.min_ping_time,
+			avg_ping_time = EXCLUDED.avg_ping_time,
+			max_ping_time = EXCLUDED.max_ping_time,
+			p95_ping_time = EXCLUDED.p95_ping_time,
+			sample_count = EXCLUDED.sample_count,
+			success_count = EXCLUDED.success_count
+	`
+
+	result, err := r.db.Exec(query, until)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to roll up ping history: %v", err)
+		return 0, fmt.Errorf("failed to roll up ping history: %w", err)
+	}
+
+	rolledUp, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read rolled up rows count: %v", err)
+		return 0, fmt.Errorf("failed to read rolled up rows count: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: rolled up %d hourly buckets", rolledUp)
+
+	return rolledUp, nil
+}
+
+// DeleteRawBefore deletes the raw samples of the complete hours before
+// before, so an hour is either fully covered by raw samples or only by its
+// rollup.
+func (r *PingRetentionRepositoryImpl) DeleteRawBefore(before time.Time) (int64, error) {
+	r.logger.Debugf("REPOSITORIES: deleting ping history recorded before: %s", before)
+
+	result, err := r.db.Exec(`DELETE FROM container_ping_history WHERE recorded_at < date_trunc('hour', $1::timestamp)`, before)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to delete ping history: %v", err)
+		return 0, fmt.Errorf("failed to delete ping history: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read deleted rows count: %v", err)
+		return 0, fmt.Errorf("failed to read deleted rows count: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: deleted %d ping history records", deleted)
+
+	return deleted, nil
+}
+
+func (r *PingRetentionRepositoryImpl) DeleteRollupsBefore(before time.Time) (int64, error) {
+	r.logger.Debugf("REPOSITORIES: deleting hourly rollups started before: %s", before)
+
+	result, err := r.db.Exec(`DELETE FROM container_ping_rollup_1h WHERE bucket_start < $1`, before)
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to delete hourly rollups: %v", err)
+		return 0, fmt.Errorf("failed to delete hourly rollups: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Errorf("REPOSITORIES: failed to read deleted rows count: %v", err)
+		return 0, fmt.Errorf("failed to read deleted rows count: %w", err)
+	}
+
+	r.logger.Debugf("REPOSITORIES: deleted %d hourly rollups", deleted)
+
+	return deleted, nil
+}
@@ -0,0 +1,27 @@
+package notifiers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+// typeFilter limits a notifier to the configured notification types; an empty
+// filter accepts every type.
+type typeFilter []string
+
+func (f typeFilter) Accepts(notificationType string) bool {
+	return len(f) == 0 || slices.Contains(f, notificationType)
+}
+
+// formatTitle renders a one-line description shared by the text based formats.
+func formatTitle(notification *domain.Notification) string {
+	name := notification.Name
+	if name == "" {
+		name = notification.ContainerID
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", strings.ToUpper(notification.Severity), name, notification.Summary)
+}
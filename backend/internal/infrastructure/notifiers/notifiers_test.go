@@ -0,0 +1,171 @@
+package notifiers_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/notifiers"
+)
+
+func newTestNotification() *domain.Notification {
+	return &domain.Notification{
+		Type:        domain.NotificationTypeAlertFiring,
+		ContainerID: "container123",
+		Name:        "web",
+		Severity:    "critical",
+		Summary:     "alert exited is firing: status is exited",
+		OccurredAt:  time.Date(2025, 2, 9, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifier_SignsPayload(t *testing.T) {
+	var body []byte
+	var signature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(notifiers.SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := notifiers.NewWebhookNotifier("ops", server.URL, "s3cret", nil, server.Client())
+
+	err := notifier.Notify(context.Background(), newTestNotification())
+	require.NoError(t, err)
+
+	var payload domain.Notification
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "container123", payload.ContainerID)
+	assert.Equal(t, "sha256="+notifiers.Sign(body, "s3cret"), signature)
+}
+
+func TestWebhookNotifier_FailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := notifiers.NewWebhookNotifier("ops", server.URL, "", nil, server.Client())
+
+	err := notifier.Notify(context.Background(), newTestNotification())
+	assert.ErrorContains(t, err, "502")
+}
+
+func TestWebhookNotifier_FiltersTypes(t *testing.T) {
+	notifier := notifiers.NewWebhookNotifier("ops", "http://localhost", "", []string{domain.EventTypeUnreachable}, nil)
+
+	assert.True(t, notifier.Accepts(domain.EventTypeUnreachable))
+	assert.False(t, notifier.Accepts(domain.NotificationTypeAlertFiring))
+}
+
+func TestSlackNotifier_SendsText(t *testing.T) {
+	var message struct {
+		Text string `json:"text"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&message)
+	}))
+	defer server.Close()
+
+	notifier := notifiers.NewSlackNotifier("on-call", server.URL, nil, server.Client())
+
+	err := notifier.Notify(context.Background(), newTestNotification())
+	require.NoError(t, err)
+	assert.Equal(t, "[CRITICAL] web: alert exited is firing: status is exited", message.Text)
+}
+
+func TestSMTPNotifier_SendsMail(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go serveSMTPSink(listener, received)
+
+	port, err := strconv.Atoi(strings.Split(listener.Addr().String(), ":")[1])
+	require.NoError(t, err)
+
+	notifier := notifiers.NewSMTPNotifier(
+		"mail",
+		"127.0.0.1",
+		uint16(port),
+		"",
+		"",
+		"monitoring@example.com",
+		[]string{"ops@example.com"},
+		nil,
+	)
+
+	err = notifier.Notify(context.Background(), newTestNotification())
+	require.NoError(t, err)
+
+	select {
+	case data := <-received:
+		assert.Contains(t, data, "Subject: [CRITICAL] web: alert exited is firing: status is exited")
+		assert.Contains(t, data, "To: ops@example.com")
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp sink received no message")
+	}
+}
+
+// serveSMTPSink accepts a single SMTP session and reports the DATA section.
+func serveSMTPSink(listener net.Listener, received chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case command == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+
+			received <- data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
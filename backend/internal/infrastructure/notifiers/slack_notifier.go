@@ -0,0 +1,59 @@
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	appNotifiers "github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+// SlackNotifier posts to Slack incoming webhooks or any service accepting the
+// same payload (Mattermost, Rocket.Chat).
+type SlackNotifier struct {
+	typeFilter
+	name   string
+	url    string
+	client *http.Client
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func NewSlackNotifier(
+	name string,
+	url string,
+	types []string,
+	client *http.Client,
+) appNotifiers.Notifier {
+	return &SlackNotifier{
+		typeFilter: types,
+		name:       name,
+		url:        url,
+		client:     client,
+	}
+}
+
+func (n *SlackNotifier) Name() string {
+	return n.name
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	body, err := json.Marshal(slackMessage{Text: formatTitle(notification)})
+	if err != nil {
+		return fmt.Errorf("failed to encode slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return doRequest(n.client, req)
+}
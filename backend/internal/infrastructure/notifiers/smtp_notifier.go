@@ -0,0 +1,91 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	appNotifiers "github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+type SMTPNotifier struct {
+	typeFilter
+	name     string
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func NewSMTPNotifier(
+	name string,
+	host string,
+	port uint16,
+	username string,
+	password string,
+	from string,
+	to []string,
+	types []string,
+) appNotifiers.Notifier {
+	return &SMTPNotifier{
+		typeFilter: types,
+		name:       name,
+		addr:       net.JoinHostPort(host, strconv.Itoa(int(port))),
+		host:       host,
+		username:   username,
+		password:   password,
+		from:       from,
+		to:         to,
+	}
+}
+
+func (n *SMTPNotifier) Name() string {
+	return n.name
+}
+
+// Notify uses STARTTLS when the server offers it. net/smtp has no context
+// support, so cancellation is only checked before sending.
+func (n *SMTPNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	if err := smtp.SendMail(n.addr, auth, n.from, n.to, n.message(notification)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
+
+func (n *SMTPNotifier) message(notification *domain.Notification) []byte {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "From: %s\r\n", n.from)
+	fmt.Fprintf(&builder, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&builder, "Subject: %s\r\n", formatTitle(notification))
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	fmt.Fprintf(&builder, "Container: %s (%s)\r\n", notification.Name, notification.ContainerID)
+	fmt.Fprintf(&builder, "Type: %s\r\n", notification.Type)
+	fmt.Fprintf(&builder, "Severity: %s\r\n", notification.Severity)
+	fmt.Fprintf(&builder, "Occurred at: %s\r\n", notification.OccurredAt.UTC().Format(time.RFC3339))
+	builder.WriteString("\r\n")
+	builder.WriteString(notification.Summary)
+	builder.WriteString("\r\n")
+
+	return []byte(builder.String())
+}
@@ -0,0 +1,88 @@
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	appNotifiers "github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body,
+// prefixed with "sha256=", when the webhook has a secret.
+const SignatureHeader = "X-Signature-256"
+
+type WebhookNotifier struct {
+	typeFilter
+	name   string
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookNotifier(
+	name string,
+	url string,
+	secret string,
+	types []string,
+	client *http.Client,
+) appNotifiers.Notifier {
+	return &WebhookNotifier{
+		typeFilter: types,
+		name:       name,
+		url:        url,
+		secret:     secret,
+		client:     client,
+	}
+}
+
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, n.secret))
+	}
+
+	return doRequest(n.client, req)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of body.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
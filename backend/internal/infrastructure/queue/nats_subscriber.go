@@ -0,0 +1,101 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// MessageHandler processes a message body and returns the body of the reply.
+type MessageHandler func(data []byte) []byte
+
+// NATSSubscriber feeds the messages of a subject to a handler and replies to
+// the senders waiting for an answer. Subscribers sharing the group split the
+// messages between them, so every message is handled by a single backend.
+type NATSSubscriber struct {
+	url     string
+	subject string
+	group   string
+	handler MessageHandler
+	logger  utils.LoggerInterface
+	conn    *nats.Conn
+	closed  chan struct{}
+}
+
+func NewNATSSubscriber(
+	url, subject, group string,
+	handler MessageHandler,
+	logger utils.LoggerInterface,
+) *NATSSubscriber {
+	return &NATSSubscriber{
+		url:     url,
+		subject: subject,
+		group:   group,
+		handler: handler,
+		logger:  logger,
+		closed:  make(chan struct{}),
+	}
+}
+
+// Start connects in the background, so the backend comes up even while the
+// NATS server is not reachable yet.
+func (s *NATSSubscriber) Start() error {
+	s.logger.Infof("QUEUE: subscribing to %s as %s on %s", s.subject, s.group, s.url)
+
+	conn, err := nats.Connect(s.url,
+		nats.Name("docker-monitoring-backend"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			s.logger.Warnf("QUEUE: disconnected from NATS: %v", err)
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			s.logger.Infof("QUEUE: reconnected to NATS at %s", conn.ConnectedUrl())
+		}),
+		nats.ClosedHandler(func(_ *nats.Conn) {
+			close(s.closed)
+		}),
+	)
+	if err != nil {
+		s.logger.Errorf("QUEUE: failed to connect to NATS: %v", err)
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	if _, err := conn.QueueSubscribe(s.subject, s.group, s.handle); err != nil {
+		conn.Close()
+		s.logger.Errorf("QUEUE: failed to subscribe to %s: %v", s.subject, err)
+		return fmt.Errorf("failed to subscribe to %s: %w", s.subject, err)
+	}
+
+	s.conn = conn
+
+	return nil
+}
+
+// Stop lets the messages already received finish before closing.
+func (s *NATSSubscriber) Stop() {
+	if s.conn == nil {
+		return
+	}
+
+	if err := s.conn.Drain(); err != nil {
+		s.logger.Errorf("QUEUE: failed to drain NATS connection: %v", err)
+		s.conn.Close()
+	}
+
+	<-s.closed
+}
+
+func (s *NATSSubscriber) handle(msg *nats.Msg) {
+	reply := s.handler(msg.Data)
+
+	if msg.Reply == "" {
+		return
+	}
+
+	if err := msg.Respond(reply); err != nil {
+		s.logger.Errorf("QUEUE: failed to reply on %s: %v", s.subject, err)
+	}
+}
@@ -0,0 +1,112 @@
+package queue_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/queue"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/consumers"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+const subject = "container_status.batch"
+
+// startNATSServer runs an in-process NATS server on a free port.
+func startNATSServer(t *testing.T) string {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second), "NATS server did not start")
+
+	return srv.ClientURL()
+}
+
+// newLogger accepts any log call, the messages are not under test.
+func newLogger() *mocks.LoggerInterface {
+	logger := new(mocks.LoggerInterface)
+	for args := 1; args <= 4; args++ {
+		anything := make([]interface{}, args)
+		for i := range anything {
+			anything[i] = mock.Anything
+		}
+		for _, method := range []string{"Debugf", "Infof", "Warnf", "Errorf"} {
+			logger.On(method, anything...).Maybe().Return()
+		}
+	}
+
+	return logger
+}
+
+// request sends body like a pinger and decodes the reply of the backend.
+func request(t *testing.T, url, body string) pdto.ContainerStatusBatchReply {
+	t.Helper()
+
+	conn, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	msg, err := conn.Request(subject, []byte(body), 5*time.Second)
+	require.NoError(t, err)
+
+	var reply pdto.ContainerStatusBatchReply
+	require.NoError(t, json.Unmarshal(msg.Data, &reply))
+
+	return reply
+}
+
+func startSubscriber(t *testing.T, useCase *mocks.ContainerStatusUseCaseInterface) string {
+	t.Helper()
+
+	url := startNATSServer(t)
+	logger := newLogger()
+	consumer := consumers.NewContainerStatusConsumer(useCase, logger)
+
+	subscriber := queue.NewNATSSubscriber(url, subject, "backend", consumer.HandleBatch, logger)
+	require.NoError(t, subscriber.Start())
+	t.Cleanup(subscriber.Stop)
+
+	return url
+}
+
+func TestNATSSubscriber_AppliesBatchAndReplies(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockUseCase.On("ApplyContainerStatusBatch", mock.MatchedBy(func(batch *adto.ContainerStatusBatchDTO) bool {
+		return batch.HostID == "node-1" && len(batch.Statuses) == 1 && batch.Statuses[0].ContainerID == "web"
+	})).Return(&adto.ContainerStatusBatchResultDTO{Created: 1, Deleted: 2}, nil)
+
+	url := startSubscriber(t, mockUseCase)
+
+	reply := request(t, url, `{"host_id":"node-1","statuses":[`+
+		`{"container_id":"web","ip_address":"172.18.0.2","name":"/web","status":"running","ping_time":0.42}],`+
+		`"live_container_ids":["web"]}`)
+
+	assert.Empty(t, reply.Error)
+	assert.False(t, reply.Rejected)
+	assert.Equal(t, pdto.ContainerStatusBatchResponse{Created: 1, Deleted: 2}, reply.ContainerStatusBatchResponse)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestNATSSubscriber_InvalidBatch_RepliesRejected(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+
+	url := startSubscriber(t, mockUseCase)
+
+	reply := request(t, url, `{"statuses":[{"container_id":"web","status":"sleeping"}],"live_container_ids":[]}`)
+
+	assert.NotEmpty(t, reply.Error)
+	assert.True(t, reply.Rejected)
+	mockUseCase.AssertNotCalled(t, "ApplyContainerStatusBatch", mock.Anything)
+}
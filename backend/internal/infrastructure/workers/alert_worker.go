@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// AlertWorker checks the no_successful_ping rules on every interval, so they
+// also fire for containers that are no longer reported.
+type AlertWorker struct {
+	useCase  usecases.AlertUseCaseInterface
+	interval time.Duration
+	logger   utils.LoggerInterface
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewAlertWorker(
+	useCase usecases.AlertUseCaseInterface,
+	interval time.Duration,
+	logger utils.LoggerInterface,
+) *AlertWorker {
+	return &AlertWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (w *AlertWorker) Start() {
+	w.logger.Infof("ALERTS: starting worker with interval %s", w.interval)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				if err := w.useCase.CheckNoSuccessfulPing(time.Now()); err != nil {
+					w.logger.Errorf("ALERTS: checking alert rules failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop waits for an in-flight check to finish before returning.
+func (w *AlertWorker) Stop() {
+	w.logger.Info("ALERTS: stopping worker")
+
+	close(w.stop)
+	w.wg.Wait()
+
+	w.logger.Info("ALERTS: worker stopped")
+}
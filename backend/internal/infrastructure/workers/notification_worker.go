@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// NotificationWorker queues notifications and delivers them in the background,
+// so slow or unreachable notification targets never delay status updates.
+type NotificationWorker struct {
+	useCase usecases.NotificationUseCaseInterface
+	queue   chan *domain.Notification
+	logger  utils.LoggerInterface
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func NewNotificationWorker(
+	useCase usecases.NotificationUseCaseInterface,
+	queueSize int,
+	logger utils.LoggerInterface,
+) *NotificationWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &NotificationWorker{
+		useCase: useCase,
+		queue:   make(chan *domain.Notification, queueSize),
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Dispatch drops the notification when the queue is full rather than block.
+func (w *NotificationWorker) Dispatch(notification *domain.Notification) {
+	select {
+	case w.queue <- notification:
+	default:
+		w.logger.Warnf("NOTIFICATIONS: queue is full, dropping %s notification for container ID %s",
+			notification.Type, notification.ContainerID)
+	}
+}
+
+func (w *NotificationWorker) Start() {
+	w.logger.Infof("NOTIFICATIONS: starting worker with queue size %d", cap(w.queue))
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		for {
+			select {
+			case <-w.ctx.Done():
+				return
+			case notification := <-w.queue:
+				w.useCase.Deliver(w.ctx, notification)
+			}
+		}
+	}()
+}
+
+// Stop aborts pending retries and drops queued notifications.
+func (w *NotificationWorker) Stop() {
+	w.logger.Info("NOTIFICATIONS: stopping worker")
+
+	w.cancel()
+	w.wg.Wait()
+
+	w.logger.Infof("NOTIFICATIONS: worker stopped, %d queued notifications dropped", len(w.queue))
+}
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// RetentionWorker runs ping data compaction right after start and then on
+// every interval until stopped.
+type RetentionWorker struct {
+	useCase  usecases.RetentionUseCaseInterface
+	interval time.Duration
+	logger   utils.LoggerInterface
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewRetentionWorker(
+	useCase usecases.RetentionUseCaseInterface,
+	interval time.Duration,
+	logger utils.LoggerInterface,
+) *RetentionWorker {
+	return &RetentionWorker{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (w *RetentionWorker) Start() {
+	w.logger.Infof("RETENTION: starting worker with interval %s", w.interval)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.runOnce()
+
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for an in-flight compaction to finish before returning.
+func (w *RetentionWorker) Stop() {
+	w.logger.Info("RETENTION: stopping worker")
+
+	close(w.stop)
+	w.wg.Wait()
+
+	w.logger.Info("RETENTION: worker stopped")
+}
+
+func (w *RetentionWorker) runOnce() {
+	report, err := w.useCase.Compact(time.Now())
+	if err != nil {
+		w.logger.Errorf("RETENTION: compaction failed: %v", err)
+		return
+	}
+
+	w.logger.Infof(
+		"RETENTION: rolled up %d hourly buckets, purged %d raw samples and %d hourly rollups",
+		report.RolledUpBuckets,
+		report.PurgedRawSamples,
+		report.PurgedRollups,
+	)
+}
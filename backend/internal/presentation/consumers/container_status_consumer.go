@@ -0,0 +1,72 @@
+package consumers
+
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// ContainerStatusConsumer applies the batches the pingers publish to the
+// queue. It takes the body of POST /container_status/batch and feeds the same
+// use case, so both transports behave alike.
+type ContainerStatusConsumer struct {
+	useCase  usecases.ContainerStatusUseCaseInterface
+	validate *validator.Validate
+	logger   utils.LoggerInterface
+}
+
+func NewContainerStatusConsumer(
+	useCase usecases.ContainerStatusUseCaseInterface,
+	logger utils.LoggerInterface,
+) *ContainerStatusConsumer {
+	return &ContainerStatusConsumer{
+		useCase:  useCase,
+		validate: validator.New(),
+		logger:   logger,
+	}
+}
+
+// HandleBatch applies a single message and returns the encoded reply.
+func (c *ContainerStatusConsumer) HandleBatch(data []byte) []byte {
+	c.logger.Debugf("CONSUMERS: received container status batch")
+
+	return c.encodeReply(c.applyBatch(data))
+}
+
+func (c *ContainerStatusConsumer) applyBatch(data []byte) pdto.ContainerStatusBatchReply {
+	var req pdto.ContainerStatusBatchRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		c.logger.Errorf("CONSUMERS: container status batch decode error: %v", err)
+		return pdto.ContainerStatusBatchReply{Error: "Invalid message body", Rejected: true}
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		c.logger.Errorf("CONSUMERS: container status batch validation error: %v", err)
+		return pdto.ContainerStatusBatchReply{Error: "Validation error: " + err.Error(), Rejected: true}
+	}
+
+	batch := mapper.MapBatchRequestToAppDTO(req)
+
+	result, err := c.useCase.ApplyContainerStatusBatch(&batch)
+	if err != nil {
+		c.logger.Errorf("CONSUMERS: container status batch error: %v", err)
+		return pdto.ContainerStatusBatchReply{Error: "Failed to apply container status batch"}
+	}
+
+	return pdto.ContainerStatusBatchReply{ContainerStatusBatchResponse: mapper.MapBatchResultToResponse(*result)}
+}
+
+func (c *ContainerStatusConsumer) encodeReply(reply pdto.ContainerStatusBatchReply) []byte {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		c.logger.Errorf("CONSUMERS: error encoding reply: %v", err)
+		return []byte(`{"error":"Internal Server Error"}`)
+	}
+
+	return data
+}
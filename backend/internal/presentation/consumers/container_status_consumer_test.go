@@ -0,0 +1,90 @@
+package consumers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/consumers"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+func decodeReply(t *testing.T, data []byte) pdto.ContainerStatusBatchReply {
+	t.Helper()
+
+	var reply pdto.ContainerStatusBatchReply
+	assert.NoError(t, json.Unmarshal(data, &reply))
+
+	return reply
+}
+
+func TestHandleBatch_AppliesBatch(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	consumer := consumers.NewContainerStatusConsumer(mockUseCase, mockLogger)
+
+	body := `{"host_id":"node-1","statuses":[` +
+		`{"container_id":"web","ip_address":"192.168.1.101","status":"running","ping_time":12,"last_successful_ping":"2025-02-09T12:00:00Z"}],` +
+		`"live_container_ids":["web","idle"]}`
+
+	mockLogger.On("Debugf", mock.Anything).Return()
+	mockUseCase.On("ApplyContainerStatusBatch", mock.MatchedBy(func(batch *adto.ContainerStatusBatchDTO) bool {
+		return batch.HostID == "node-1" && len(batch.Statuses) == 1 &&
+			batch.Statuses[0].ContainerID == "web" && len(batch.LiveContainerIDs) == 2
+	})).Return(&adto.ContainerStatusBatchResultDTO{Updated: 1, Deleted: 1}, nil)
+
+	reply := decodeReply(t, consumer.HandleBatch([]byte(body)))
+
+	assert.Empty(t, reply.Error)
+	assert.Equal(t, pdto.ContainerStatusBatchResponse{Updated: 1, Deleted: 1}, reply.ContainerStatusBatchResponse)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestHandleBatch_InvalidMessage_RepliesWithError(t *testing.T) {
+	bodies := []string{
+		`{`,
+		`{"statuses":[]}`,
+		`{"statuses":[{"container_id":"web","status":"sleeping"}],"live_container_ids":[]}`,
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			consumer := consumers.NewContainerStatusConsumer(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			reply := decodeReply(t, consumer.HandleBatch([]byte(body)))
+
+			assert.NotEmpty(t, reply.Error)
+			assert.True(t, reply.Rejected)
+			mockUseCase.AssertNotCalled(t, "ApplyContainerStatusBatch", mock.Anything)
+		})
+	}
+}
+
+func TestHandleBatch_UseCaseError_RepliesWithError(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	consumer := consumers.NewContainerStatusConsumer(mockUseCase, mockLogger)
+
+	mockLogger.On("Debugf", mock.Anything).Return()
+	mockLogger.On("Errorf", "CONSUMERS: container status batch error: %v", mock.Anything).Return()
+	mockUseCase.On("ApplyContainerStatusBatch", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	reply := decodeReply(t, consumer.HandleBatch([]byte(`{"host_id":"node-1","live_container_ids":[]}`)))
+
+	assert.Equal(t, "Failed to apply container status batch", reply.Error)
+	assert.False(t, reply.Rejected)
+	mockLogger.AssertExpectations(t)
+}
@@ -4,16 +4,148 @@ import "time"
 
 type CreateContainerStatusRequest struct {
 	ContainerID        string    `json:"container_id" validate:"required"`
+	HostID             string    `json:"host_id" validate:"max=255"`
 	IPAddress          string    `json:"ip_address" validate:"required,ip"`
 	Name               string    `json:"name"`
 	Status             string    `json:"status" validate:"required,oneof=created restarting running removing paused exited dead"`
 	PingTime           float64   `json:"ping_time"`
 	LastSuccessfulPing time.Time `json:"last_successful_ping" validate:"required"`
+	ProbeType          string    `json:"probe_type" validate:"omitempty,oneof=icmp tcp http https dns"`
 }
 
 type UpdateContainerStatusRequest struct {
+	HostID             string    `json:"host_id" validate:"max=255"`
 	Name               string    `json:"name"`
 	Status             string    `json:"status" validate:"omitempty,oneof=created restarting running removing paused exited dead"`
 	PingTime           float64   `json:"ping_time"`
 	LastSuccessfulPing time.Time `json:"last_successful_ping,omitempty"`
+	ProbeType          string    `json:"probe_type" validate:"omitempty,oneof=icmp tcp http https dns"`
+}
+
+// UpsertContainerStatusRequest is the full state of a container sent with
+// PUT. last_successful_ping is omitted when the ping failed, and probe_type
+// keeps the stored probe type when omitted.
+type UpsertContainerStatusRequest struct {
+	HostID             string    `json:"host_id" validate:"max=255"`
+	IPAddress          string    `json:"ip_address" validate:"required,ip"`
+	Name               string    `json:"name"`
+	Status             string    `json:"status" validate:"required,oneof=created restarting running removing paused exited dead"`
+	PingTime           float64   `json:"ping_time"`
+	LastSuccessfulPing time.Time `json:"last_successful_ping,omitempty"`
+	ProbeType          string    `json:"probe_type" validate:"omitempty,oneof=icmp tcp http https dns"`
+	// Health replaces the stored health of the container when present.
+	Health *ContainerHealthRequest `json:"health,omitempty"`
+	// PingStats replaces the stored ping stats of the container when present.
+	PingStats *PingStatsRequest `json:"ping_stats,omitempty"`
+	// Networks replaces the stored networks of the container when present.
+	Networks []ContainerNetworkRequest `json:"networks,omitempty" validate:"omitempty,dive"`
+}
+
+// ContainerHealthRequest is the state of the Docker HEALTHCHECK of a
+// container; status is none when it has no healthcheck.
+type ContainerHealthRequest struct {
+	Status        string `json:"status" validate:"required,oneof=none starting healthy unhealthy"`
+	FailingStreak int    `json:"failing_streak" validate:"gte=0"`
+	LastOutput    string `json:"last_output" validate:"max=4096"`
+}
+
+// PingStatsRequest sums up the attempts of the last check of a container.
+// The round trip times are in milliseconds.
+type PingStatsRequest struct {
+	PacketsSent       int     `json:"packets_sent" validate:"gte=0"`
+	PacketsReceived   int     `json:"packets_received" validate:"gte=0,ltefield=PacketsSent"`
+	PacketLossPercent float64 `json:"packet_loss_percent" validate:"gte=0,lte=100"`
+	MinRTT            float64 `json:"rtt_min_ms" validate:"gte=0"`
+	AvgRTT            float64 `json:"rtt_avg_ms" validate:"gte=0"`
+	MaxRTT            float64 `json:"rtt_max_ms" validate:"gte=0"`
+	StdDevRTT         float64 `json:"rtt_stddev_ms" validate:"gte=0"`
+}
+
+// ContainerNetworkRequest is a network the container is attached to, with the
+// ping time of each of its addresses. A ping time is null when the address
+// did not answer.
+type ContainerNetworkRequest struct {
+	NetworkName  string   `json:"network_name" validate:"required,max=255"`
+	MACAddress   string   `json:"mac_address" validate:"omitempty,mac"`
+	Gateway      string   `json:"gateway" validate:"omitempty,ip"`
+	IPv4Address  string   `json:"ipv4_address" validate:"omitempty,ipv4"`
+	IPv4PingTime *float64 `json:"ipv4_ping_time"`
+	IPv6Address  string   `json:"ipv6_address" validate:"omitempty,ipv6"`
+	IPv6PingTime *float64 `json:"ipv6_ping_time"`
+}
+
+// ContainerStatusBatchItem is a status reported in a batch. The IP address
+// may be empty for a container without network, and last_successful_ping is
+// omitted when the ping failed. ping_time is in milliseconds, -1 when the
+// ping failed. probe_type is how the container was checked: icmp, tcp, http,
+// https or dns.
+type ContainerStatusBatchItem struct {
+	ContainerID        string                    `json:"container_id" validate:"required"`
+	IPAddress          string                    `json:"ip_address" validate:"omitempty,ip"`
+	Name               string                    `json:"name"`
+	Status             string                    `json:"status" validate:"required,oneof=created restarting running removing paused exited dead"`
+	PingTime           float64                   `json:"ping_time"`
+	LastSuccessfulPing time.Time                 `json:"last_successful_ping,omitempty"`
+	ProbeType          string                    `json:"probe_type" validate:"omitempty,oneof=icmp tcp http https dns"`
+	Health             *ContainerHealthRequest   `json:"health,omitempty"`
+	PingStats          *PingStatsRequest         `json:"ping_stats,omitempty"`
+	Networks           []ContainerNetworkRequest `json:"networks,omitempty" validate:"omitempty,dive"`
+}
+
+// ContainerStatusBatchRequest carries a whole ping cycle of one agent.
+// live_container_ids lists every container present on the host, statuses of
+// the host not listed there nor in statuses are deleted. checked_at is when
+// the cycle ran; a pinger replaying batches it could not deliver sets it, so
+// the history keeps the time of the ping rather than of the delivery. It
+// also sets replayed on them, as their live_container_ids are outdated and
+// no status is deleted for them.
+type ContainerStatusBatchRequest struct {
+	HostID           string                     `json:"host_id" validate:"max=255"`
+	CheckedAt        time.Time                  `json:"checked_at,omitempty"`
+	Replayed         bool                       `json:"replayed,omitempty"`
+	Statuses         []ContainerStatusBatchItem `json:"statuses" validate:"dive"`
+	LiveContainerIDs []string                   `json:"live_container_ids" validate:"required,dive,required"`
+}
+
+// ContainerStatusSubscriptionFilter uses the query param names and value
+// syntax of GET /container_status, so a subscription filters the same way.
+type ContainerStatusSubscriptionFilter struct {
+	ContainerID           *string    `json:"container_id,omitempty"`
+	HostID                *string    `json:"host_id,omitempty"`
+	IPAddress             *string    `json:"ip,omitempty"`
+	Name                  *string    `json:"name,omitempty"`
+	Status                *string    `json:"status,omitempty"`
+	Health                *string    `json:"health,omitempty"`
+	PingTimeMin           *float64   `json:"ping_time_min,omitempty"`
+	PingTimeMax           *float64   `json:"ping_time_max,omitempty"`
+	LastSuccessfulPingGte *time.Time `json:"last_successful_ping_gte,omitempty"`
+	LastSuccessfulPingLte *time.Time `json:"last_successful_ping_lte,omitempty"`
+	CreatedAtGte          *time.Time `json:"created_at_gte,omitempty"`
+	CreatedAtLte          *time.Time `json:"created_at_lte,omitempty"`
+	UpdatedAtGte          *time.Time `json:"updated_at_gte,omitempty"`
+	UpdatedAtLte          *time.Time `json:"updated_at_lte,omitempty"`
+}
+
+type ContainerStatusSubscriptionRequest struct {
+	Action string                             `json:"action" validate:"required,oneof=subscribe unsubscribe"`
+	Filter *ContainerStatusSubscriptionFilter `json:"filter"`
+}
+
+// AgentHeartbeatRequest carries the state of a pinger. CycleDuration is the
+// length of its last ping cycle and PingInterval the time between two
+// cycles, both in milliseconds. OverrunCycles counts the cycles that took
+// longer than the interval since the pinger started.
+type AgentHeartbeatRequest struct {
+	HostName       string  `json:"host_name" validate:"max=255"`
+	Version        string  `json:"version" validate:"max=64"`
+	DockerVersion  string  `json:"docker_version" validate:"max=64"`
+	ContainerCount int     `json:"container_count" validate:"gte=0"`
+	CycleDuration  float64 `json:"cycle_duration" validate:"gte=0"`
+	PingInterval   float64 `json:"ping_interval" validate:"gte=0"`
+	OverrunCycles  int     `json:"overrun_cycles" validate:"gte=0"`
+}
+
+type RegisterAgentRequest struct {
+	ID string `json:"id" validate:"required,max=255"`
+	AgentHeartbeatRequest
 }
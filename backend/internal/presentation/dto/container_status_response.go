@@ -3,16 +3,146 @@ package dto
 import "time"
 
 type GetContainerStatusResponse struct {
-	ContainerID        string    `json:"container_id"`
-	Name               string    `json:"name"`
-	IPAddress          string    `json:"ip_address"`
-	Status             string    `json:"status"`
-	PingTime           float64   `json:"ping_time"`
-	LastSuccessfulPing time.Time `json:"last_successful_ping"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ContainerID        string                     `json:"container_id"`
+	HostID             string                     `json:"host_id"`
+	Name               string                     `json:"name"`
+	IPAddress          string                     `json:"ip_address"`
+	Status             string                     `json:"status"`
+	PingTime           float64                    `json:"ping_time"`
+	LastSuccessfulPing time.Time                  `json:"last_successful_ping"`
+	ProbeType          string                     `json:"probe_type"`
+	Health             ContainerHealthResponse    `json:"health"`
+	PingStats          PingStatsResponse          `json:"ping_stats"`
+	CreatedAt          time.Time                  `json:"created_at"`
+	UpdatedAt          time.Time                  `json:"updated_at"`
+	Networks           []ContainerNetworkResponse `json:"networks"`
+}
+
+type ContainerHealthResponse struct {
+	Status        string `json:"status"`
+	FailingStreak int    `json:"failing_streak"`
+	LastOutput    string `json:"last_output"`
+}
+
+type PingStatsResponse struct {
+	PacketsSent       int     `json:"packets_sent"`
+	PacketsReceived   int     `json:"packets_received"`
+	PacketLossPercent float64 `json:"packet_loss_percent"`
+	MinRTT            float64 `json:"rtt_min_ms"`
+	AvgRTT            float64 `json:"rtt_avg_ms"`
+	MaxRTT            float64 `json:"rtt_max_ms"`
+	StdDevRTT         float64 `json:"rtt_stddev_ms"`
+}
+
+type ContainerNetworkResponse struct {
+	NetworkName  string   `json:"network_name"`
+	MACAddress   string   `json:"mac_address"`
+	Gateway      string   `json:"gateway"`
+	IPv4Address  string   `json:"ipv4_address"`
+	IPv4PingTime *float64 `json:"ipv4_ping_time"`
+	IPv6Address  string   `json:"ipv6_address"`
+	IPv6PingTime *float64 `json:"ipv6_ping_time"`
+}
+
+type ContainerStatusChangeResponse struct {
+	Type        string                      `json:"type"`
+	ContainerID string                      `json:"container_id"`
+	Status      *GetContainerStatusResponse `json:"status"`
+}
+
+type ContainerStatusSubscriptionResponse struct {
+	Type    string                             `json:"type"`
+	Filter  *ContainerStatusSubscriptionFilter `json:"filter,omitempty"`
+	Message string                             `json:"message,omitempty"`
+}
+
+type ContainerStatusBatchResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
 }
 
 type DeleteContainerStatusResponse struct {
 	Message string `json:"message"`
 }
+
+type ContainerPingSampleResponse struct {
+	ContainerID string    `json:"container_id"`
+	IPAddress   string    `json:"ip_address"`
+	Status      string    `json:"status"`
+	PingTime    *float64  `json:"ping_time"`
+	Success     bool      `json:"success"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+type ContainerPingAggregateResponse struct {
+	BucketStart  time.Time `json:"bucket_start"`
+	MinPingTime  *float64  `json:"min_ping_time"`
+	AvgPingTime  *float64  `json:"avg_ping_time"`
+	MaxPingTime  *float64  `json:"max_ping_time"`
+	P95PingTime  *float64  `json:"p95_ping_time"`
+	SampleCount  int64     `json:"sample_count"`
+	SuccessRatio float64   `json:"success_ratio"`
+}
+
+type ContainerEventResponse struct {
+	ID                 int64      `json:"id"`
+	ContainerID        string     `json:"container_id"`
+	Name               string     `json:"name"`
+	EventType          string     `json:"event_type"`
+	PreviousStatus     string     `json:"previous_status"`
+	CurrentStatus      string     `json:"current_status"`
+	LastSuccessfulPing *time.Time `json:"last_successful_ping"`
+	OccurredAt         time.Time  `json:"occurred_at"`
+}
+
+type AlertResponse struct {
+	ID          int64      `json:"id"`
+	RuleName    string     `json:"rule_name"`
+	ContainerID string     `json:"container_id"`
+	Severity    string     `json:"severity"`
+	State       string     `json:"state"`
+	Message     string     `json:"message"`
+	FiredAt     time.Time  `json:"fired_at"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+}
+
+type AlertRuleResponse struct {
+	Name        string  `json:"name"`
+	Metric      string  `json:"metric"`
+	Operator    string  `json:"operator,omitempty"`
+	Threshold   float64 `json:"threshold,omitempty"`
+	Value       string  `json:"value,omitempty"`
+	Consecutive int     `json:"consecutive,omitempty"`
+	For         string  `json:"for,omitempty"`
+	Severity    string  `json:"severity"`
+}
+
+type AgentResponse struct {
+	ID              string    `json:"id"`
+	HostName        string    `json:"host_name"`
+	Version         string    `json:"version"`
+	DockerVersion   string    `json:"docker_version"`
+	ContainerCount  int       `json:"container_count"`
+	CycleDuration   float64   `json:"cycle_duration"`
+	PingInterval    float64   `json:"ping_interval"`
+	OverrunCycles   int       `json:"overrun_cycles"`
+	State           string    `json:"state"`
+	RegisteredAt    time.Time `json:"registered_at"`
+	LastHeartbeatAt time.Time `json:"last_heartbeat_at"`
+}
+
+type RegisterAgentResponse struct {
+	Agent             AgentResponse `json:"agent"`
+	HeartbeatInterval string        `json:"heartbeat_interval"`
+}
+
+// ContainerStatusBatchReply answers a batch received from the queue. Error is
+// set instead of the counts when the batch was not applied, and Rejected
+// when the batch itself is invalid, so sending it again cannot succeed.
+type ContainerStatusBatchReply struct {
+	ContainerStatusBatchResponse
+	Error    string `json:"error,omitempty"`
+	Rejected bool   `json:"rejected,omitempty"`
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type AgentHandler struct {
+	useCase  usecases.AgentUseCaseInterface
+	validate *validator.Validate
+	logger   utils.LoggerInterface
+}
+
+func NewAgentHandler(
+	useCase usecases.AgentUseCaseInterface,
+	logger utils.LoggerInterface,
+) *AgentHandler {
+	return &AgentHandler{
+		useCase:  useCase,
+		validate: validator.New(),
+		logger:   logger,
+	}
+}
+
+// RegisterAgent godoc
+// @Summary Register an agent
+// @Description Registers a pinger, replacing a previous registration with the same ID. The response tells the agent how often to send heartbeats.
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Param request body dto.RegisterAgentRequest true "Agent data"
+// @Success 200 {object} dto.RegisterAgentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /agents/register [post].
+func (h *AgentHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received RegisterAgent request")
+
+	var req pdto.RegisterAgentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("HANDLERS: registerAgent decode error: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Errorf("HANDLERS: registerAgent validation error: %v", err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	appDTO := mapper.MapRegisterAgentRequestToAppDTO(req)
+
+	agent, err := h.useCase.RegisterAgent(&appDTO)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: registerAgent error: %v", err)
+		http.Error(w, "Failed to register agent", http.StatusInternalServerError)
+		return
+	}
+
+	response := pdto.RegisterAgentResponse{
+		Agent:             mapper.MapAgentDTOToResponse(*agent),
+		HeartbeatInterval: h.useCase.HeartbeatInterval().String(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RecordHeartbeat godoc
+// @Summary Record an agent heartbeat
+// @Description Stores the current state of a registered pinger. An unknown agent gets 404 and is expected to register again.
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Param agent_id path string true "Agent ID"
+// @Param request body dto.AgentHeartbeatRequest true "Agent state"
+// @Success 204 "No Content"
+// @Failure 400 {string} string "Bad Request"
+// @Failure 404 {string} string "Not Found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /agents/{agent_id}/heartbeat [post].
+func (h *AgentHandler) RecordHeartbeat(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)["agent_id"]
+
+	h.logger.Debugf("HANDLERS: received RecordHeartbeat request for agent_id: %s", agentID)
+
+	var req pdto.AgentHeartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("HANDLERS: recordHeartbeat decode error for agent_id %s: %v", agentID, err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Errorf("HANDLERS: recordHeartbeat validation error for agent_id %s: %v", agentID, err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	appDTO := mapper.MapAgentHeartbeatRequestToAppDTO(agentID, req)
+
+	if err := h.useCase.RecordHeartbeat(&appDTO); err != nil {
+		if errors.Is(err, usecases.ErrAgentNotFound) {
+			h.logger.Warnf("HANDLERS: agent %s is not registered", agentID)
+			http.Error(w, "Agent not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Errorf("HANDLERS: failed to record heartbeat for agent_id %s: %v", agentID, err)
+		http.Error(w, "Failed to record heartbeat", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAgents godoc
+// @Summary Retrieve agents
+// @Description Returns the registered pingers. An agent that missed the configured number of heartbeats in a row is stale.
+// @Tags Agents
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.AgentResponse
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /agents [get].
+func (h *AgentHandler) GetAgents(w http.ResponseWriter, _ *http.Request) {
+	h.logger.Debugf("HANDLERS: received GetAgents request")
+
+	agents, err := h.useCase.FindAgents()
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getAgents error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	response := mapper.MapAgentDTOsToResponse(agents)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,185 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+const agentID = "node-1"
+
+func TestRegisterAgent_ReturnsHeartbeatInterval(t *testing.T) {
+	mockUseCase := new(mocks.AgentUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+	jsonBody, err := json.Marshal(map[string]any{
+		"id":              agentID,
+		"host_name":       "docker-1",
+		"version":         "1.2.0",
+		"docker_version":  "27.2.0",
+		"container_count": 4,
+		"cycle_duration":  1520.5,
+	})
+	assert.NoError(t, err)
+
+	mockUseCase.On("RegisterAgent", mock.MatchedBy(func(agent *adto.AgentDTO) bool {
+		return agent.ID == agentID && agent.DockerVersion == "27.2.0" && agent.ContainerCount == 4
+	})).Return(&adto.AgentDTO{ID: agentID, State: domain.AgentStateOnline, RegisteredAt: time.Now()}, nil)
+	mockUseCase.On("HeartbeatInterval").Return(30 * time.Second)
+	mockLogger.On("Debugf", mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/agents/register", bytes.NewReader(jsonBody))
+	rec := httptest.NewRecorder()
+
+	handler.RegisterAgent(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response pdto.RegisterAgentResponse
+	err = json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, agentID, response.Agent.ID)
+	assert.Equal(t, "30s", response.HeartbeatInterval)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestRegisterAgent_InvalidBody_ReturnsBadRequest(t *testing.T) {
+	bodies := []string{
+		`{`,
+		`{"host_name":"docker-1"}`,
+		`{"id":"node-1","container_count":-1}`,
+		`{"id":"node-1","overrun_cycles":-1}`,
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			mockUseCase := new(mocks.AgentUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodPost, "/agents/register", bytes.NewBufferString(body))
+			rec := httptest.NewRecorder()
+
+			handler.RegisterAgent(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertNotCalled(t, "RegisterAgent", mock.Anything)
+		})
+	}
+}
+
+func TestRecordHeartbeat_Success(t *testing.T) {
+	mockUseCase := new(mocks.AgentUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("RecordHeartbeat", mock.MatchedBy(func(agent *adto.AgentDTO) bool {
+		return agent.ID == agentID && agent.ContainerCount == 7 && agent.CycleDuration == 2100 &&
+			agent.PingInterval == 5000 && agent.OverrunCycles == 2
+	})).Return(nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/agents/"+agentID+"/heartbeat",
+		bytes.NewBufferString(`{"container_count":7,"cycle_duration":2100,"ping_interval":5000,"overrun_cycles":2}`),
+	)
+	req = mux.SetURLVars(req, map[string]string{"agent_id": agentID})
+	rec := httptest.NewRecorder()
+
+	handler.RecordHeartbeat(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestRecordHeartbeat_UnknownAgent_ReturnsNotFound(t *testing.T) {
+	mockUseCase := new(mocks.AgentUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("RecordHeartbeat", mock.Anything).
+		Return(fmt.Errorf("agent %s: %w", agentID, usecases.ErrAgentNotFound))
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, agentID).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/agents/"+agentID+"/heartbeat", bytes.NewBufferString(`{}`))
+	req = mux.SetURLVars(req, map[string]string{"agent_id": agentID})
+	rec := httptest.NewRecorder()
+
+	handler.RecordHeartbeat(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetAgents_ReturnsDataSuccessfully(t *testing.T) {
+	mockUseCase := new(mocks.AgentUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindAgents").Return([]*adto.AgentDTO{
+		{ID: agentID, State: domain.AgentStateStale, LastHeartbeatAt: time.Now().Add(-time.Hour)},
+	}, nil)
+	mockLogger.On("Debugf", mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/agents", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetAgents(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.AgentResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, domain.AgentStateStale, response[0].State)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetAgents_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
+	mockUseCase := new(mocks.AgentUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAgentHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindAgents").Return(nil, fmt.Errorf("db error"))
+	mockLogger.On("Debugf", mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/agents", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetAgents(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
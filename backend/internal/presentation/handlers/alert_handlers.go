@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type AlertHandler struct {
+	useCase usecases.AlertUseCaseInterface
+	logger  utils.LoggerInterface
+}
+
+func NewAlertHandler(
+	useCase usecases.AlertUseCaseInterface,
+	logger utils.LoggerInterface,
+) *AlertHandler {
+	return &AlertHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// GetAlerts godoc
+// @Summary Retrieve alerts
+// @Description Returns firing and resolved alerts produced by the configured rules, newest first
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Param container_id query string false "Filter by container ID"
+// @Param rule query string false "Filter by rule name"
+// @Param state query string false "Filter by alert state" Enums(firing, resolved)
+// @Param limit query int false "Limit the number of returned alerts (default 100)"
+// @Success 200 {array} dto.AlertResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /alerts [get].
+func (h *AlertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received GetAlerts request with query: %s", r.URL.RawQuery)
+
+	queryParams := r.URL.Query()
+	filter := adto.AlertFilter{}
+
+	if containerID := queryParams.Get("container_id"); containerID != "" {
+		filter.ContainerID = &containerID
+	}
+
+	if rule := queryParams.Get("rule"); rule != "" {
+		filter.RuleName = &rule
+	}
+
+	if state := queryParams.Get("state"); state != "" {
+		if state != domain.AlertStateFiring && state != domain.AlertStateResolved {
+			h.logger.Errorf("HANDLERS: unknown state param: %s", state)
+			http.Error(w, fmt.Sprintf("invalid state param: unknown alert state %q", state), http.StatusBadRequest)
+			return
+		}
+
+		filter.State = &state
+	}
+
+	var err error
+	if filter.Limit, err = parsePositiveIntParam(queryParams, "limit"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing limit param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.useCase.FindAlerts(&filter)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getAlerts error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Debugf("HANDLERS: found %d alerts", len(alerts))
+	response := mapper.MapAlertDTOsToResponse(alerts)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetAlertRules godoc
+// @Summary Retrieve alert rules
+// @Description Returns the alert rules loaded from the alerts section of the config
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.AlertRuleResponse
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /alerts/rules [get].
+func (h *AlertHandler) GetAlertRules(w http.ResponseWriter, _ *http.Request) {
+	h.logger.Debugf("HANDLERS: received GetAlertRules request")
+
+	response := mapper.MapAlertRulesToResponse(h.useCase.Rules())
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,140 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+func TestGetAlerts_ReturnsDataSuccessfully(t *testing.T) {
+	mockUseCase := new(mocks.AlertUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAlertHandler(mockUseCase, mockLogger)
+
+	expectedAlerts := []*adto.AlertDTO{
+		{
+			ID:          1,
+			RuleName:    "exited",
+			ContainerID: containerID,
+			Severity:    "critical",
+			State:       domain.AlertStateFiring,
+			Message:     "status is exited",
+			FiredAt:     time.Now(),
+		},
+	}
+
+	mockUseCase.On("FindAlerts", mock.MatchedBy(func(filter *adto.AlertFilter) bool {
+		return *filter.ContainerID == containerID && *filter.RuleName == "exited" &&
+			*filter.State == domain.AlertStateFiring && *filter.Limit == 5
+	})).Return(expectedAlerts, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/alerts?container_id="+containerID+"&rule=exited&state=firing&limit=5",
+		http.NoBody,
+	)
+	rec := httptest.NewRecorder()
+
+	handler.GetAlerts(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.AlertResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "status is exited", response[0].Message)
+	assert.Nil(t, response[0].ResolvedAt)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetAlerts_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	queries := []string{
+		"state=pending",
+		"limit=abc",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			mockUseCase := new(mocks.AlertUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewAlertHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/alerts?"+query, http.NoBody)
+			rec := httptest.NewRecorder()
+
+			handler.GetAlerts(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAlerts_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
+	mockUseCase := new(mocks.AlertUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAlertHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindAlerts", mock.Anything).Return(nil, fmt.Errorf("database error"))
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/alerts", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetAlerts(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetAlertRules_ReturnsConfiguredRules(t *testing.T) {
+	mockUseCase := new(mocks.AlertUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewAlertHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("Rules").Return([]domain.AlertRule{
+		{Name: "unreachable", Metric: domain.AlertMetricNoSuccessfulPing, For: 2 * time.Minute, Severity: "critical"},
+	})
+	mockLogger.On("Debugf", mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/alerts/rules", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetAlertRules(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.AlertRuleResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "2m0s", response[0].For)
+
+	mockUseCase.AssertExpectations(t)
+}
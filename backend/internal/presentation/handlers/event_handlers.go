@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+type ContainerEventHandler struct {
+	useCase usecases.ContainerEventUseCaseInterface
+	logger  utils.LoggerInterface
+}
+
+func NewContainerEventHandler(
+	useCase usecases.ContainerEventUseCaseInterface,
+	logger utils.LoggerInterface,
+) *ContainerEventHandler {
+	return &ContainerEventHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// GetContainerEvents godoc
+// @Summary Retrieve container events
+// @Description Returns container state transitions (status changes, reachability changes, removals), newest first
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param container_id query string false "Filter by container ID"
+// @Param event_type query string false "Filter by event type" Enums(status_changed, unreachable, reachable, removed)
+// @Param from query string false "Start of the time range (inclusive), format: RFC3339"
+// @Param to query string false "End of the time range (inclusive), format: RFC3339"
+// @Param limit query int false "Limit the number of returned events (default 100)"
+// @Success 200 {array} dto.ContainerEventResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /events [get].
+func (h *ContainerEventHandler) GetContainerEvents(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received GetContainerEvents request with query: %s", r.URL.RawQuery)
+
+	queryParams := r.URL.Query()
+	filter := adto.ContainerEventFilter{}
+
+	if containerID := queryParams.Get("container_id"); containerID != "" {
+		filter.ContainerID = &containerID
+	}
+
+	if eventType := queryParams.Get("event_type"); eventType != "" {
+		if !domain.IsValidEventType(eventType) {
+			h.logger.Errorf("HANDLERS: unknown event_type param: %s", eventType)
+			http.Error(w, fmt.Sprintf("invalid event_type param: unknown event type %q", eventType), http.StatusBadRequest)
+			return
+		}
+
+		filter.EventType = &eventType
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(queryParams, "from"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing from param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseTimeParam(queryParams, "to"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing to param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		h.logger.Errorf("HANDLERS: invalid time range: from %s is after to %s", filter.From, filter.To)
+		http.Error(w, "from must not be after to", http.StatusBadRequest)
+		return
+	}
+
+	if filter.Limit, err = parsePositiveIntParam(queryParams, "limit"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing limit param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.useCase.FindEvents(&filter)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getContainerEvents error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Debugf("HANDLERS: found %d container events", len(events))
+	response := mapper.MapEventDTOsToResponse(events)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
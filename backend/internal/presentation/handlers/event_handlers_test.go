@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+func TestGetContainerEvents_ReturnsDataSuccessfully(t *testing.T) {
+	mockUseCase := new(mocks.ContainerEventUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerEventHandler(mockUseCase, mockLogger)
+
+	expectedEvents := []*adto.ContainerEventDTO{
+		{
+			ID:             1,
+			ContainerID:    containerID,
+			EventType:      domain.EventTypeStatusChanged,
+			PreviousStatus: "running",
+			CurrentStatus:  "exited",
+			OccurredAt:     time.Now(),
+		},
+	}
+
+	mockUseCase.On("FindEvents", mock.MatchedBy(func(filter *adto.ContainerEventFilter) bool {
+		return *filter.ContainerID == containerID &&
+			*filter.EventType == domain.EventTypeStatusChanged &&
+			filter.From != nil && filter.To != nil && *filter.Limit == 10
+	})).Return(expectedEvents, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/events?container_id="+containerID+"&event_type=status_changed&from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z&limit=10",
+		http.NoBody,
+	)
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerEvents(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.ContainerEventResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "exited", response[0].CurrentStatus)
+	assert.Nil(t, response[0].LastSuccessfulPing)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetContainerEvents_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	queries := []string{
+		"event_type=exploded",
+		"from=not-a-date",
+		"to=not-a-date",
+		"from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z",
+		"limit=-5",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerEventUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerEventHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/events?"+query, http.NoBody)
+			rec := httptest.NewRecorder()
+
+			handler.GetContainerEvents(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerEvents_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
+	mockUseCase := new(mocks.ContainerEventUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerEventHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindEvents", mock.Anything).Return(nil, fmt.Errorf("database error"))
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/events", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerEvents(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -41,17 +42,26 @@ func NewContainerStatusHandler(
 // @Accept json
 // @Produce json
 // @Param container_id query string false "Filter by container ID"
-// @Param ip query string false "Filter by IP"
-// @Param name query string false "Filter by name"
-// @Param status query string false "Filter by status"
+// @Param host_id query string false "Filter by the host the pinger runs on"
+// @Param ip query string false "Filter by IP address or CIDR network, prefix with ! to negate"
+// @Param name query string false "Filter by name, * and ? are glob wildcards, prefix with ! to negate"
+// @Param status query string false "Filter by comma separated statuses, prefix with ! to negate"
+// @Param health query string false "Filter by comma separated health statuses (none, starting, healthy, unhealthy), prefix with ! to negate"
 // @Param ping_time_min query number false "Filter by minimum ping time"
 // @Param ping_time_max query number false "Filter by maximum ping time"
+// @Param last_successful_ping_gte query string false "Filter by last successful ping (greater than or equal to), format: RFC3339"
+// @Param last_successful_ping_lte query string false "Filter by last successful ping (less than or equal to), format: RFC3339"
 // @Param created_at_gte query string false "Filter by creation date (greater than or equal to), format: RFC3339"
 // @Param created_at_lte query string false "Filter by creation date (less than or equal to), format: RFC3339"
 // @Param updated_at_gte query string false "Filter by last update date (greater than or equal to), format: RFC3339"
 // @Param updated_at_lte query string false "Filter by last update date (less than or equal to), format: RFC3339"
-// @Param limit query int false "Limit the number of returned records"
+// @Param limit query int false "Limit the number of returned records, enables the next page cursor"
+// @Param sort query string false "Sort by a column, format: field:asc|desc, default: container_id:asc"
+// @Param cursor query string false "Continue after the page that returned this X-Next-Cursor"
 // @Success 200 {array} dto.GetContainerStatusResponse
+// @Header 200 {integer} X-Total-Count "Number of containers matching the filters"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Failure 400 {string} string "Bad Request"
 // @Failure 500 {string} string "Internal Server Error"
 // @Security ApiKeyAuth
 // @Router /container_status [get].
@@ -61,112 +71,40 @@ func (h *ContainerStatusHandler) GetFilteredContainerStatuses(
 ) {
 	h.logger.Debugf("HANDLERS: received GetFilteredContainerStatuses request with query: %s", r.URL.RawQuery)
 
-	queryParams := r.URL.Query()
-	filter := adto.ContainerStatusFilter{}
-
-	if containerID := queryParams.Get("container_id"); containerID != "" {
-		filter.ContainerID = &containerID
-	}
-
-	if ip := queryParams.Get("ip"); ip != "" {
-		filter.IPAddress = &ip
-	}
-
-	if name := queryParams.Get("name"); name != "" {
-		filter.Name = &name
-	}
-
-	if status := queryParams.Get("status"); status != "" {
-		filter.Status = &status
-	}
-
-	if pingMinStr := queryParams.Get("ping_time_min"); pingMinStr != "" {
-		pingMin, err := strconv.ParseFloat(pingMinStr, 64)
-		if err == nil {
-			filter.PingTimeMin = &pingMin
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing ping_time_min param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-	}
-
-	if pingMaxStr := queryParams.Get("ping_time_max"); pingMaxStr != "" {
-		pingMax, err := strconv.ParseFloat(pingMaxStr, 64)
-		if err == nil {
-			filter.PingTimeMax = &pingMax
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing ping_time_max param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-	}
-
-	if createdAtGteStr := queryParams.Get("created_at_gte"); createdAtGteStr != "" {
-		createdAtGte, err := time.Parse(time.RFC3339, createdAtGteStr)
-		if err == nil {
-			filter.CreatedAtGte = &createdAtGte
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing created_at_gte param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
+	filter, err := parseContainerStatusFilter(r.URL.Query())
+	if err != nil {
+		h.logger.Errorf("HANDLERS: error parsing filter params: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if createdAtLteStr := queryParams.Get("created_at_lte"); createdAtLteStr != "" {
-		createdAtLte, err := time.Parse(time.RFC3339, createdAtLteStr)
-		if err == nil {
-			filter.CreatedAtLte = &createdAtLte
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing created_at_lte param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
+	if err = parsePageParams(r.URL.Query(), filter); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing page params: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	if updatedAtGteStr := queryParams.Get("updated_at_gte"); updatedAtGteStr != "" {
-		updatedAtGte, err := time.Parse(time.RFC3339, updatedAtGteStr)
-		if err == nil {
-			filter.UpdatedAtGte = &updatedAtGte
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing updated_at_gte param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
+	page, err := h.useCase.FindContainerStatusPage(filter)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getFilteredContainerStatuses error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
 	}
 
-	if updatedAtLteStr := queryParams.Get("updated_at_lte"); updatedAtLteStr != "" {
-		updatedAtLte, err := time.Parse(time.RFC3339, updatedAtLteStr)
-		if err == nil {
-			filter.UpdatedAtLte = &updatedAtLte
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing updated_at_lte param: %v", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-	}
+	h.logger.Debugf("HANDLERS: found %d of %d container statuses", len(page.Items), page.Total)
+	response := mapper.MapAppDTOsToResponse(page.Items)
 
-	if limitStr := queryParams.Get("limit"); limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err == nil {
-			filter.Limit = &limit
-		} else {
-			h.logger.Errorf("HANDLERS: error parsing limit param: %v", err)
+	if page.NextCursor != nil {
+		cursor, err := encodeCursor(page.NextCursor)
+		if err != nil {
+			h.logger.Errorf("HANDLERS: error encoding cursor: %v", err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set(NextCursorHeader, cursor)
 	}
 
-	statuses, err := h.useCase.FindContainerStatuses(&filter)
-	if err != nil {
-		h.logger.Errorf("HANDLERS: getFilteredContainerStatuses error: %v", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	h.logger.Debugf("HANDLERS: found %d container statuses", len(statuses))
-	response := mapper.MapAppDTOsToResponse(statuses)
-
+	w.Header().Set(TotalCountHeader, strconv.Itoa(page.Total))
 	w.Header().Set("Content-Type", "application/json")
 	if err = json.NewEncoder(w).Encode(response); err != nil {
 		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
@@ -225,6 +163,53 @@ func (h *ContainerStatusHandler) CreateContainerStatus(w http.ResponseWriter, r
 	}
 }
 
+// ApplyContainerStatusBatch godoc
+// @Summary Apply a batch of container statuses
+// @Description Stores the results of one ping cycle of an agent in a single transaction. Every status is created or updated, and the statuses of the host whose containers are neither in statuses nor in live_container_ids are deleted.
+// @Tags Containers
+// @Accept json
+// @Produce json
+// @Param request body dto.ContainerStatusBatchRequest true "Ping cycle results"
+// @Success 200 {object} dto.ContainerStatusBatchResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /container_status/batch [post].
+func (h *ContainerStatusHandler) ApplyContainerStatusBatch(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received ApplyContainerStatusBatch request")
+
+	var req pdto.ContainerStatusBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("HANDLERS: applyContainerStatusBatch decode error: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Errorf("HANDLERS: applyContainerStatusBatch validation error: %v", err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	batch := mapper.MapBatchRequestToAppDTO(req)
+
+	result, err := h.useCase.ApplyContainerStatusBatch(&batch)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: applyContainerStatusBatch error: %v", err)
+		http.Error(w, "Failed to apply container status batch", http.StatusInternalServerError)
+		return
+	}
+
+	response := mapper.MapBatchResultToResponse(*result)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
 // UpdateContainerStatus godoc
 // @Summary Update container by container ID
 // @Description Partially updates a container by its container ID
@@ -235,6 +220,7 @@ func (h *ContainerStatusHandler) CreateContainerStatus(w http.ResponseWriter, r
 // @Param request body dto.UpdateContainerStatusRequest true "Fields to update"
 // @Success 204
 // @Failure 400 {string} string "Bad Request"
+// @Failure 409 {string} string "Container belongs to another host"
 // @Failure 500 {string} string "Internal Server Error"
 // @Security ApiKeyAuth
 // @Router /container_status/{container_id} [patch].
@@ -260,6 +246,11 @@ func (h *ContainerStatusHandler) UpdateContainerStatus(w http.ResponseWriter, r
 	appDTO := mapper.MapUpdateRequestToAppDTO(req)
 
 	err := h.useCase.UpdateContainerStatus(containerID, &appDTO)
+	if errors.Is(err, usecases.ErrHostMismatch) {
+		h.logger.Warnf("HANDLERS: container_id %s belongs to another host than %s", containerID, appDTO.HostID)
+		http.Error(w, "Container belongs to another host", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		h.logger.Errorf("HANDLERS: failed to update container status for container_id %s: %v", containerID, err)
 		http.Error(w, "Failed to update container status", http.StatusInternalServerError)
@@ -270,6 +261,70 @@ func (h *ContainerStatusHandler) UpdateContainerStatus(w http.ResponseWriter, r
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpsertContainerStatus godoc
+// @Summary Create or replace container by container ID
+// @Description Stores the full state of a container, creating it when it is unknown. Repeating the request has no further effect, except that last_successful_ping never moves back.
+// @Tags Containers
+// @Accept json
+// @Produce json
+// @Param container_id path string true "Container ID"
+// @Param request body dto.UpsertContainerStatusRequest true "Container state"
+// @Success 200 {object} dto.GetContainerStatusResponse "Replaced"
+// @Success 201 {object} dto.GetContainerStatusResponse "Created"
+// @Failure 400 {string} string "Bad Request"
+// @Failure 409 {string} string "Container belongs to another host"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /container_status/{container_id} [put].
+func (h *ContainerStatusHandler) UpsertContainerStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	containerID := vars["container_id"]
+
+	h.logger.Debugf("HANDLERS: received UpsertContainerStatus request for container_id: %s", containerID)
+
+	var req pdto.UpsertContainerStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("HANDLERS: upsertContainerStatus decode error for container_id %s: %v", containerID, err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		h.logger.Errorf("HANDLERS: upsertContainerStatus validation error for container_id %s: %v", containerID, err)
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	appDTO := mapper.MapUpsertRequestToAppDTO(containerID, req)
+
+	status, created, err := h.useCase.UpsertContainerStatus(containerID, &appDTO)
+	if errors.Is(err, usecases.ErrHostMismatch) {
+		h.logger.Warnf("HANDLERS: container_id %s belongs to another host than %s", containerID, appDTO.HostID)
+		http.Error(w, "Container belongs to another host", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		h.logger.Errorf("HANDLERS: failed to upsert container status for container_id %s: %v", containerID, err)
+		http.Error(w, "Failed to upsert container status", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Debugf("HANDLERS: successfully upserted container status for container_id: %s", containerID)
+
+	code := http.StatusOK
+	if created {
+		code = http.StatusCreated
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(mapper.MapAppDTOToResponse(*status)); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
 // DeleteContainerStatus godoc
 // @Summary Delete container by container ID
 // @Description Deletes a container from the database
@@ -303,3 +358,176 @@ func (h *ContainerStatusHandler) DeleteContainerStatus(w http.ResponseWriter, r
 	h.logger.Debugf("HANDLERS: successfully deleted container status for container_id: %s", containerID)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetContainerPingHistory godoc
+// @Summary Retrieve ping history of a container
+// @Description Returns recorded ping samples of a container in ascending time order
+// @Tags Containers
+// @Accept json
+// @Produce json
+// @Param container_id path string true "Container ID"
+// @Param from query string false "Start of the time range (inclusive), format: RFC3339"
+// @Param to query string false "End of the time range (inclusive), format: RFC3339"
+// @Param step query string false "Keep only the latest sample per step, Go duration format (e.g. 1m, 1h)"
+// @Param limit query int false "Limit the number of returned samples, the most recent ones are kept (default 1000)"
+// @Success 200 {array} dto.ContainerPingSampleResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /container_status/{container_id}/history [get].
+func (h *ContainerStatusHandler) GetContainerPingHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	containerID := vars["container_id"]
+
+	h.logger.Debugf("HANDLERS: received GetContainerPingHistory request for container_id: %s with query: %s", containerID, r.URL.RawQuery)
+
+	queryParams := r.URL.Query()
+	filter := adto.ContainerPingHistoryFilter{ContainerID: containerID}
+
+	var err error
+	if filter.From, err = parseTimeParam(queryParams, "from"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing from param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseTimeParam(queryParams, "to"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing to param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		h.logger.Errorf("HANDLERS: invalid time range: from %s is after to %s", filter.From, filter.To)
+		http.Error(w, "from must not be after to", http.StatusBadRequest)
+		return
+	}
+
+	if filter.Step, err = parseDurationParam(queryParams, "step"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing step param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.Limit, err = parsePositiveIntParam(queryParams, "limit"); err != nil {
+		h.logger.Errorf("HANDLERS: error parsing limit param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	samples, err := h.useCase.FindContainerPingHistory(&filter)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getContainerPingHistory error for container_id %s: %v", containerID, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Debugf("HANDLERS: found %d ping history samples", len(samples))
+	response := mapper.MapSampleDTOsToResponse(samples)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+const (
+	defaultAggregateRange = 24 * time.Hour
+	maxAggregateBuckets   = 10000
+)
+
+// GetContainerPingAggregate godoc
+// @Summary Retrieve aggregated ping history of a container
+// @Description Returns per-bucket min/avg/max/p95 ping time, sample count and success ratio in ascending time order
+// @Tags Containers
+// @Accept json
+// @Produce json
+// @Param container_id path string true "Container ID"
+// @Param bucket query string true "Bucket size, Go duration format (e.g. 5m, 1h)"
+// @Param from query string false "Start of the time range (inclusive), format: RFC3339, defaults to 24h before to"
+// @Param to query string false "End of the time range (inclusive), format: RFC3339, defaults to now"
+// @Success 200 {array} dto.ContainerPingAggregateResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /container_status/{container_id}/aggregate [get].
+func (h *ContainerStatusHandler) GetContainerPingAggregate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	containerID := vars["container_id"]
+
+	h.logger.Debugf("HANDLERS: received GetContainerPingAggregate request for container_id: %s with query: %s", containerID, r.URL.RawQuery)
+
+	queryParams := r.URL.Query()
+
+	bucket, err := parseDurationParam(queryParams, "bucket")
+	if err != nil {
+		h.logger.Errorf("HANDLERS: error parsing bucket param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if bucket == nil {
+		h.logger.Errorf("HANDLERS: getContainerPingAggregate validation error: bucket param is required")
+		http.Error(w, "bucket param is required", http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseTimeParam(queryParams, "from")
+	if err != nil {
+		h.logger.Errorf("HANDLERS: error parsing from param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseTimeParam(queryParams, "to")
+	if err != nil {
+		h.logger.Errorf("HANDLERS: error parsing to param: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := adto.ContainerPingAggregateFilter{
+		ContainerID: containerID,
+		Bucket:      *bucket,
+		To:          time.Now().UTC(),
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	filter.From = filter.To.Add(-defaultAggregateRange)
+	if from != nil {
+		filter.From = *from
+	}
+
+	if filter.From.After(filter.To) {
+		h.logger.Errorf("HANDLERS: invalid time range: from %s is after to %s", filter.From, filter.To)
+		http.Error(w, "from must not be after to", http.StatusBadRequest)
+		return
+	}
+
+	if filter.To.Sub(filter.From)/filter.Bucket > maxAggregateBuckets {
+		h.logger.Errorf("HANDLERS: bucket %s is too small for range %s - %s", filter.Bucket, filter.From, filter.To)
+		http.Error(w, fmt.Sprintf("time range must not span more than %d buckets", maxAggregateBuckets), http.StatusBadRequest)
+		return
+	}
+
+	aggregates, err := h.useCase.AggregateContainerPingHistory(&filter)
+	if err != nil {
+		h.logger.Errorf("HANDLERS: getContainerPingAggregate error for container_id %s: %v", containerID, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Debugf("HANDLERS: aggregated ping history into %d buckets", len(aggregates))
+	response := mapper.MapAggregateDTOsToResponse(aggregates)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Errorf("HANDLERS: error encoding response: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
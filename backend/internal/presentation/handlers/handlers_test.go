@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
 	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
 	"github.com/repyg/DockerMonitoringApp/backend/mocks"
@@ -35,8 +36,10 @@ func TestGetContainerStatuses_ReturnsDataSuccessfully(t *testing.T) {
 		{IPAddress: ipAddress, PingTime: pingTime, LastSuccessfulPing: time.Now()},
 	}
 
-	mockUseCase.On("FindContainerStatuses", mock.Anything).Return(expectedStatuses, nil)
+	mockUseCase.On("FindContainerStatusPage", mock.Anything).
+		Return(&adto.ContainerStatusPageDTO{Items: expectedStatuses, Total: 1}, nil)
 	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
 
 	req := httptest.NewRequest(http.MethodGet, "/container_status", http.NoBody)
 	rec := httptest.NewRecorder()
@@ -44,6 +47,8 @@ func TestGetContainerStatuses_ReturnsDataSuccessfully(t *testing.T) {
 	handler.GetFilteredContainerStatuses(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(handlers.TotalCountHeader))
+	assert.Empty(t, rec.Header().Get(handlers.NextCursorHeader))
 
 	var response []pdto.GetContainerStatusResponse
 	err := json.NewDecoder(rec.Body).Decode(&response)
@@ -65,8 +70,10 @@ func TestGetContainerStatuses_WithQueryParams_ReturnsFilteredData(t *testing.T)
 		{IPAddress: ipAddress, PingTime: pingTime, LastSuccessfulPing: time.Now()},
 	}
 
-	mockUseCase.On("FindContainerStatuses", mock.Anything).Return(expectedStatuses, nil)
+	mockUseCase.On("FindContainerStatusPage", mock.Anything).
+		Return(&adto.ContainerStatusPageDTO{Items: expectedStatuses, Total: 1}, nil)
 	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
 
 	req := httptest.NewRequest(
 		http.MethodGet,
@@ -90,13 +97,44 @@ func TestGetContainerStatuses_WithQueryParams_ReturnsFilteredData(t *testing.T)
 	mockLogger.AssertExpectations(t)
 }
 
+func TestGetContainerStatuses_HostIDParam_FiltersByHost(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindContainerStatusPage", mock.MatchedBy(func(filter *adto.ContainerStatusFilter) bool {
+		return filter.HostID != nil && *filter.HostID == "node-1"
+	})).Return(&adto.ContainerStatusPageDTO{
+		Items: []*adto.ContainerStatusDTO{{ContainerID: containerID, HostID: "node-1"}},
+		Total: 1,
+	}, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status?host_id=node-1", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetFilteredContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.GetContainerStatusResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "node-1", response[0].HostID)
+
+	mockUseCase.AssertExpectations(t)
+}
+
 func TestGetContainerStatuses_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
 	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
 
-	mockUseCase.On("FindContainerStatuses", mock.Anything).Return(nil, fmt.Errorf("database error"))
+	mockUseCase.On("FindContainerStatusPage", mock.Anything).Return(nil, fmt.Errorf("database error"))
 	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
 
@@ -119,7 +157,7 @@ func TestGetContainerStatuses_InvalidID_ReturnsInternalServerError(t *testing.T)
 	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
 
-	mockUseCase.On("FindContainerStatuses", mock.Anything).
+	mockUseCase.On("FindContainerStatusPage", mock.Anything).
 		Return(nil, fmt.Errorf("invalid id")).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/container_status?container_id=invalid", http.NoBody)
@@ -132,7 +170,7 @@ func TestGetContainerStatuses_InvalidID_ReturnsInternalServerError(t *testing.T)
 	mockUseCase.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidPingTimeMin_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidPingTimeMin_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -150,11 +188,11 @@ func TestGetContainerStatuses_InvalidPingTimeMin_ReturnsInternalServerError(t *t
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidPingTimeMax_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidPingTimeMax_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -172,11 +210,11 @@ func TestGetContainerStatuses_InvalidPingTimeMax_ReturnsInternalServerError(t *t
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidCreatedAtGte_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidCreatedAtGte_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -194,11 +232,11 @@ func TestGetContainerStatuses_InvalidCreatedAtGte_ReturnsInternalServerError(t *
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidCreatedAtLte_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidCreatedAtLte_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -216,11 +254,11 @@ func TestGetContainerStatuses_InvalidCreatedAtLte_ReturnsInternalServerError(t *
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidUpdatedAtGte_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidUpdatedAtGte_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -238,11 +276,11 @@ func TestGetContainerStatuses_InvalidUpdatedAtGte_ReturnsInternalServerError(t *
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidUpdatedAtLte_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidUpdatedAtLte_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -260,11 +298,11 @@ func TestGetContainerStatuses_InvalidUpdatedAtLte_ReturnsInternalServerError(t *
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
-func TestGetContainerStatuses_InvalidLimit_ReturnsInternalServerError(t *testing.T) {
+func TestGetContainerStatuses_InvalidLimit_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
 
@@ -278,10 +316,145 @@ func TestGetContainerStatuses_InvalidLimit_ReturnsInternalServerError(t *testing
 
 	handler.GetFilteredContainerStatuses(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	mockLogger.AssertExpectations(t)
 }
 
+func TestGetContainerStatuses_FilterLanguage_ParsesMatches(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindContainerStatusPage", mock.MatchedBy(func(filter *adto.ContainerStatusFilter) bool {
+		return filter.Name.Pattern == "web-*" && filter.Name.Negate &&
+			assert.ObjectsAreEqual([]string{"running", "exited"}, filter.Status.Values) && !filter.Status.Negate &&
+			assert.ObjectsAreEqual([]string{"healthy"}, filter.Health.Values) && filter.Health.Negate &&
+			filter.IPAddress.Prefix.String() == "10.0.0.0/8" && !filter.IPAddress.Negate &&
+			filter.LastSuccessfulPingGte.Equal(time.Date(2025, 2, 9, 12, 0, 0, 0, time.UTC))
+	})).Return(&adto.ContainerStatusPageDTO{}, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/container_status?name=!web-*&status=running,exited&health=!healthy&ip=10.1.2.3/8&last_successful_ping_gte=2025-02-09T12:00:00Z",
+		http.NoBody,
+	)
+	rec := httptest.NewRecorder()
+
+	handler.GetFilteredContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetContainerStatuses_InvalidFilterLanguage_ReturnsBadRequest(t *testing.T) {
+	for name, query := range map[string]string{
+		"unknown status":       "status=running,sleeping",
+		"unknown health":       "health=sick",
+		"empty negated name":   "name=!",
+		"malformed ip":         "ip=10.0.0",
+		"malformed cidr":       "ip=10.0.0.0/33",
+		"malformed ping range": "last_successful_ping_lte=yesterday",
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/container_status?"+query, http.NoBody)
+			rec := httptest.NewRecorder()
+
+			handler.GetFilteredContainerStatuses(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertNotCalled(t, "FindContainerStatusPage", mock.Anything)
+		})
+	}
+}
+
+func TestGetContainerStatuses_NextCursor_RoundTrips(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	updatedAt := time.Date(2025, 2, 9, 12, 0, 0, 123456000, time.UTC)
+	sort := adto.ContainerStatusSort{Field: adto.SortFieldUpdatedAt, Descending: true}
+
+	mockUseCase.On("FindContainerStatusPage", mock.MatchedBy(func(filter *adto.ContainerStatusFilter) bool {
+		return filter.After == nil && *filter.Sort == sort && *filter.Limit == 1
+	})).Return(&adto.ContainerStatusPageDTO{
+		Items:      []*adto.ContainerStatusDTO{{ContainerID: containerID, UpdatedAt: updatedAt}},
+		Total:      2,
+		NextCursor: &adto.ContainerStatusCursor{Sort: sort, Value: updatedAt, ContainerID: containerID},
+	}, nil).Once()
+	mockUseCase.On("FindContainerStatusPage", mock.MatchedBy(func(filter *adto.ContainerStatusFilter) bool {
+		return filter.After != nil &&
+			*filter.Sort == sort &&
+			filter.After.Sort == sort &&
+			filter.After.ContainerID == containerID &&
+			updatedAt.Equal(filter.After.Value.(time.Time))
+	})).Return(&adto.ContainerStatusPageDTO{Items: []*adto.ContainerStatusDTO{{ContainerID: "other"}}, Total: 2}, nil).Once()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status?sort=updated_at:desc&limit=1", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.GetFilteredContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(handlers.TotalCountHeader))
+
+	cursor := rec.Header().Get(handlers.NextCursorHeader)
+	assert.NotEmpty(t, cursor)
+
+	req = httptest.NewRequest(http.MethodGet, "/container_status?limit=1&cursor="+cursor, http.NoBody)
+	rec = httptest.NewRecorder()
+
+	handler.GetFilteredContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(handlers.NextCursorHeader))
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetContainerStatuses_InvalidPageParams_ReturnsBadRequest(t *testing.T) {
+	cursor := "eyJmIjoibmFtZSIsInYiOiJ3ZWIiLCJpZCI6ImNvbnRhaW5lcjEyMyJ9" // {"f":"name","v":"web","id":"container123"}
+
+	for name, query := range map[string]string{
+		"unknown sort field":     "sort=image",
+		"unknown sort direction": "sort=name:up",
+		"malformed cursor":       "cursor=not-a-cursor",
+		"cursor of other sort":   "sort=status&cursor=" + cursor,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/container_status?"+query, http.NoBody)
+			rec := httptest.NewRecorder()
+
+			handler.GetFilteredContainerStatuses(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertNotCalled(t, "FindContainerStatusPage", mock.Anything)
+		})
+	}
+}
+
 func TestCreateContainerStatus_SuccessfullyCreatesContainer(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
@@ -395,6 +568,85 @@ func TestCreateContainerStatus_ErrorFromUseCase_ReturnsInternalServerError(t *te
 	mockLogger.AssertExpectations(t)
 }
 
+func TestApplyContainerStatusBatch_ReturnsCounts(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	body := `{"host_id":"node-1","statuses":[` +
+		`{"container_id":"web","ip_address":"192.168.1.101","status":"running","ping_time":12.5,"last_successful_ping":"2025-02-09T12:00:00Z","probe_type":"http",` +
+		`"ping_stats":{"packets_sent":4,"packets_received":3,"packet_loss_percent":25,"rtt_min_ms":11.2,"rtt_avg_ms":12.5,"rtt_max_ms":14,"rtt_stddev_ms":1.1},` +
+		`"health":{"status":"unhealthy","failing_streak":3,"last_output":"connection refused"},` +
+		`"networks":[{"network_name":"frontend","ipv4_address":"192.168.1.101","ipv4_ping_time":12,"ipv6_address":"fd00::2","ipv6_ping_time":null}]},` +
+		`{"container_id":"job","status":"exited"}],"live_container_ids":["web","job","idle"]}`
+
+	mockUseCase.On("ApplyContainerStatusBatch", mock.MatchedBy(func(batch *adto.ContainerStatusBatchDTO) bool {
+		return batch.HostID == "node-1" && len(batch.Statuses) == 2 && len(batch.LiveContainerIDs) == 3 &&
+			batch.Statuses[0].HostID == "node-1" && !batch.Statuses[0].LastSuccessfulPing.IsZero() &&
+			batch.Statuses[0].ProbeType == "http" && batch.Statuses[1].ProbeType == "" &&
+			batch.Statuses[0].Health.Status == "unhealthy" && batch.Statuses[0].Health.FailingStreak == 3 &&
+			batch.Statuses[1].Health == nil &&
+			batch.Statuses[0].PingTime == 12.5 && batch.Statuses[0].PingStats.PacketsReceived == 3 &&
+			batch.Statuses[0].PingStats.PacketLossPercent == 25 && batch.Statuses[0].PingStats.AvgRTT == 12.5 &&
+			batch.Statuses[1].PingStats == nil &&
+			len(batch.Statuses[0].Networks) == 1 && *batch.Statuses[0].Networks[0].IPv4PingTime == 12 &&
+			batch.Statuses[0].Networks[0].IPv6PingTime == nil &&
+			batch.Statuses[1].LastSuccessfulPing.IsZero() && batch.Statuses[1].Networks == nil
+	})).Return(&adto.ContainerStatusBatchResultDTO{Created: 1, Updated: 1, Deleted: 2}, nil)
+	mockLogger.On("Debugf", mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/container_status/batch", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	handler.ApplyContainerStatusBatch(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response pdto.ContainerStatusBatchResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, pdto.ContainerStatusBatchResponse{Created: 1, Updated: 1, Deleted: 2}, response)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestApplyContainerStatusBatch_InvalidBody_ReturnsBadRequest(t *testing.T) {
+	bodies := []string{
+		`{`,
+		`{"statuses":[]}`,
+		`{"statuses":[{"container_id":"web","status":"sleeping"}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","ip_address":"nope","status":"running"}],"live_container_ids":[]}`,
+		`{"live_container_ids":[""]}`,
+		`{"statuses":[{"container_id":"web","status":"running","networks":[{"ipv4_address":"10.0.0.2"}]}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","status":"running","networks":[{"network_name":"a","ipv4_address":"fd00::2"}]}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","status":"running","probe_type":"udp"}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","status":"running","health":{"status":"sick"}}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","status":"running","ping_stats":{"packets_sent":1,"packet_loss_percent":150}}],"live_container_ids":[]}`,
+		`{"statuses":[{"container_id":"web","status":"running","ping_stats":{"packets_sent":1,"packets_received":2}}],"live_container_ids":[]}`,
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodPost, "/container_status/batch", bytes.NewBufferString(body))
+			rec := httptest.NewRecorder()
+
+			handler.ApplyContainerStatusBatch(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertNotCalled(t, "ApplyContainerStatusBatch", mock.Anything)
+		})
+	}
+}
+
 func TestUpdateContainerStatus_SuccessfullyUpdatesContainer(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
@@ -465,6 +717,34 @@ func TestUpdateContainerStatus_ErrorFromUseCase_ReturnsInternalServerError(t *te
 	mockLogger.AssertExpectations(t)
 }
 
+func TestUpdateContainerStatus_OtherHost_ReturnsConflict(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	body := `{"host_id":"node-2","status":"running"}`
+
+	mockUseCase.
+		On("UpdateContainerStatus", containerID, mock.MatchedBy(func(status *adto.ContainerStatusDTO) bool {
+			return status.HostID == "node-2"
+		})).
+		Return(fmt.Errorf("container %s: %w", containerID, usecases.ErrHostMismatch)).
+		Once()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, containerID, "node-2").Return()
+
+	req := httptest.NewRequest(http.MethodPatch, "/container_status/"+containerID, bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.UpdateContainerStatus(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
 func TestUpdateContainerStatus_InvalidJSON_ReturnsBadRequest(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
@@ -520,6 +800,125 @@ func TestUpdateContainerStatus_NoFieldsProvided_ReturnsBadRequest(t *testing.T)
 	mockLogger.AssertExpectations(t)
 }
 
+func TestUpsertContainerStatus_ReturnsStatusCodeByOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		created  bool
+		wantCode int
+	}{
+		{name: "created", created: true, wantCode: http.StatusCreated},
+		{name: "replaced", created: false, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			body := `{"host_id":"node-1","ip_address":"192.168.1.101","name":"web","status":"running","ping_time":15.5}`
+
+			mockUseCase.On("UpsertContainerStatus", containerID, mock.MatchedBy(func(statusDTO *adto.ContainerStatusDTO) bool {
+				return statusDTO.ContainerID == containerID && statusDTO.HostID == "node-1" &&
+					statusDTO.IPAddress == "192.168.1.101" && statusDTO.LastSuccessfulPing.IsZero()
+			})).Return(&adto.ContainerStatusDTO{ContainerID: containerID, HostID: "node-1", Status: "running"}, tt.created, nil).Once()
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodPut, "/container_status/"+containerID, bytes.NewBufferString(body))
+			req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+			rec := httptest.NewRecorder()
+
+			handler.UpsertContainerStatus(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+
+			var response pdto.GetContainerStatusResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, containerID, response.ContainerID)
+
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpsertContainerStatus_InvalidBody_ReturnsBadRequest(t *testing.T) {
+	bodies := []string{
+		`{`,
+		`{"status":"running"}`,
+		`{"ip_address":"192.168.1.101","status":"sleeping"}`,
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodPut, "/container_status/"+containerID, bytes.NewBufferString(body))
+			req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+			rec := httptest.NewRecorder()
+
+			handler.UpsertContainerStatus(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertNotCalled(t, "UpsertContainerStatus", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpsertContainerStatus_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	body := `{"ip_address":"192.168.1.101","status":"running"}`
+
+	mockUseCase.On("UpsertContainerStatus", containerID, mock.Anything).Return(nil, false, fmt.Errorf("db error")).Once()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPut, "/container_status/"+containerID, bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.UpsertContainerStatus(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUpsertContainerStatus_OtherHost_ReturnsConflict(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	body := `{"host_id":"node-2","ip_address":"192.168.1.101","status":"running"}`
+
+	mockUseCase.On("UpsertContainerStatus", containerID, mock.Anything).
+		Return(nil, false, fmt.Errorf("container %s: %w", containerID, usecases.ErrHostMismatch)).
+		Once()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, containerID, "node-2").Return()
+
+	req := httptest.NewRequest(http.MethodPut, "/container_status/"+containerID, bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.UpsertContainerStatus(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
 func TestDeleteContainerStatus_SuccessfullyDeletesContainer(t *testing.T) {
 	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
 	mockLogger := new(mocks.LoggerInterface)
@@ -587,3 +986,194 @@ func TestDeleteContainerStatus_ContainerNotFound_ReturnsNotFound(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+func TestGetContainerPingHistory_ReturnsDataSuccessfully(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	samplePingTime := pingTime
+	expectedSamples := []*adto.ContainerPingSampleDTO{
+		{ContainerID: containerID, IPAddress: ipAddress, PingTime: &samplePingTime, Success: true, RecordedAt: time.Now()},
+	}
+
+	mockUseCase.On("FindContainerPingHistory", mock.MatchedBy(func(filter *adto.ContainerPingHistoryFilter) bool {
+		return filter.ContainerID == containerID &&
+			filter.From != nil && filter.To != nil &&
+			*filter.Step == time.Minute && *filter.Limit == 10
+	})).Return(expectedSamples, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/container_status/"+containerID+"/history?from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z&step=1m&limit=10",
+		http.NoBody,
+	)
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerPingHistory(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.ContainerPingSampleResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, pingTime, *response[0].PingTime)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetContainerPingHistory_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	queries := []string{
+		"from=not-a-date",
+		"to=not-a-date",
+		"from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z",
+		"step=not-a-duration",
+		"step=-1m",
+		"limit=0",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/container_status/"+containerID+"/history?"+query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+			rec := httptest.NewRecorder()
+
+			handler.GetContainerPingHistory(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerPingHistory_ErrorFromUseCase_ReturnsInternalServerError(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("FindContainerPingHistory", mock.Anything).Return(nil, fmt.Errorf("database error"))
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status/"+containerID+"/history", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerPingHistory(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetContainerPingAggregate_ReturnsDataSuccessfully(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	avgPingTime := pingTime
+	expectedAggregates := []*adto.ContainerPingAggregateDTO{
+		{BucketStart: from, AvgPingTime: &avgPingTime, SampleCount: 60, SuccessRatio: 1},
+	}
+
+	mockUseCase.On("AggregateContainerPingHistory", &adto.ContainerPingAggregateFilter{
+		ContainerID: containerID,
+		Bucket:      5 * time.Minute,
+		From:        from,
+		To:          from.Add(time.Hour),
+	}).Return(expectedAggregates, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/container_status/"+containerID+"/aggregate?bucket=5m&from=2023-01-01T00:00:00Z&to=2023-01-01T01:00:00Z",
+		http.NoBody,
+	)
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerPingAggregate(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []pdto.ContainerPingAggregateResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, int64(60), response[0].SampleCount)
+	assert.Equal(t, pingTime, *response[0].AvgPingTime)
+
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetContainerPingAggregate_DefaultsToLastDay(t *testing.T) {
+	mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+	mockLogger := new(mocks.LoggerInterface)
+
+	handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+	mockUseCase.On("AggregateContainerPingHistory", mock.MatchedBy(func(filter *adto.ContainerPingAggregateFilter) bool {
+		return filter.To.Sub(filter.From) == 24*time.Hour && time.Since(filter.To) < time.Minute
+	})).Return([]*adto.ContainerPingAggregateDTO{}, nil)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status/"+containerID+"/aggregate?bucket=1h", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+	rec := httptest.NewRecorder()
+
+	handler.GetContainerPingAggregate(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestGetContainerPingAggregate_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	queries := []string{
+		"",
+		"bucket=not-a-duration",
+		"bucket=0s",
+		"bucket=5m&from=not-a-date",
+		"bucket=5m&to=not-a-date",
+		"bucket=5m&from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z",
+		"bucket=1s&from=2023-01-01T00:00:00Z&to=2023-02-01T00:00:00Z",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			mockUseCase := new(mocks.ContainerStatusUseCaseInterface)
+			mockLogger := new(mocks.LoggerInterface)
+
+			handler := handlers.NewContainerStatusHandler(mockUseCase, mockLogger)
+
+			mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+			mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			req := httptest.NewRequest(http.MethodGet, "/container_status/"+containerID+"/aggregate?"+query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"container_id": containerID})
+			rec := httptest.NewRecorder()
+
+			handler.GetContainerPingAggregate(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
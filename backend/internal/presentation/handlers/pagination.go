@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+)
+
+const (
+	TotalCountHeader = "X-Total-Count"
+	NextCursorHeader = "X-Next-Cursor"
+)
+
+var errInvalidCursor = errors.New("invalid cursor param")
+
+// cursorPayload is the JSON behind the opaque cursor. Clients must not rely
+// on its layout.
+type cursorPayload struct {
+	Field       string `json:"f"`
+	Descending  bool   `json:"d,omitempty"`
+	Value       any    `json:"v"`
+	ContainerID string `json:"id"`
+}
+
+// parsePageParams reads sort and cursor into the filter. A cursor continues
+// the listing with its own sort, so a different explicit sort is rejected.
+func parsePageParams(queryParams url.Values, filter *adto.ContainerStatusFilter) error {
+	sort, err := parseSortParam(queryParams)
+	if err != nil {
+		return err
+	}
+	filter.Sort = sort
+
+	value := queryParams.Get("cursor")
+	if value == "" {
+		return nil
+	}
+
+	cursor, err := decodeCursor(value)
+	if err != nil {
+		return err
+	}
+
+	if filter.Sort == nil {
+		filter.Sort = &cursor.Sort
+	} else if *filter.Sort != cursor.Sort {
+		return errors.New("invalid cursor param: cursor was created for a different sort")
+	}
+	filter.After = cursor
+
+	return nil
+}
+
+func parseSortParam(queryParams url.Values) (*adto.ContainerStatusSort, error) {
+	value := queryParams.Get("sort")
+	if value == "" {
+		return nil, nil
+	}
+
+	field, direction, _ := strings.Cut(value, ":")
+	if !adto.IsValidSortField(field) {
+		return nil, fmt.Errorf("invalid sort param: unknown field %q", field)
+	}
+
+	sort := &adto.ContainerStatusSort{Field: field}
+	switch direction {
+	case "", "asc":
+	case "desc":
+		sort.Descending = true
+	default:
+		return nil, fmt.Errorf("invalid sort param: direction must be asc or desc, got %q", direction)
+	}
+
+	return sort, nil
+}
+
+func encodeCursor(cursor *adto.ContainerStatusCursor) (string, error) {
+	data, err := json.Marshal(cursorPayload{
+		Field:       cursor.Sort.Field,
+		Descending:  cursor.Sort.Descending,
+		Value:       cursor.Value,
+		ContainerID: cursor.ContainerID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(value string) (*adto.ContainerStatusCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err = json.Unmarshal(data, &payload); err != nil || !adto.IsValidSortField(payload.Field) || payload.Value == nil {
+		return nil, errInvalidCursor
+	}
+
+	// JSON brings strings and numbers back as they were, timestamps have to
+	// be parsed again.
+	switch payload.Field {
+	case adto.SortFieldLastSuccessfulPing, adto.SortFieldCreatedAt, adto.SortFieldUpdatedAt:
+		raw, ok := payload.Value.(string)
+		if !ok {
+			return nil, errInvalidCursor
+		}
+
+		if payload.Value, err = time.Parse(time.RFC3339Nano, raw); err != nil {
+			return nil, errInvalidCursor
+		}
+	case adto.SortFieldPingTime:
+		if _, ok := payload.Value.(float64); !ok {
+			return nil, errInvalidCursor
+		}
+	default:
+		if _, ok := payload.Value.(string); !ok {
+			return nil, errInvalidCursor
+		}
+	}
+
+	return &adto.ContainerStatusCursor{
+		Sort:        adto.ContainerStatusSort{Field: payload.Field, Descending: payload.Descending},
+		Value:       payload.Value,
+		ContainerID: payload.ContainerID,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+)
+
+// parseContainerStatusFilter builds a filter from the container status query
+// params. It is shared by the REST listing and the WebSocket subscriptions, so
+// both understand the same vocabulary.
+func parseContainerStatusFilter(queryParams url.Values) (*adto.ContainerStatusFilter, error) {
+	filter := &adto.ContainerStatusFilter{
+		ContainerID: parseStringParam(queryParams, "container_id"),
+		HostID:      parseStringParam(queryParams, "host_id"),
+	}
+
+	var err error
+	if filter.IPAddress, err = parseIPMatch("ip", queryParams.Get("ip")); err != nil {
+		return nil, err
+	}
+
+	if filter.Name, err = parsePatternMatch("name", queryParams.Get("name")); err != nil {
+		return nil, err
+	}
+
+	if filter.Status, err = parseStatusMatch("status", queryParams.Get("status")); err != nil {
+		return nil, err
+	}
+
+	if filter.Health, err = parseHealthMatch("health", queryParams.Get("health")); err != nil {
+		return nil, err
+	}
+
+	if filter.PingTimeMin, err = parseFloatParam(queryParams, "ping_time_min"); err != nil {
+		return nil, err
+	}
+
+	if filter.PingTimeMax, err = parseFloatParam(queryParams, "ping_time_max"); err != nil {
+		return nil, err
+	}
+
+	if filter.LastSuccessfulPingGte, err = parseTimeParam(queryParams, "last_successful_ping_gte"); err != nil {
+		return nil, err
+	}
+
+	if filter.LastSuccessfulPingLte, err = parseTimeParam(queryParams, "last_successful_ping_lte"); err != nil {
+		return nil, err
+	}
+
+	if filter.CreatedAtGte, err = parseTimeParam(queryParams, "created_at_gte"); err != nil {
+		return nil, err
+	}
+
+	if filter.CreatedAtLte, err = parseTimeParam(queryParams, "created_at_lte"); err != nil {
+		return nil, err
+	}
+
+	if filter.UpdatedAtGte, err = parseTimeParam(queryParams, "updated_at_gte"); err != nil {
+		return nil, err
+	}
+
+	if filter.UpdatedAtLte, err = parseTimeParam(queryParams, "updated_at_lte"); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit, err = parsePositiveIntParam(queryParams, "limit"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// parseSubscriptionFilter reads the filter of a WebSocket subscription
+// message with the same rules as parseContainerStatusFilter.
+func parseSubscriptionFilter(subscription *pdto.ContainerStatusSubscriptionFilter) (*adto.ContainerStatusFilter, error) {
+	if subscription == nil {
+		return &adto.ContainerStatusFilter{}, nil
+	}
+
+	filter := &adto.ContainerStatusFilter{
+		ContainerID:           subscription.ContainerID,
+		HostID:                subscription.HostID,
+		PingTimeMin:           subscription.PingTimeMin,
+		PingTimeMax:           subscription.PingTimeMax,
+		LastSuccessfulPingGte: subscription.LastSuccessfulPingGte,
+		LastSuccessfulPingLte: subscription.LastSuccessfulPingLte,
+		CreatedAtGte:          subscription.CreatedAtGte,
+		CreatedAtLte:          subscription.CreatedAtLte,
+		UpdatedAtGte:          subscription.UpdatedAtGte,
+		UpdatedAtLte:          subscription.UpdatedAtLte,
+	}
+
+	var err error
+	if subscription.IPAddress != nil {
+		if filter.IPAddress, err = parseIPMatch("ip", *subscription.IPAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	if subscription.Name != nil {
+		if filter.Name, err = parsePatternMatch("name", *subscription.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if subscription.Status != nil {
+		if filter.Status, err = parseStatusMatch("status", *subscription.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	if subscription.Health != nil {
+		if filter.Health, err = parseHealthMatch("health", *subscription.Health); err != nil {
+			return nil, err
+		}
+	}
+
+	return filter, nil
+}
+
+// parseNegation strips the leading ! that negates name, status, health and ip.
+func parseNegation(value string) (string, bool) {
+	if strings.HasPrefix(value, "!") {
+		return value[1:], true
+	}
+
+	return value, false
+}
+
+func parsePatternMatch(name, value string) (*adto.PatternMatch, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	pattern, negate := parseNegation(value)
+	if pattern == "" {
+		return nil, fmt.Errorf("invalid %s param: empty pattern", name)
+	}
+
+	return &adto.PatternMatch{Pattern: pattern, Negate: negate}, nil
+}
+
+func parseStatusMatch(name, value string) (*adto.SetMatch, error) {
+	return parseSetMatch(name, value, "status", domain.IsValidContainerState)
+}
+
+func parseHealthMatch(name, value string) (*adto.SetMatch, error) {
+	return parseSetMatch(name, value, "health status", domain.IsValidHealthStatus)
+}
+
+// parseSetMatch reads a comma separated list of values, each accepted by
+// isValid; kind names a value in the errors.
+func parseSetMatch(name, value, kind string, isValid func(string) bool) (*adto.SetMatch, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	list, negate := parseNegation(value)
+
+	match := &adto.SetMatch{Negate: negate}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if !isValid(item) {
+			return nil, fmt.Errorf("invalid %s param: unknown %s %q", name, kind, item)
+		}
+		match.Values = append(match.Values, item)
+	}
+
+	return match, nil
+}
+
+func parseIPMatch(name, value string) (*adto.PrefixMatch, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	network, negate := parseNegation(value)
+
+	if strings.Contains(network, "/") {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s param, expected an IP address or CIDR: %w", name, err)
+		}
+
+		return &adto.PrefixMatch{Prefix: prefix.Masked(), Negate: negate}, nil
+	}
+
+	addr, err := netip.ParseAddr(network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param, expected an IP address or CIDR: %w", name, err)
+	}
+
+	return &adto.PrefixMatch{Prefix: netip.PrefixFrom(addr, addr.BitLen()), Negate: negate}, nil
+}
+
+func parseStringParam(queryParams url.Values, name string) *string {
+	value := queryParams.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func parseFloatParam(queryParams url.Values, name string) (*float64, error) {
+	value := queryParams.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param: %w", name, err)
+	}
+
+	return &parsed, nil
+}
+
+func parseTimeParam(queryParams url.Values, name string) (*time.Time, error) {
+	value := queryParams.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param, expected RFC3339: %w", name, err)
+	}
+
+	return &parsed, nil
+}
+
+func parseDurationParam(queryParams url.Values, name string) (*time.Duration, error) {
+	value := queryParams.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param: %w", name, err)
+	}
+
+	if parsed <= 0 {
+		return nil, fmt.Errorf("invalid %s param: must be positive", name)
+	}
+
+	return &parsed, nil
+}
+
+func parsePositiveIntParam(queryParams url.Values, name string) (*int, error) {
+	value := queryParams.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s param: %w", name, err)
+	}
+
+	if parsed <= 0 {
+		return nil, fmt.Errorf("invalid %s param: must be positive", name)
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/broker"
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+// streamWriteTimeout bounds every single write to a stream, so a client that
+// stopped reading is still dropped although the server-wide WriteTimeout no
+// longer applies.
+const streamWriteTimeout = 10 * time.Second
+
+type ContainerStatusStreamHandler struct {
+	subscriber broker.ContainerStatusSubscriber
+	keepAlive  time.Duration
+	logger     utils.LoggerInterface
+}
+
+func NewContainerStatusStreamHandler(
+	subscriber broker.ContainerStatusSubscriber,
+	keepAlive time.Duration,
+	logger utils.LoggerInterface,
+) *ContainerStatusStreamHandler {
+	return &ContainerStatusStreamHandler{
+		subscriber: subscriber,
+		keepAlive:  keepAlive,
+		logger:     logger,
+	}
+}
+
+// StreamContainerStatuses godoc
+// @Summary Stream container status changes
+// @Description Streams created, updated and deleted container statuses as Server-Sent Events. The event name is the change type, the data is a JSON encoded dto.ContainerStatusChangeResponse. Comment lines are sent periodically to keep the connection alive.
+// @Tags Containers
+// @Produce text/event-stream
+// @Success 200 {object} dto.ContainerStatusChangeResponse
+// @Failure 500 {string} string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /container_status/stream [get].
+func (h *ContainerStatusStreamHandler) StreamContainerStatuses(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received StreamContainerStatuses request from %s", utils.GetClientIP(r))
+
+	rc := http.NewResponseController(w)
+
+	// The server read and write timeouts are meant for regular requests. The
+	// read deadline would cancel the request context and the write deadline
+	// would cut the stream, so both are lifted for this connection.
+	if err := rc.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Errorf("HANDLERS: failed to clear read deadline: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	changes, unsubscribe := h.subscriber.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.flush(rc); err != nil {
+		h.logger.Errorf("HANDLERS: failed to start stream: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			h.logger.Debugf("HANDLERS: stream closed by client %s", utils.GetClientIP(r))
+			return
+
+		case <-ticker.C:
+			if err := h.write(w, rc, ": keep-alive\n\n"); err != nil {
+				h.logger.Debugf("HANDLERS: stream keep-alive failed: %v", err)
+				return
+			}
+
+		case change, ok := <-changes:
+			if !ok {
+				h.logger.Warnf("HANDLERS: stream subscriber of %s fell behind, closing stream", utils.GetClientIP(r))
+				return
+			}
+
+			message, err := formatChangeEvent(change)
+			if err != nil {
+				h.logger.Errorf("HANDLERS: error encoding stream event: %v", err)
+				continue
+			}
+
+			if err = h.write(w, rc, message); err != nil {
+				h.logger.Debugf("HANDLERS: stream write failed: %v", err)
+				return
+			}
+		}
+	}
+}
+
+func (h *ContainerStatusStreamHandler) write(w http.ResponseWriter, rc *http.ResponseController, message string) error {
+	if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("failed to extend write deadline: %w", err)
+	}
+
+	if _, err := fmt.Fprint(w, message); err != nil {
+		return err
+	}
+
+	return h.flush(rc)
+}
+
+func (h *ContainerStatusStreamHandler) flush(rc *http.ResponseController) error {
+	if err := rc.Flush(); err != nil {
+		return fmt.Errorf("failed to flush stream: %w", err)
+	}
+
+	return nil
+}
+
+func formatChangeEvent(change *adto.ContainerStatusChangeDTO) (string, error) {
+	data, err := json.Marshal(mapper.MapChangeDTOToResponse(change))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("event: %s\ndata: %s\n\n", change.Type, data), nil
+}
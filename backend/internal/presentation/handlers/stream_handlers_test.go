@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/middlewares"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+// readStreamEvent returns the next event of an SSE stream, skipping comments.
+func readStreamEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && event != "":
+			return event, data
+		}
+	}
+}
+
+func TestStreamContainerStatuses_OutlivesServerTimeouts(t *testing.T) {
+	mockSubscriber := new(mocks.ContainerStatusSubscriber)
+	mockLogger := new(mocks.LoggerInterface)
+
+	changes := make(chan *adto.ContainerStatusChangeDTO, 1)
+	unsubscribed := make(chan struct{})
+
+	mockSubscriber.On("Subscribe").Return((<-chan *adto.ContainerStatusChangeDTO)(changes), func() { close(unsubscribed) })
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	handler := handlers.NewContainerStatusStreamHandler(mockSubscriber, 50*time.Millisecond, mockLogger)
+
+	server := httptest.NewUnstartedServer(middlewares.LoggingMiddleware(mockLogger)(http.HandlerFunc(handler.StreamContainerStatuses)))
+	server.Config.ReadTimeout = 200 * time.Millisecond
+	server.Config.WriteTimeout = 200 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	time.Sleep(500 * time.Millisecond)
+
+	changes <- &adto.ContainerStatusChangeDTO{
+		Type:        adto.ChangeTypeUpdated,
+		ContainerID: containerID,
+		Status:      &adto.ContainerStatusDTO{ContainerID: containerID, PingTime: pingTime},
+	}
+
+	event, data := readStreamEvent(t, bufio.NewReader(resp.Body))
+	assert.Equal(t, adto.ChangeTypeUpdated, event)
+
+	var change pdto.ContainerStatusChangeResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &change))
+	assert.Equal(t, containerID, change.ContainerID)
+	assert.Equal(t, pingTime, change.Status.PingTime)
+
+	resp.Body.Close()
+
+	select {
+	case <-unsubscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not unsubscribe after the client left")
+	}
+}
+
+func TestStreamContainerStatuses_ClosesWhenSubscriptionEnds(t *testing.T) {
+	mockSubscriber := new(mocks.ContainerStatusSubscriber)
+	mockLogger := new(mocks.LoggerInterface)
+
+	changes := make(chan *adto.ContainerStatusChangeDTO)
+	close(changes)
+
+	mockSubscriber.On("Subscribe").Return((<-chan *adto.ContainerStatusChangeDTO)(changes), func() {})
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warnf", mock.Anything, mock.Anything).Return()
+
+	handler := handlers.NewContainerStatusStreamHandler(mockSubscriber, time.Minute, mockLogger)
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.StreamContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, rec.Flushed)
+
+	mockSubscriber.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
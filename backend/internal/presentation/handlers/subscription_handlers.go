@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+
+	"github.com/repyg/DockerMonitoringApp/backend/internal/application/broker"
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/mapper"
+	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
+)
+
+const (
+	SubscriptionActionSubscribe   = "subscribe"
+	SubscriptionActionUnsubscribe = "unsubscribe"
+
+	SubscriptionMessageSubscribed   = "subscribed"
+	SubscriptionMessageUnsubscribed = "unsubscribed"
+	SubscriptionMessageError        = "error"
+)
+
+const (
+	// subscriptionPongWait is how long a client may stay silent before the
+	// connection is considered dead. Pings are sent well within it.
+	subscriptionPongWait       = 60 * time.Second
+	subscriptionPingPeriod     = subscriptionPongWait * 9 / 10
+	subscriptionMaxMessageSize = 4096
+)
+
+// subscriptionCommand carries a parsed client message from the reading
+// goroutine to the writing one, which owns the filter of the connection.
+type subscriptionCommand struct {
+	changeFilter bool
+	filter       *adto.ContainerStatusFilter
+	reply        *pdto.ContainerStatusSubscriptionResponse
+}
+
+type ContainerStatusSubscriptionHandler struct {
+	subscriber broker.ContainerStatusSubscriber
+	upgrader   websocket.Upgrader
+	validate   *validator.Validate
+	logger     utils.LoggerInterface
+}
+
+func NewContainerStatusSubscriptionHandler(
+	subscriber broker.ContainerStatusSubscriber,
+	logger utils.LoggerInterface,
+) *ContainerStatusSubscriptionHandler {
+	return &ContainerStatusSubscriptionHandler{
+		subscriber: subscriber,
+		upgrader: websocket.Upgrader{
+			// The API is authenticated by the X-Api-Key header and allows any
+			// origin through CORS, so cross-origin upgrades are accepted too.
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		validate: validator.New(),
+		logger:   logger,
+	}
+}
+
+// SubscribeContainerStatuses godoc
+// @Summary Subscribe to container status changes
+// @Description Upgrades the connection to a WebSocket and sends every created, updated and deleted container status matching the subscription filter as a dto.ContainerStatusChangeResponse. The initial filter is taken from the query params, which are the same as for GET /container_status (limit is ignored). A client changes the filter by sending a dto.ContainerStatusSubscriptionRequest and receives a dto.ContainerStatusSubscriptionResponse in reply.
+// @Tags Containers
+// @Param container_id query string false "Filter by container ID"
+// @Param host_id query string false "Filter by the host the pinger runs on"
+// @Param ip query string false "Filter by IP address or CIDR network, prefix with ! to negate"
+// @Param name query string false "Filter by name, * and ? are glob wildcards, prefix with ! to negate"
+// @Param status query string false "Filter by comma separated statuses, prefix with ! to negate"
+// @Param health query string false "Filter by comma separated health statuses (none, starting, healthy, unhealthy), prefix with ! to negate"
+// @Param ping_time_min query number false "Filter by minimum ping time"
+// @Param ping_time_max query number false "Filter by maximum ping time"
+// @Param last_successful_ping_gte query string false "Filter by last successful ping (greater than or equal to), format: RFC3339"
+// @Param last_successful_ping_lte query string false "Filter by last successful ping (less than or equal to), format: RFC3339"
+// @Param created_at_gte query string false "Filter by creation date (greater than or equal to), format: RFC3339"
+// @Param created_at_lte query string false "Filter by creation date (less than or equal to), format: RFC3339"
+// @Param updated_at_gte query string false "Filter by last update date (greater than or equal to), format: RFC3339"
+// @Param updated_at_lte query string false "Filter by last update date (less than or equal to), format: RFC3339"
+// @Success 101 {object} dto.ContainerStatusChangeResponse
+// @Failure 400 {string} string "Bad Request"
+// @Security ApiKeyAuth
+// @Router /container_status/ws [get].
+func (h *ContainerStatusSubscriptionHandler) SubscribeContainerStatuses(w http.ResponseWriter, r *http.Request) {
+	h.logger.Debugf("HANDLERS: received SubscribeContainerStatuses request with query: %s", r.URL.RawQuery)
+
+	filter, err := parseContainerStatusFilter(r.URL.Query())
+	if err != nil {
+		h.logger.Errorf("HANDLERS: error parsing filter params: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error status.
+		h.logger.Errorf("HANDLERS: websocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	changes, unsubscribe := h.subscriber.Subscribe()
+	defer unsubscribe()
+
+	commands := make(chan subscriptionCommand)
+	stop := make(chan struct{})
+	readerDone := make(chan struct{})
+
+	go func() {
+		defer close(readerDone)
+		h.readCommands(conn, commands, stop)
+	}()
+
+	h.writeChanges(conn, filter, changes, commands, readerDone)
+
+	close(stop)
+	conn.Close()
+	<-readerDone
+
+	h.logger.Debugf("HANDLERS: subscription of %s closed", utils.GetClientIP(r))
+}
+
+// writeChanges is the only writer of the connection. It returns when the
+// client is gone, a write fails or the subscriber fell behind.
+func (h *ContainerStatusSubscriptionHandler) writeChanges(
+	conn *websocket.Conn,
+	filter *adto.ContainerStatusFilter,
+	changes <-chan *adto.ContainerStatusChangeDTO,
+	commands <-chan subscriptionCommand,
+	readerDone <-chan struct{},
+) {
+	subscribed := &pdto.ContainerStatusSubscriptionResponse{
+		Type:   SubscriptionMessageSubscribed,
+		Filter: mapper.MapFilterToSubscriptionFilter(filter),
+	}
+	if err := h.writeJSON(conn, subscribed); err != nil {
+		h.logger.Debugf("HANDLERS: subscription write failed: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(subscriptionPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-readerDone:
+			return
+
+		case <-ticker.C:
+			deadline := time.Now().Add(streamWriteTimeout)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				h.logger.Debugf("HANDLERS: subscription ping failed: %v", err)
+				return
+			}
+
+		case command := <-commands:
+			if command.changeFilter {
+				filter = command.filter
+			}
+
+			if err := h.writeJSON(conn, command.reply); err != nil {
+				h.logger.Debugf("HANDLERS: subscription write failed: %v", err)
+				return
+			}
+
+		case change, ok := <-changes:
+			if !ok {
+				h.logger.Warnf("HANDLERS: subscriber fell behind, closing subscription")
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber fell behind")
+				_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(streamWriteTimeout))
+				return
+			}
+
+			if filter == nil || !filter.Matches(change.Status) {
+				continue
+			}
+
+			if err := h.writeJSON(conn, mapper.MapChangeDTOToResponse(change)); err != nil {
+				h.logger.Debugf("HANDLERS: subscription write failed: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// readCommands turns client messages into commands until the connection is
+// closed or stop is closed.
+func (h *ContainerStatusSubscriptionHandler) readCommands(
+	conn *websocket.Conn,
+	commands chan<- subscriptionCommand,
+	stop <-chan struct{},
+) {
+	conn.SetReadLimit(subscriptionMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(subscriptionPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(subscriptionPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.logger.Debugf("HANDLERS: subscription read failed: %v", err)
+			}
+			return
+		}
+
+		select {
+		case commands <- h.parseCommand(data):
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (h *ContainerStatusSubscriptionHandler) parseCommand(data []byte) subscriptionCommand {
+	var req pdto.ContainerStatusSubscriptionRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return newSubscriptionError(fmt.Sprintf("invalid message: %v", err))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return newSubscriptionError(fmt.Sprintf("invalid message: %v", err))
+	}
+
+	if req.Action == SubscriptionActionUnsubscribe {
+		return subscriptionCommand{
+			changeFilter: true,
+			reply:        &pdto.ContainerStatusSubscriptionResponse{Type: SubscriptionMessageUnsubscribed},
+		}
+	}
+
+	filter, err := parseSubscriptionFilter(req.Filter)
+	if err != nil {
+		return newSubscriptionError(err.Error())
+	}
+
+	return subscriptionCommand{
+		changeFilter: true,
+		filter:       filter,
+		reply: &pdto.ContainerStatusSubscriptionResponse{
+			Type:   SubscriptionMessageSubscribed,
+			Filter: mapper.MapFilterToSubscriptionFilter(filter),
+		},
+	}
+}
+
+func (h *ContainerStatusSubscriptionHandler) writeJSON(conn *websocket.Conn, message any) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		return fmt.Errorf("failed to extend write deadline: %w", err)
+	}
+
+	return conn.WriteJSON(message)
+}
+
+func newSubscriptionError(message string) subscriptionCommand {
+	return subscriptionCommand{
+		reply: &pdto.ContainerStatusSubscriptionResponse{
+			Type:    SubscriptionMessageError,
+			Message: message,
+		},
+	}
+}
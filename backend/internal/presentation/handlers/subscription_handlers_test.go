@@ -0,0 +1,185 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/middlewares"
+	"github.com/repyg/DockerMonitoringApp/backend/mocks"
+)
+
+// newSubscriptionServer serves the handler behind the logging middleware. The
+// changes channel should be unbuffered, so a send returns only once the
+// handler has taken the change and the tests can rely on the ordering.
+func newSubscriptionServer(
+	t *testing.T,
+	changes chan *adto.ContainerStatusChangeDTO,
+) *httptest.Server {
+	t.Helper()
+
+	mockSubscriber := new(mocks.ContainerStatusSubscriber)
+	mockLogger := new(mocks.LoggerInterface)
+
+	mockSubscriber.On("Subscribe").Return((<-chan *adto.ContainerStatusChangeDTO)(changes), func() {})
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("Debugf", mock.Anything).Maybe().Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	handler := handlers.NewContainerStatusSubscriptionHandler(mockSubscriber, mockLogger)
+
+	server := httptest.NewServer(middlewares.LoggingMiddleware(mockLogger)(http.HandlerFunc(handler.SubscribeContainerStatuses)))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func dialSubscription(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/?" + query
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	return conn
+}
+
+func newStatusChange(name, status string, pingTime float64) *adto.ContainerStatusChangeDTO {
+	return &adto.ContainerStatusChangeDTO{
+		Type:        adto.ChangeTypeUpdated,
+		ContainerID: containerID,
+		Status: &adto.ContainerStatusDTO{
+			ContainerID: containerID,
+			Name:        name,
+			Status:      status,
+			PingTime:    pingTime,
+		},
+	}
+}
+
+func TestSubscribeContainerStatuses_FiltersByQueryParams(t *testing.T) {
+	changes := make(chan *adto.ContainerStatusChangeDTO)
+	server := newSubscriptionServer(t, changes)
+
+	conn := dialSubscription(t, server, "status=running&ping_time_max=50")
+
+	var subscribed pdto.ContainerStatusSubscriptionResponse
+	require.NoError(t, conn.ReadJSON(&subscribed))
+	assert.Equal(t, handlers.SubscriptionMessageSubscribed, subscribed.Type)
+	assert.Equal(t, "running", *subscribed.Filter.Status)
+	assert.InDelta(t, 50, *subscribed.Filter.PingTimeMax, 0)
+
+	changes <- newStatusChange("web", "exited", 10)
+	changes <- newStatusChange("web", "running", 10)
+
+	var change pdto.ContainerStatusChangeResponse
+	require.NoError(t, conn.ReadJSON(&change))
+	assert.Equal(t, adto.ChangeTypeUpdated, change.Type)
+	assert.Equal(t, "running", change.Status.Status)
+}
+
+func TestSubscribeContainerStatuses_ChangesFilterMidConnection(t *testing.T) {
+	changes := make(chan *adto.ContainerStatusChangeDTO)
+	server := newSubscriptionServer(t, changes)
+
+	conn := dialSubscription(t, server, "")
+
+	var reply pdto.ContainerStatusSubscriptionResponse
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageSubscribed, reply.Type)
+
+	require.NoError(t, conn.WriteJSON(map[string]any{
+		"action": handlers.SubscriptionActionSubscribe,
+		"filter": map[string]any{"name": "db", "ping_time_min": 5},
+	}))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageSubscribed, reply.Type)
+	assert.Equal(t, "db", *reply.Filter.Name)
+
+	changes <- newStatusChange("web", "running", 10)
+	changes <- newStatusChange("db", "running", 10)
+
+	var change pdto.ContainerStatusChangeResponse
+	require.NoError(t, conn.ReadJSON(&change))
+	assert.Equal(t, "db", change.Status.Name)
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"action": handlers.SubscriptionActionUnsubscribe}))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageUnsubscribed, reply.Type)
+
+	changes <- newStatusChange("db", "exited", 10)
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"action": handlers.SubscriptionActionSubscribe}))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageSubscribed, reply.Type)
+
+	changes <- newStatusChange("db", "running", 10)
+
+	require.NoError(t, conn.ReadJSON(&change))
+	assert.Equal(t, "running", change.Status.Status)
+}
+
+func TestSubscribeContainerStatuses_InvalidMessage_RepliesWithError(t *testing.T) {
+	changes := make(chan *adto.ContainerStatusChangeDTO)
+	server := newSubscriptionServer(t, changes)
+
+	conn := dialSubscription(t, server, "")
+
+	var reply pdto.ContainerStatusSubscriptionResponse
+	require.NoError(t, conn.ReadJSON(&reply))
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"action": "pause"}))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageError, reply.Type)
+	assert.Contains(t, reply.Message, "Action")
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{")))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageError, reply.Type)
+
+	require.NoError(t, conn.WriteJSON(map[string]any{
+		"action": handlers.SubscriptionActionSubscribe,
+		"filter": map[string]any{"status": "sleeping"},
+	}))
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, handlers.SubscriptionMessageError, reply.Type)
+	assert.Contains(t, reply.Message, "sleeping")
+
+	changes <- newStatusChange("web", "running", 10)
+
+	var change pdto.ContainerStatusChangeResponse
+	require.NoError(t, conn.ReadJSON(&change))
+	assert.Equal(t, "web", change.Status.Name)
+}
+
+func TestSubscribeContainerStatuses_InvalidQueryParam_ReturnsBadRequest(t *testing.T) {
+	mockSubscriber := new(mocks.ContainerStatusSubscriber)
+	mockLogger := new(mocks.LoggerInterface)
+
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	handler := handlers.NewContainerStatusSubscriptionHandler(mockSubscriber, mockLogger)
+
+	req := httptest.NewRequest(http.MethodGet, "/container_status/ws?ping_time_min=fast", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	handler.SubscribeContainerStatuses(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockSubscriber.AssertNotCalled(t, "Subscribe")
+}
@@ -2,39 +2,102 @@ package mapper
 
 import (
 	adto "github.com/repyg/DockerMonitoringApp/backend/internal/application/dto"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
 	pdto "github.com/repyg/DockerMonitoringApp/backend/internal/presentation/dto"
 )
 
 func MapCreateRequestToAppDTO(req pdto.CreateContainerStatusRequest) adto.ContainerStatusDTO {
 	return adto.ContainerStatusDTO{
 		ContainerID:        req.ContainerID,
+		HostID:             req.HostID,
 		IPAddress:          req.IPAddress,
 		Name:               req.Name,
 		Status:             req.Status,
 		PingTime:           req.PingTime,
 		LastSuccessfulPing: req.LastSuccessfulPing,
+		ProbeType:          req.ProbeType,
 	}
 }
 
 func MapUpdateRequestToAppDTO(req pdto.UpdateContainerStatusRequest) adto.ContainerStatusDTO {
 	return adto.ContainerStatusDTO{
+		HostID:             req.HostID,
 		Name:               req.Name,
 		Status:             req.Status,
 		PingTime:           req.PingTime,
 		LastSuccessfulPing: req.LastSuccessfulPing,
+		ProbeType:          req.ProbeType,
+	}
+}
+
+func MapUpsertRequestToAppDTO(containerID string, req pdto.UpsertContainerStatusRequest) adto.ContainerStatusDTO {
+	return adto.ContainerStatusDTO{
+		ContainerID:        containerID,
+		HostID:             req.HostID,
+		IPAddress:          req.IPAddress,
+		Name:               req.Name,
+		Status:             req.Status,
+		PingTime:           req.PingTime,
+		LastSuccessfulPing: req.LastSuccessfulPing,
+		ProbeType:          req.ProbeType,
+		Health:             mapHealthRequestToAppDTO(req.Health),
+		PingStats:          mapPingStatsRequestToAppDTO(req.PingStats),
+		Networks:           mapNetworkRequestsToAppDTO(req.Networks),
+	}
+}
+
+func MapBatchRequestToAppDTO(req pdto.ContainerStatusBatchRequest) adto.ContainerStatusBatchDTO {
+	batch := adto.ContainerStatusBatchDTO{
+		HostID:           req.HostID,
+		CheckedAt:        req.CheckedAt,
+		Replayed:         req.Replayed,
+		Statuses:         make([]*adto.ContainerStatusDTO, 0, len(req.Statuses)),
+		LiveContainerIDs: req.LiveContainerIDs,
+	}
+
+	for _, item := range req.Statuses {
+		batch.Statuses = append(batch.Statuses, &adto.ContainerStatusDTO{
+			ContainerID:        item.ContainerID,
+			HostID:             req.HostID,
+			IPAddress:          item.IPAddress,
+			Name:               item.Name,
+			Status:             item.Status,
+			PingTime:           item.PingTime,
+			LastSuccessfulPing: item.LastSuccessfulPing,
+			ProbeType:          item.ProbeType,
+			Health:             mapHealthRequestToAppDTO(item.Health),
+			PingStats:          mapPingStatsRequestToAppDTO(item.PingStats),
+			Networks:           mapNetworkRequestsToAppDTO(item.Networks),
+		})
+	}
+
+	return batch
+}
+
+func MapBatchResultToResponse(result adto.ContainerStatusBatchResultDTO) pdto.ContainerStatusBatchResponse {
+	return pdto.ContainerStatusBatchResponse{
+		Created: result.Created,
+		Updated: result.Updated,
+		Deleted: result.Deleted,
+		Skipped: result.Skipped,
 	}
 }
 
 func MapAppDTOToResponse(appDTO adto.ContainerStatusDTO) pdto.GetContainerStatusResponse {
 	return pdto.GetContainerStatusResponse{
 		ContainerID:        appDTO.ContainerID,
+		HostID:             appDTO.HostID,
 		Name:               appDTO.Name,
 		IPAddress:          appDTO.IPAddress,
 		Status:             appDTO.Status,
 		PingTime:           appDTO.PingTime,
 		LastSuccessfulPing: appDTO.LastSuccessfulPing,
+		ProbeType:          appDTO.ProbeType,
+		Health:             mapHealthDTOToResponse(appDTO.Health),
+		PingStats:          mapPingStatsDTOToResponse(appDTO.PingStats),
 		CreatedAt:          appDTO.CreatedAt,
 		UpdatedAt:          appDTO.UpdatedAt,
+		Networks:           mapNetworkDTOsToResponse(appDTO.Networks),
 	}
 }
 
@@ -46,3 +109,246 @@ func MapAppDTOsToResponse(appDTOs []*adto.ContainerStatusDTO) []pdto.GetContaine
 
 	return responses
 }
+
+func MapChangeDTOToResponse(change *adto.ContainerStatusChangeDTO) pdto.ContainerStatusChangeResponse {
+	response := pdto.ContainerStatusChangeResponse{
+		Type:        change.Type,
+		ContainerID: change.ContainerID,
+	}
+
+	if change.Status != nil {
+		status := MapAppDTOToResponse(*change.Status)
+		response.Status = &status
+	}
+
+	return response
+}
+
+func MapFilterToSubscriptionFilter(filter *adto.ContainerStatusFilter) *pdto.ContainerStatusSubscriptionFilter {
+	response := &pdto.ContainerStatusSubscriptionFilter{
+		ContainerID:           filter.ContainerID,
+		HostID:                filter.HostID,
+		PingTimeMin:           filter.PingTimeMin,
+		PingTimeMax:           filter.PingTimeMax,
+		LastSuccessfulPingGte: filter.LastSuccessfulPingGte,
+		LastSuccessfulPingLte: filter.LastSuccessfulPingLte,
+		CreatedAtGte:          filter.CreatedAtGte,
+		CreatedAtLte:          filter.CreatedAtLte,
+		UpdatedAtGte:          filter.UpdatedAtGte,
+		UpdatedAtLte:          filter.UpdatedAtLte,
+	}
+
+	if filter.IPAddress != nil {
+		ip := filter.IPAddress.String()
+		response.IPAddress = &ip
+	}
+
+	if filter.Name != nil {
+		name := filter.Name.String()
+		response.Name = &name
+	}
+
+	if filter.Status != nil {
+		status := filter.Status.String()
+		response.Status = &status
+	}
+
+	if filter.Health != nil {
+		health := filter.Health.String()
+		response.Health = &health
+	}
+
+	return response
+}
+
+func MapSampleDTOsToResponse(appDTOs []*adto.ContainerPingSampleDTO) []pdto.ContainerPingSampleResponse {
+	var responses = make([]pdto.ContainerPingSampleResponse, 0, len(appDTOs))
+	for _, dto := range appDTOs {
+		responses = append(responses, pdto.ContainerPingSampleResponse{
+			ContainerID: dto.ContainerID,
+			IPAddress:   dto.IPAddress,
+			Status:      dto.Status,
+			PingTime:    dto.PingTime,
+			Success:     dto.Success,
+			RecordedAt:  dto.RecordedAt,
+		})
+	}
+
+	return responses
+}
+
+func MapAggregateDTOsToResponse(appDTOs []*adto.ContainerPingAggregateDTO) []pdto.ContainerPingAggregateResponse {
+	var responses = make([]pdto.ContainerPingAggregateResponse, 0, len(appDTOs))
+	for _, dto := range appDTOs {
+		responses = append(responses, pdto.ContainerPingAggregateResponse{
+			BucketStart:  dto.BucketStart,
+			MinPingTime:  dto.MinPingTime,
+			AvgPingTime:  dto.AvgPingTime,
+			MaxPingTime:  dto.MaxPingTime,
+			P95PingTime:  dto.P95PingTime,
+			SampleCount:  dto.SampleCount,
+			SuccessRatio: dto.SuccessRatio,
+		})
+	}
+
+	return responses
+}
+
+func MapEventDTOsToResponse(appDTOs []*adto.ContainerEventDTO) []pdto.ContainerEventResponse {
+	var responses = make([]pdto.ContainerEventResponse, 0, len(appDTOs))
+	for _, dto := range appDTOs {
+		responses = append(responses, pdto.ContainerEventResponse{
+			ID:                 dto.ID,
+			ContainerID:        dto.ContainerID,
+			Name:               dto.Name,
+			EventType:          dto.EventType,
+			PreviousStatus:     dto.PreviousStatus,
+			CurrentStatus:      dto.CurrentStatus,
+			LastSuccessfulPing: dto.LastSuccessfulPing,
+			OccurredAt:         dto.OccurredAt,
+		})
+	}
+
+	return responses
+}
+
+func MapAlertDTOsToResponse(appDTOs []*adto.AlertDTO) []pdto.AlertResponse {
+	var responses = make([]pdto.AlertResponse, 0, len(appDTOs))
+	for _, dto := range appDTOs {
+		responses = append(responses, pdto.AlertResponse{
+			ID:          dto.ID,
+			RuleName:    dto.RuleName,
+			ContainerID: dto.ContainerID,
+			Severity:    dto.Severity,
+			State:       dto.State,
+			Message:     dto.Message,
+			FiredAt:     dto.FiredAt,
+			ResolvedAt:  dto.ResolvedAt,
+		})
+	}
+
+	return responses
+}
+
+func MapAlertRulesToResponse(rules []domain.AlertRule) []pdto.AlertRuleResponse {
+	var responses = make([]pdto.AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		response := pdto.AlertRuleResponse{
+			Name:        rule.Name,
+			Metric:      rule.Metric,
+			Operator:    rule.Operator,
+			Threshold:   rule.Threshold,
+			Value:       rule.Value,
+			Consecutive: rule.Consecutive,
+			Severity:    rule.Severity,
+		}
+
+		if rule.For > 0 {
+			response.For = rule.For.String()
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+func MapRegisterAgentRequestToAppDTO(req pdto.RegisterAgentRequest) adto.AgentDTO {
+	return MapAgentHeartbeatRequestToAppDTO(req.ID, req.AgentHeartbeatRequest)
+}
+
+func MapAgentHeartbeatRequestToAppDTO(agentID string, req pdto.AgentHeartbeatRequest) adto.AgentDTO {
+	return adto.AgentDTO{
+		ID:             agentID,
+		HostName:       req.HostName,
+		Version:        req.Version,
+		DockerVersion:  req.DockerVersion,
+		ContainerCount: req.ContainerCount,
+		CycleDuration:  req.CycleDuration,
+		PingInterval:   req.PingInterval,
+		OverrunCycles:  req.OverrunCycles,
+	}
+}
+
+func MapAgentDTOToResponse(appDTO adto.AgentDTO) pdto.AgentResponse {
+	return pdto.AgentResponse{
+		ID:              appDTO.ID,
+		HostName:        appDTO.HostName,
+		Version:         appDTO.Version,
+		DockerVersion:   appDTO.DockerVersion,
+		ContainerCount:  appDTO.ContainerCount,
+		CycleDuration:   appDTO.CycleDuration,
+		PingInterval:    appDTO.PingInterval,
+		OverrunCycles:   appDTO.OverrunCycles,
+		State:           appDTO.State,
+		RegisteredAt:    appDTO.RegisteredAt,
+		LastHeartbeatAt: appDTO.LastHeartbeatAt,
+	}
+}
+
+func MapAgentDTOsToResponse(appDTOs []*adto.AgentDTO) []pdto.AgentResponse {
+	var responses = make([]pdto.AgentResponse, 0, len(appDTOs))
+	for _, dto := range appDTOs {
+		responses = append(responses, MapAgentDTOToResponse(*dto))
+	}
+
+	return responses
+}
+
+func mapHealthRequestToAppDTO(req *pdto.ContainerHealthRequest) *adto.ContainerHealthDTO {
+	if req == nil {
+		return nil
+	}
+
+	health := adto.ContainerHealthDTO(*req)
+	return &health
+}
+
+func mapHealthDTOToResponse(appDTO *adto.ContainerHealthDTO) pdto.ContainerHealthResponse {
+	if appDTO == nil {
+		return pdto.ContainerHealthResponse{Status: domain.HealthStatusNone}
+	}
+
+	return pdto.ContainerHealthResponse(*appDTO)
+}
+
+func mapPingStatsRequestToAppDTO(req *pdto.PingStatsRequest) *adto.PingStatsDTO {
+	if req == nil {
+		return nil
+	}
+
+	stats := adto.PingStatsDTO(*req)
+	return &stats
+}
+
+func mapPingStatsDTOToResponse(appDTO *adto.PingStatsDTO) pdto.PingStatsResponse {
+	if appDTO == nil {
+		return pdto.PingStatsResponse{}
+	}
+
+	return pdto.PingStatsResponse(*appDTO)
+}
+
+// mapNetworkRequestsToAppDTO keeps nil networks nil, so statuses reported
+// without networks keep the stored ones.
+func mapNetworkRequestsToAppDTO(reqs []pdto.ContainerNetworkRequest) []adto.ContainerNetworkDTO {
+	if reqs == nil {
+		return nil
+	}
+
+	networks := make([]adto.ContainerNetworkDTO, 0, len(reqs))
+	for _, req := range reqs {
+		networks = append(networks, adto.ContainerNetworkDTO(req))
+	}
+
+	return networks
+}
+
+func mapNetworkDTOsToResponse(appDTOs []adto.ContainerNetworkDTO) []pdto.ContainerNetworkResponse {
+	responses := make([]pdto.ContainerNetworkResponse, 0, len(appDTOs))
+	for _, appDTO := range appDTOs {
+		responses = append(responses, pdto.ContainerNetworkResponse(appDTO))
+	}
+
+	return responses
+}
@@ -16,6 +16,11 @@ func InitRoutes(
 	cfg *config.Config,
 	errHandler *handlers.ErrorHandlers,
 	conHandler *handlers.ContainerStatusHandler,
+	eventHandler *handlers.ContainerEventHandler,
+	alertHandler *handlers.AlertHandler,
+	streamHandler *handlers.ContainerStatusStreamHandler,
+	subscriptionHandler *handlers.ContainerStatusSubscriptionHandler,
+	agentHandler *handlers.AgentHandler,
 	logger utils.LoggerInterface,
 ) *mux.Router {
 	router := mux.NewRouter()
@@ -36,10 +41,37 @@ func InitRoutes(
 		Methods(http.MethodGet, http.MethodOptions)
 	apiRouter.HandleFunc("/container_status", conHandler.CreateContainerStatus).
 		Methods(http.MethodPost, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/batch", conHandler.ApplyContainerStatusBatch).
+		Methods(http.MethodPost, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/stream", streamHandler.StreamContainerStatuses).
+		Methods(http.MethodGet, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/ws", subscriptionHandler.SubscribeContainerStatuses).
+		Methods(http.MethodGet, http.MethodOptions)
 	apiRouter.HandleFunc("/container_status/{container_id}", conHandler.UpdateContainerStatus).
 		Methods(http.MethodPatch, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/{container_id}", conHandler.UpsertContainerStatus).
+		Methods(http.MethodPut, http.MethodOptions)
 	apiRouter.HandleFunc("/container_status/{container_id}", conHandler.DeleteContainerStatus).
 		Methods(http.MethodDelete, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/{container_id}/history", conHandler.GetContainerPingHistory).
+		Methods(http.MethodGet, http.MethodOptions)
+	apiRouter.HandleFunc("/container_status/{container_id}/aggregate", conHandler.GetContainerPingAggregate).
+		Methods(http.MethodGet, http.MethodOptions)
+
+	apiRouter.HandleFunc("/events", eventHandler.GetContainerEvents).
+		Methods(http.MethodGet, http.MethodOptions)
+
+	apiRouter.HandleFunc("/alerts", alertHandler.GetAlerts).
+		Methods(http.MethodGet, http.MethodOptions)
+	apiRouter.HandleFunc("/alerts/rules", alertHandler.GetAlertRules).
+		Methods(http.MethodGet, http.MethodOptions)
+
+	apiRouter.HandleFunc("/agents", agentHandler.GetAgents).
+		Methods(http.MethodGet, http.MethodOptions)
+	apiRouter.HandleFunc("/agents/register", agentHandler.RegisterAgent).
+		Methods(http.MethodPost, http.MethodOptions)
+	apiRouter.HandleFunc("/agents/{agent_id}/heartbeat", agentHandler.RecordHeartbeat).
+		Methods(http.MethodPost, http.MethodOptions)
 
 	return router
 }
@@ -8,26 +8,114 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	appNotifiers "github.com/repyg/DockerMonitoringApp/backend/internal/application/notifiers"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/broker"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/config"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/db/postgres/repositories"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/notifiers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/queue"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/infrastructure/workers"
+	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/consumers"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/handlers"
 	"github.com/repyg/DockerMonitoringApp/backend/internal/presentation/routes"
 	"github.com/repyg/DockerMonitoringApp/backend/pkg/utils"
 )
 
+const (
+	streamBufferSize = 64
+	streamKeepAlive  = 15 * time.Second
+)
+
 type Server struct {
-	httpServer *http.Server
-	logger     utils.LoggerInterface
+	httpServer         *http.Server
+	retentionWorker    *workers.RetentionWorker
+	alertWorker        *workers.AlertWorker
+	notificationWorker *workers.NotificationWorker
+	queueSubscriber    *queue.NATSSubscriber
+	logger             utils.LoggerInterface
 }
 
 func NewServer(cfg *config.Config, db *sqlx.DB, logger utils.LoggerInterface) *Server {
 	repo := repositories.NewContainerStatusRepositoryImpl(db, logger)
-	useCase := usecases.NewContainerStatusUseCase(repo, logger)
+	historyRepo := repositories.NewContainerPingHistoryRepositoryImpl(db, logger)
+	eventRepo := repositories.NewContainerEventRepositoryImpl(db, logger)
+	deliveryRepo := repositories.NewNotificationDeliveryRepositoryImpl(db, logger)
+	notificationUseCase := usecases.NewNotificationUseCase(
+		buildNotifiers(cfg.Notifications),
+		deliveryRepo,
+		usecases.RetryPolicy{
+			MaxAttempts:    cfg.Notifications.MaxAttempts,
+			InitialBackoff: cfg.Notifications.InitialBackoff,
+			MaxBackoff:     cfg.Notifications.MaxBackoff,
+		},
+		logger,
+	)
+	notificationWorker := workers.NewNotificationWorker(notificationUseCase, cfg.Notifications.QueueSize, logger)
+	alertRepo := repositories.NewAlertRepositoryImpl(db, logger)
+	alertUseCase := usecases.NewAlertUseCase(
+		mapAlertRules(cfg.Alerts.Rules),
+		alertRepo,
+		historyRepo,
+		repo,
+		notificationWorker,
+		logger,
+	)
+	statusBroker := broker.NewContainerStatusBroker(streamBufferSize, logger)
+	useCase := usecases.NewContainerStatusUseCase(
+		repo,
+		historyRepo,
+		eventRepo,
+		alertUseCase,
+		notificationWorker,
+		statusBroker,
+		logger,
+	)
 	containerHandler := handlers.NewContainerStatusHandler(useCase, logger)
+	eventUseCase := usecases.NewContainerEventUseCase(eventRepo, logger)
+	eventHandler := handlers.NewContainerEventHandler(eventUseCase, logger)
+	alertHandler := handlers.NewAlertHandler(alertUseCase, logger)
+	alertWorker := workers.NewAlertWorker(alertUseCase, cfg.Alerts.Interval, logger)
+	streamHandler := handlers.NewContainerStatusStreamHandler(statusBroker, streamKeepAlive, logger)
+	subscriptionHandler := handlers.NewContainerStatusSubscriptionHandler(statusBroker, logger)
+	agentRepo := repositories.NewAgentRepositoryImpl(db, logger)
+	agentUseCase := usecases.NewAgentUseCase(
+		agentRepo,
+		cfg.Agents.HeartbeatInterval,
+		cfg.Agents.MissedHeartbeats,
+		logger,
+	)
+	agentHandler := handlers.NewAgentHandler(agentUseCase, logger)
 	errHandler := handlers.NewErrorHandlers(logger)
 
-	router := routes.InitRoutes(cfg, errHandler, containerHandler, logger)
+	retentionRepo := repositories.NewPingRetentionRepositoryImpl(db, logger)
+	retentionUseCase := usecases.NewRetentionUseCase(retentionRepo, cfg.Retention.Raw, cfg.Retention.Rollup1h, logger)
+	retentionWorker := workers.NewRetentionWorker(retentionUseCase, cfg.Retention.Interval, logger)
+
+	var queueSubscriber *queue.NATSSubscriber
+	if cfg.Queue.Enabled {
+		consumer := consumers.NewContainerStatusConsumer(useCase, logger)
+		queueSubscriber = queue.NewNATSSubscriber(
+			cfg.Queue.URL,
+			cfg.Queue.Subject,
+			cfg.Queue.Group,
+			consumer.HandleBatch,
+			logger,
+		)
+	}
+
+	router := routes.InitRoutes(
+		cfg,
+		errHandler,
+		containerHandler,
+		eventHandler,
+		alertHandler,
+		streamHandler,
+		subscriptionHandler,
+		agentHandler,
+		logger,
+	)
 
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -38,12 +126,26 @@ func NewServer(cfg *config.Config, db *sqlx.DB, logger utils.LoggerInterface) *S
 	}
 
 	return &Server{
-		httpServer: httpServer,
-		logger:     logger,
+		httpServer:         httpServer,
+		retentionWorker:    retentionWorker,
+		alertWorker:        alertWorker,
+		notificationWorker: notificationWorker,
+		queueSubscriber:    queueSubscriber,
+		logger:             logger,
 	}
 }
 
 func (s *Server) Start() error {
+	s.retentionWorker.Start()
+	s.alertWorker.Start()
+	s.notificationWorker.Start()
+
+	if s.queueSubscriber != nil {
+		if err := s.queueSubscriber.Start(); err != nil {
+			return fmt.Errorf("failed to start queue subscriber: %w", err)
+		}
+	}
+
 	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		s.logger.Infof("SERVER: failed to start HTTP server: %v\n", err)
 		return fmt.Errorf("failed to start HTTP server: %w", err)
@@ -53,10 +155,77 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) Stop() error {
+	s.retentionWorker.Stop()
+	s.alertWorker.Stop()
+
+	if s.queueSubscriber != nil {
+		s.queueSubscriber.Stop()
+	}
+
 	if err := s.httpServer.Close(); err != nil {
 		s.logger.Infof("SERVER: failed to stop HTTP server: %v\n", err)
 		return fmt.Errorf("failed to stop HTTP server: %w", err)
 	}
 
+	s.notificationWorker.Stop()
+
 	return nil
 }
+
+// mapAlertRules converts configured rules to domain rules, filling in the
+// defaults: a single sample for ping_time rules and the warning severity.
+func mapAlertRules(configs []*config.AlertRuleConfig) []domain.AlertRule {
+	rules := make([]domain.AlertRule, 0, len(configs))
+	for _, cfg := range configs {
+		rule := domain.AlertRule{
+			Name:        cfg.Name,
+			Metric:      cfg.Metric,
+			Operator:    cfg.Operator,
+			Threshold:   cfg.Threshold,
+			Value:       cfg.Value,
+			Consecutive: cfg.Consecutive,
+			For:         cfg.For,
+			Severity:    cfg.Severity,
+		}
+
+		if rule.Metric == domain.AlertMetricPingTime && rule.Consecutive == 0 {
+			rule.Consecutive = 1
+		}
+
+		if rule.Severity == "" {
+			rule.Severity = "warning"
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func buildNotifiers(cfg *config.NotificationsConfig) []appNotifiers.Notifier {
+	client := &http.Client{Timeout: cfg.Timeout}
+
+	var result []appNotifiers.Notifier
+	for _, webhook := range cfg.Webhooks {
+		result = append(result, notifiers.NewWebhookNotifier(webhook.Name, webhook.URL, webhook.Secret, webhook.Types, client))
+	}
+
+	for _, slack := range cfg.Slack {
+		result = append(result, notifiers.NewSlackNotifier(slack.Name, slack.URL, slack.Types, client))
+	}
+
+	for _, smtp := range cfg.SMTP {
+		result = append(result, notifiers.NewSMTPNotifier(
+			smtp.Name,
+			smtp.Host,
+			smtp.Port,
+			smtp.Username,
+			smtp.Password,
+			smtp.From,
+			smtp.To,
+			smtp.Types,
+		))
+	}
+
+	return result
+}
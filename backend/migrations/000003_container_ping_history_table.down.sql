@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_container_ping_history_container_id_recorded_at;
+
+DROP TABLE IF EXISTS container_ping_history;
\ No newline at end of file
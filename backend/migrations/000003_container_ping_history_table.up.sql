@@ -0,0 +1,11 @@
+CREATE TABLE container_ping_history (
+    id BIGSERIAL PRIMARY KEY,
+    container_id TEXT NOT NULL,
+    ip_address INET NOT NULL,
+    status VARCHAR(255) NOT NULL DEFAULT 'created',
+    ping_time DOUBLE PRECISION NULL,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    recorded_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_container_ping_history_container_id_recorded_at ON container_ping_history(container_id, recorded_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_container_ping_history_recorded_at;
+
+DROP INDEX IF EXISTS idx_container_ping_rollup_1h_bucket_start;
+
+DROP TABLE IF EXISTS container_ping_rollup_1h;
\ No newline at end of file
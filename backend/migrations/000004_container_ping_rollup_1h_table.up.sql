@@ -0,0 +1,15 @@
+CREATE TABLE container_ping_rollup_1h (
+    container_id TEXT NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    min_ping_time DOUBLE PRECISION NULL,
+    avg_ping_time DOUBLE PRECISION NULL,
+    max_ping_time DOUBLE PRECISION NULL,
+    p95_ping_time DOUBLE PRECISION NULL,
+    sample_count BIGINT NOT NULL,
+    success_count BIGINT NOT NULL,
+    PRIMARY KEY (container_id, bucket_start)
+);
+
+CREATE INDEX idx_container_ping_rollup_1h_bucket_start ON container_ping_rollup_1h(bucket_start);
+
+CREATE INDEX idx_container_ping_history_recorded_at ON container_ping_history(recorded_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_container_events_container_id_occurred_at;
+
+DROP INDEX IF EXISTS idx_container_events_occurred_at;
+
+DROP TABLE IF EXISTS container_events;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE container_events (
+    id BIGSERIAL PRIMARY KEY,
+    container_id TEXT NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    event_type VARCHAR(64) NOT NULL,
+    previous_status VARCHAR(255) NOT NULL DEFAULT '',
+    current_status VARCHAR(255) NOT NULL DEFAULT '',
+    last_successful_ping TIMESTAMP,
+    occurred_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_container_events_occurred_at ON container_events(occurred_at);
+
+CREATE INDEX idx_container_events_container_id_occurred_at ON container_events(container_id, occurred_at);
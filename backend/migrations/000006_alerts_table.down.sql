@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_alerts_fired_at;
+
+DROP INDEX IF EXISTS idx_alerts_firing_rule_name_container_id;
+
+DROP TABLE IF EXISTS alerts;
\ No newline at end of file
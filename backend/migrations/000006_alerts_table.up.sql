@@ -0,0 +1,14 @@
+CREATE TABLE alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule_name VARCHAR(255) NOT NULL,
+    container_id TEXT NOT NULL,
+    severity VARCHAR(32) NOT NULL DEFAULT 'warning',
+    state VARCHAR(32) NOT NULL DEFAULT 'firing',
+    message TEXT NOT NULL DEFAULT '',
+    fired_at TIMESTAMP NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_alerts_firing_rule_name_container_id ON alerts(rule_name, container_id) WHERE state = 'firing';
+
+CREATE INDEX idx_alerts_fired_at ON alerts(fired_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_deliveries_created_at;
+
+DROP TABLE IF EXISTS notification_deliveries;
\ No newline at end of file
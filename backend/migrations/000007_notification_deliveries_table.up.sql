@@ -0,0 +1,12 @@
+CREATE TABLE notification_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    notifier VARCHAR(255) NOT NULL,
+    notification_type VARCHAR(64) NOT NULL,
+    container_id TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_notification_deliveries_created_at ON notification_deliveries(created_at);
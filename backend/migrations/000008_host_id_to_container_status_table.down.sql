@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_container_status_host_id;
+
+ALTER TABLE container_status DROP COLUMN IF EXISTS host_id;
@@ -0,0 +1,3 @@
+ALTER TABLE container_status ADD COLUMN host_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_container_status_host_id ON container_status(host_id);
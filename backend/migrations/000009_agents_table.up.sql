@@ -0,0 +1,10 @@
+CREATE TABLE agents (
+    id TEXT PRIMARY KEY,
+    host_name VARCHAR(255) NOT NULL DEFAULT '',
+    version VARCHAR(64) NOT NULL DEFAULT '',
+    docker_version VARCHAR(64) NOT NULL DEFAULT '',
+    container_count INTEGER NOT NULL DEFAULT 0,
+    cycle_duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    registered_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_heartbeat_at TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,11 @@
+CREATE TABLE container_networks (
+    container_id TEXT NOT NULL REFERENCES container_status(container_id) ON DELETE CASCADE,
+    network_name VARCHAR(255) NOT NULL,
+    mac_address VARCHAR(64) NOT NULL DEFAULT '',
+    gateway INET NULL,
+    ipv4_address INET NULL,
+    ipv4_ping_time DOUBLE PRECISION NULL,
+    ipv6_address INET NULL,
+    ipv6_ping_time DOUBLE PRECISION NULL,
+    PRIMARY KEY (container_id, network_name)
+);
@@ -0,0 +1 @@
+ALTER TABLE container_status DROP COLUMN IF EXISTS probe_type;
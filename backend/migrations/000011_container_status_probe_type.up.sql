@@ -0,0 +1 @@
+ALTER TABLE container_status ADD COLUMN probe_type VARCHAR(16) NOT NULL DEFAULT 'icmp';
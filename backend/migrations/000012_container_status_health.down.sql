@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_container_status_health_status;
+
+ALTER TABLE container_status
+    DROP COLUMN IF EXISTS health_last_output,
+    DROP COLUMN IF EXISTS health_failing_streak,
+    DROP COLUMN IF EXISTS health_status;
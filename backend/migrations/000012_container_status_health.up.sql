@@ -0,0 +1,6 @@
+ALTER TABLE container_status
+    ADD COLUMN health_status VARCHAR(16) NOT NULL DEFAULT 'none',
+    ADD COLUMN health_failing_streak INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN health_last_output TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_container_status_health_status ON container_status(health_status);
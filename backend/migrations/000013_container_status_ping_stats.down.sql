@@ -0,0 +1,26 @@
+COMMENT ON COLUMN container_status.ping_time IS NULL;
+
+UPDATE container_status SET ping_time = ping_time * 1000 WHERE ping_time > 0;
+
+UPDATE container_networks
+SET ipv4_ping_time = ipv4_ping_time * 1000,
+    ipv6_ping_time = ipv6_ping_time * 1000
+WHERE ipv4_ping_time IS NOT NULL OR ipv6_ping_time IS NOT NULL;
+
+UPDATE container_ping_history SET ping_time = ping_time * 1000 WHERE ping_time IS NOT NULL;
+
+UPDATE container_ping_rollup_1h
+SET min_ping_time = min_ping_time * 1000,
+    avg_ping_time = avg_ping_time * 1000,
+    max_ping_time = max_ping_time * 1000,
+    p95_ping_time = p95_ping_time * 1000
+WHERE min_ping_time IS NOT NULL;
+
+ALTER TABLE container_status
+    DROP COLUMN IF EXISTS rtt_stddev_ms,
+    DROP COLUMN IF EXISTS rtt_max_ms,
+    DROP COLUMN IF EXISTS rtt_avg_ms,
+    DROP COLUMN IF EXISTS rtt_min_ms,
+    DROP COLUMN IF EXISTS packet_loss_percent,
+    DROP COLUMN IF EXISTS packets_received,
+    DROP COLUMN IF EXISTS packets_sent;
@@ -0,0 +1,27 @@
+ALTER TABLE container_status
+    ADD COLUMN packets_sent INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN packets_received INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN packet_loss_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN rtt_min_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN rtt_avg_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN rtt_max_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN rtt_stddev_ms DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN container_status.ping_time IS 'Average round trip time in milliseconds, -1 when the last ping failed';
+
+-- The pingers used to report ping times in microseconds.
+UPDATE container_status SET ping_time = ping_time / 1000 WHERE ping_time > 0;
+
+UPDATE container_networks
+SET ipv4_ping_time = ipv4_ping_time / 1000,
+    ipv6_ping_time = ipv6_ping_time / 1000
+WHERE ipv4_ping_time IS NOT NULL OR ipv6_ping_time IS NOT NULL;
+
+UPDATE container_ping_history SET ping_time = ping_time / 1000 WHERE ping_time IS NOT NULL;
+
+UPDATE container_ping_rollup_1h
+SET min_ping_time = min_ping_time / 1000,
+    avg_ping_time = avg_ping_time / 1000,
+    max_ping_time = max_ping_time / 1000,
+    p95_ping_time = p95_ping_time / 1000
+WHERE min_ping_time IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE agents
+    DROP COLUMN IF EXISTS overrun_cycles,
+    DROP COLUMN IF EXISTS ping_interval;
@@ -0,0 +1,3 @@
+ALTER TABLE agents
+    ADD COLUMN ping_interval DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN overrun_cycles INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DELETE FROM container_ping_history WHERE ip_address IS NULL;
+
+ALTER TABLE container_ping_history ALTER COLUMN ip_address SET NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE container_ping_history ALTER COLUMN ip_address DROP NOT NULL;
@@ -14,6 +14,64 @@ type ContainerStatusRepository struct {
 	mock.Mock
 }
 
+// ApplyBatch provides a mock function with given fields: statuses, deleteIDs
+func (_m *ContainerStatusRepository) ApplyBatch(statuses []*domain.ContainerStatus, deleteIDs []string) ([]string, error) {
+	ret := _m.Called(statuses, deleteIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyBatch")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*domain.ContainerStatus, []string) ([]string, error)); ok {
+		return rf(statuses, deleteIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]*domain.ContainerStatus, []string) []string); ok {
+		r0 = rf(statuses, deleteIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*domain.ContainerStatus, []string) error); ok {
+		r1 = rf(statuses, deleteIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: filter
+func (_m *ContainerStatusRepository) Count(filter *dto.ContainerStatusFilter) (int, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusFilter) (int, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusFilter) int); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.ContainerStatusFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: status
 func (_m *ContainerStatusRepository) Create(status *domain.ContainerStatus) error {
 	ret := _m.Called(status)
@@ -98,6 +156,34 @@ func (_m *ContainerStatusRepository) Update(status *domain.ContainerStatus) erro
 	return r0
 }
 
+// Upsert provides a mock function with given fields: status
+func (_m *ContainerStatusRepository) Upsert(status *domain.ContainerStatus) (bool, error) {
+	ret := _m.Called(status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ContainerStatus) (bool, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ContainerStatus) bool); ok {
+		r0 = rf(status)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ContainerStatus) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewContainerStatusRepository creates a new instance of ContainerStatusRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContainerStatusRepository(t interface {
@@ -12,6 +12,66 @@ type ContainerStatusUseCaseInterface struct {
 	mock.Mock
 }
 
+// AggregateContainerPingHistory provides a mock function with given fields: filter
+func (_m *ContainerStatusUseCaseInterface) AggregateContainerPingHistory(filter *dto.ContainerPingAggregateFilter) ([]*dto.ContainerPingAggregateDTO, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateContainerPingHistory")
+	}
+
+	var r0 []*dto.ContainerPingAggregateDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.ContainerPingAggregateFilter) ([]*dto.ContainerPingAggregateDTO, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.ContainerPingAggregateFilter) []*dto.ContainerPingAggregateDTO); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.ContainerPingAggregateDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.ContainerPingAggregateFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyContainerStatusBatch provides a mock function with given fields: batch
+func (_m *ContainerStatusUseCaseInterface) ApplyContainerStatusBatch(batch *dto.ContainerStatusBatchDTO) (*dto.ContainerStatusBatchResultDTO, error) {
+	ret := _m.Called(batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyContainerStatusBatch")
+	}
+
+	var r0 *dto.ContainerStatusBatchResultDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusBatchDTO) (*dto.ContainerStatusBatchResultDTO, error)); ok {
+		return rf(batch)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusBatchDTO) *dto.ContainerStatusBatchResultDTO); ok {
+		r0 = rf(batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ContainerStatusBatchResultDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.ContainerStatusBatchDTO) error); ok {
+		r1 = rf(batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateContainerStatus provides a mock function with given fields: statusDTO
 func (_m *ContainerStatusUseCaseInterface) CreateContainerStatus(statusDTO *dto.ContainerStatusDTO) (*dto.ContainerStatusDTO, error) {
 	ret := _m.Called(statusDTO)
@@ -60,6 +120,66 @@ func (_m *ContainerStatusUseCaseInterface) DeleteContainerStatusByContainerID(co
 	return r0
 }
 
+// FindContainerPingHistory provides a mock function with given fields: filter
+func (_m *ContainerStatusUseCaseInterface) FindContainerPingHistory(filter *dto.ContainerPingHistoryFilter) ([]*dto.ContainerPingSampleDTO, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindContainerPingHistory")
+	}
+
+	var r0 []*dto.ContainerPingSampleDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.ContainerPingHistoryFilter) ([]*dto.ContainerPingSampleDTO, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.ContainerPingHistoryFilter) []*dto.ContainerPingSampleDTO); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.ContainerPingSampleDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.ContainerPingHistoryFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindContainerStatusPage provides a mock function with given fields: filter
+func (_m *ContainerStatusUseCaseInterface) FindContainerStatusPage(filter *dto.ContainerStatusFilter) (*dto.ContainerStatusPageDTO, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindContainerStatusPage")
+	}
+
+	var r0 *dto.ContainerStatusPageDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusFilter) (*dto.ContainerStatusPageDTO, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*dto.ContainerStatusFilter) *dto.ContainerStatusPageDTO); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ContainerStatusPageDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*dto.ContainerStatusFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindContainerStatuses provides a mock function with given fields: filter
 func (_m *ContainerStatusUseCaseInterface) FindContainerStatuses(filter *dto.ContainerStatusFilter) ([]*dto.ContainerStatusDTO, error) {
 	ret := _m.Called(filter)
@@ -108,6 +228,43 @@ func (_m *ContainerStatusUseCaseInterface) UpdateContainerStatus(containerID str
 	return r0
 }
 
+// UpsertContainerStatus provides a mock function with given fields: containerID, statusDTO
+func (_m *ContainerStatusUseCaseInterface) UpsertContainerStatus(containerID string, statusDTO *dto.ContainerStatusDTO) (*dto.ContainerStatusDTO, bool, error) {
+	ret := _m.Called(containerID, statusDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertContainerStatus")
+	}
+
+	var r0 *dto.ContainerStatusDTO
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, *dto.ContainerStatusDTO) (*dto.ContainerStatusDTO, bool, error)); ok {
+		return rf(containerID, statusDTO)
+	}
+	if rf, ok := ret.Get(0).(func(string, *dto.ContainerStatusDTO) *dto.ContainerStatusDTO); ok {
+		r0 = rf(containerID, statusDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ContainerStatusDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *dto.ContainerStatusDTO) bool); ok {
+		r1 = rf(containerID, statusDTO)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, *dto.ContainerStatusDTO) error); ok {
+		r2 = rf(containerID, statusDTO)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewContainerStatusUseCaseInterface creates a new instance of ContainerStatusUseCaseInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContainerStatusUseCaseInterface(t interface {
@@ -12,6 +12,18 @@ export interface Container {
   status: string;
   ping_time: number;
   last_successful_ping: string;
+  probe_type: string;
+  ping_stats?: PingStats;
+}
+
+export interface PingStats {
+  packets_sent: number;
+  packets_received: number;
+  packet_loss_percent: number;
+  rtt_min_ms: number;
+  rtt_avg_ms: number;
+  rtt_max_ms: number;
+  rtt_stddev_ms: number;
 }
 
 export const fetchContainers = async (): Promise<Container[]> => {
@@ -2,7 +2,7 @@
 
 import { useQuery } from "@tanstack/react-query";
 import { Table, Tag, Spin } from "antd";
-import { fetchContainers, Container } from "../api/containers";
+import { fetchContainers, Container, PingStats } from "../api/containers";
 
 function compareIPs(ipA: string, ipB: string) {
   const octetsA = ipA.split(".").map(Number);
@@ -44,12 +44,27 @@ const columns = [
       return <Tag color={color}>{status.toUpperCase()}</Tag>;
     },
   },
+  {
+    title: "Проверка",
+    dataIndex: "probe_type",
+    key: "probe_type",
+    render: (probeType: string) => <Tag>{(probeType || "icmp").toUpperCase()}</Tag>,
+  },
   {
     title: "Пинг (мс)",
     dataIndex: "ping_time",
     key: "ping_time",
     render: (ping: number) => (ping === -1 ? "N/A" : `${ping.toFixed(2)} ms`),
   },
+  {
+    title: "Потери",
+    dataIndex: "ping_stats",
+    key: "ping_stats",
+    render: (stats?: PingStats) =>
+      stats && stats.packets_sent > 0
+        ? `${stats.packet_loss_percent.toFixed(1)}% (${stats.packets_received}/${stats.packets_sent})`
+        : "—",
+  },
   {
     title: "Последний успешный пинг",
     dataIndex: "last_successful_ping",
@@ -7,14 +7,22 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/usecases"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/backend"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/config"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/docker"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/flags"
+	infraProbers "github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/spool"
 	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
 )
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
 	flagsData, err := flags.ParseFlags()
 	if err != nil {
@@ -38,16 +46,106 @@ func main() {
 		logger.Fatalf("Docker repository init failed: %v", err)
 	}
 
-	statusRepo := backend.NewBackendStatusRepo(
-		cfg.Backend.URL,
-		cfg.Backend.APIKey,
+	agentID := cfg.Agent.ID
+	if agentID == "" {
+		engine, err := containerRepo.GetEngineInfo(context.Background())
+		if err != nil || engine.HostName == "" {
+			logger.Fatalf("Agent ID is not configured and the Docker host name is unavailable: %v", err)
+		}
+		agentID = engine.HostName
+	}
+	logger.Infof("Running as agent %s, version %s", agentID, version)
+
+	var statusRepo repositories.StatusRepository
+	switch cfg.Backend.Transport {
+	case "nats":
+		conn, err := backend.ConnectNATS(cfg.Backend.NATS.URL, logger)
+		if err != nil {
+			logger.Fatalf("NATS connection failed: %v", err)
+		}
+		defer conn.Close()
+
+		logger.Infof("Sending statuses to %s over NATS at %s", cfg.Backend.NATS.Subject, cfg.Backend.NATS.URL)
+		statusRepo = backend.NewNATSStatusRepo(
+			conn,
+			cfg.Backend.NATS.Subject,
+			agentID,
+			cfg.Backend.NATS.Timeout,
+			logger,
+		)
+	default:
+		statusRepo = backend.NewBackendStatusRepo(
+			cfg.Backend.URL,
+			cfg.Backend.APIKey,
+			agentID,
+			cfg.Backend.Timeout,
+			backend.RetryPolicy{
+				MaxAttempts:    cfg.Backend.Retry.MaxAttempts,
+				InitialBackoff: cfg.Backend.Retry.InitialBackoff,
+				MaxBackoff:     cfg.Backend.Retry.MaxBackoff,
+			},
+			backend.CircuitBreakerPolicy{
+				FailureThreshold: cfg.Backend.CircuitBreaker.FailureThreshold,
+				Cooldown:         cfg.Backend.CircuitBreaker.Cooldown,
+			},
+			logger,
+		)
+	}
+
+	if cfg.Spool.Enabled {
+		statusRepo, err = spool.NewSpoolStatusRepo(
+			statusRepo,
+			cfg.Spool.Dir,
+			cfg.Spool.MaxBytes,
+			cfg.Spool.SegmentBytes,
+			logger,
+		)
+		if err != nil {
+			logger.Fatalf("Spool init failed: %v", err)
+		}
+		logger.Infof("Spooling undelivered statuses to %s", cfg.Spool.Dir)
+	}
+
+	discovery := usecases.NewDiscoveryUsecase(
+		containerRepo,
+		cfg.Docker.ResyncInterval,
 		logger,
 	)
 
+	defaultProbe, err := domain.ParseProbeSpec(cfg.Ping.Probe.Spec)
+	if err != nil {
+		logger.Fatalf("Probe config error: %v", err)
+	}
+	defaultProbe.ExpectStatus = cfg.Ping.Probe.ExpectStatus
+	defaultProbe.BodyContains = cfg.Ping.Probe.BodyContains
+	defaultProbe.Timeout = cfg.Ping.Probe.Timeout
+	defaultProbe.Count = cfg.Ping.Probe.Count
+	defaultProbe.Netns = cfg.Ping.Probe.Netns
+	logger.Infof("Checking containers with %s (netns %s) unless they set a probe", defaultProbe, defaultProbe.Netns)
+
 	pinger := usecases.NewPingerUsecase(
-		containerRepo,
+		discovery,
 		statusRepo,
+		[]probers.Prober{
+			infraProbers.NewICMPProber(),
+			infraProbers.NewTCPProber(),
+			infraProbers.NewHTTPProber(domain.ProbeHTTP),
+			infraProbers.NewHTTPProber(domain.ProbeHTTPS),
+			infraProbers.NewDNSProber(),
+		},
+		defaultProbe,
 		cfg.Ping.PingInterval,
+		cfg.Ping.Workers,
+		cfg.Ping.Jitter,
+		logger,
+	)
+
+	agent := usecases.NewAgentUsecase(
+		backend.NewBackendAgentRepo(cfg.Backend.URL, cfg.Backend.APIKey, logger),
+		containerRepo,
+		pinger,
+		agentID,
+		version,
 		logger,
 	)
 
@@ -62,6 +160,9 @@ func main() {
 		cancel()
 	}()
 
+	go discovery.Run(ctx)
+	go agent.Run(ctx)
+
 	logger.Info("Starting pinger service")
 	if err := pinger.Run(ctx); err != nil {
 		logger.Fatalf("Pinger service failed: %v", err)
@@ -0,0 +1,16 @@
+package probers
+
+import (
+	"context"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+)
+
+// Prober checks a single address of a container with one type of probe. It
+// returns the statistics of its attempts, and an error saying why the check
+// failed when no attempt was answered; the statistics still count the
+// attempts then. The deadline of ctx bounds the check.
+type Prober interface {
+	Type() string
+	Probe(ctx context.Context, address string, spec domain.ProbeSpec) (domain.ProbeStats, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+)
+
+// ErrAgentNotRegistered is returned by Heartbeat when the backend does not
+// know the agent, so it has to register again.
+var ErrAgentNotRegistered = errors.New("agent is not registered")
+
+type AgentRepository interface {
+	// Register returns the heartbeat interval requested by the backend.
+	Register(ctx context.Context, agent domain.AgentInfo) (time.Duration, error)
+	Heartbeat(ctx context.Context, agent domain.AgentInfo) error
+}
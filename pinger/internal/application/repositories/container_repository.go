@@ -2,10 +2,28 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
 )
 
+var (
+	// ErrContainerNotFound is returned by GetContainer when the container is
+	// gone.
+	ErrContainerNotFound = errors.New("container not found")
+	// ErrContainerExcluded is returned by GetContainer when the container is
+	// not selected for monitoring.
+	ErrContainerExcluded = errors.New("container excluded from monitoring")
+)
+
+// ContainerRepository only returns the containers selected for monitoring.
 type ContainerRepository interface {
 	GetContainers(ctx context.Context) ([]domain.ContainerInfo, error)
+	GetContainer(ctx context.Context, containerID string) (domain.ContainerInfo, error)
+	// WatchEvents streams the container lifecycle events from the time it
+	// is called until ctx is done or the stream fails, including those that
+	// happen before the subscription is established. The event channel is
+	// closed either way, and the error channel receives the failure.
+	WatchEvents(ctx context.Context) (<-chan domain.ContainerEvent, <-chan error)
+	GetEngineInfo(ctx context.Context) (domain.EngineInfo, error)
 }
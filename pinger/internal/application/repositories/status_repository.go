@@ -2,13 +2,17 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
 )
 
+// ErrBatchRejected means the backend refused the batch itself, so sending it
+// again cannot succeed.
+var ErrBatchRejected = errors.New("batch rejected by the backend")
+
 type StatusRepository interface {
-	UpdateStatus(ctx context.Context, containerID string, pingTime int64, name, status string, success bool) error
-	CreateStatus(ctx context.Context, containerID, ip string, pingTime int64, name, status string) error
-	DeleteStatus(ctx context.Context, containerID string) error
-	GetStatuses(ctx context.Context) ([]domain.PingResult, error)
+	// SendBatch reports the results of one monitoring cycle together with the
+	// IDs of all containers alive on the host; the backend removes the rest.
+	SendBatch(ctx context.Context, batch domain.StatusBatch) error
 }
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+const registerRetryInterval = 10 * time.Second
+
+// CycleReporter exposes the last completed ping cycle, it is implemented by
+// PingerUsecase.
+type CycleReporter interface {
+	LastCycle() domain.CycleStats
+}
+
+// AgentUsecase registers the pinger with the backend and then sends
+// heartbeats at the interval the backend asked for, so the backend notices
+// when the pinger is gone.
+type AgentUsecase struct {
+	agentRepo     repositories.AgentRepository
+	containerRepo repositories.ContainerRepository
+	cycles        CycleReporter
+	agentID       string
+	version       string
+	engine        domain.EngineInfo
+	logger        utils.LoggerInterface
+}
+
+func NewAgentUsecase(
+	ar repositories.AgentRepository,
+	cr repositories.ContainerRepository,
+	cycles CycleReporter,
+	agentID, version string,
+	logger utils.LoggerInterface,
+) *AgentUsecase {
+	return &AgentUsecase{
+		agentRepo:     ar,
+		containerRepo: cr,
+		cycles:        cycles,
+		agentID:       agentID,
+		version:       version,
+		logger:        logger,
+	}
+}
+
+func (uc *AgentUsecase) Run(ctx context.Context) {
+	interval, ok := uc.register(ctx)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := uc.agentRepo.Heartbeat(ctx, uc.agentInfo(ctx))
+			if errors.Is(err, repositories.ErrAgentNotRegistered) {
+				if interval, ok = uc.register(ctx); !ok {
+					return
+				}
+				ticker.Reset(interval)
+				continue
+			}
+
+			if err != nil {
+				uc.logger.Errorf("Heartbeat failed: %v", err)
+			}
+		}
+	}
+}
+
+// register retries until the backend accepts the agent. It returns false
+// when ctx is cancelled first.
+func (uc *AgentUsecase) register(ctx context.Context) (time.Duration, bool) {
+	for {
+		interval, err := uc.agentRepo.Register(ctx, uc.agentInfo(ctx))
+		if err == nil {
+			return interval, true
+		}
+
+		uc.logger.Errorf("Agent registration failed, retrying in %v: %v", registerRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return 0, false
+		case <-time.After(registerRetryInterval):
+		}
+	}
+}
+
+// agentInfo keeps the last known engine info when Docker is unavailable, the
+// heartbeat itself is still worth sending.
+func (uc *AgentUsecase) agentInfo(ctx context.Context) domain.AgentInfo {
+	engine, err := uc.containerRepo.GetEngineInfo(ctx)
+	if err != nil {
+		uc.logger.Warnf("Failed to get Docker engine info: %v", err)
+	} else {
+		uc.engine = engine
+	}
+
+	cycle := uc.cycles.LastCycle()
+
+	return domain.AgentInfo{
+		ID:             uc.agentID,
+		HostName:       uc.engine.HostName,
+		Version:        uc.version,
+		DockerVersion:  uc.engine.Version,
+		ContainerCount: cycle.ContainerCount,
+		CycleDuration:  cycle.Duration,
+		PingInterval:   cycle.Interval,
+		OverrunCycles:  cycle.Overruns,
+	}
+}
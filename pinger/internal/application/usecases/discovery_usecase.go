@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+const (
+	eventsRetryInterval = 5 * time.Second
+	changesQueueSize    = 64
+)
+
+// ContainerInventory provides the containers of the host, it is implemented
+// by DiscoveryUsecase.
+type ContainerInventory interface {
+	// Containers returns false until the first full sync is done, an empty
+	// inventory would otherwise remove every status of the host.
+	Containers() ([]domain.ContainerInfo, bool)
+	// Changes delivers the containers that started, stopped, were removed or
+	// changed networks since the inventory was synced.
+	Changes() <-chan domain.ContainerChange
+}
+
+// DiscoveryUsecase keeps the inventory of the host containers up to date
+// from the Docker events stream. A full resync runs on start, after the
+// stream is reconnected and every resync interval, in case an event was
+// missed. The stream is subscribed before each resync, so a container that
+// changes while it is listed is caught by an event.
+type DiscoveryUsecase struct {
+	containerRepo  repositories.ContainerRepository
+	resyncInterval time.Duration
+	logger         utils.LoggerInterface
+	changes        chan domain.ContainerChange
+
+	mu         sync.RWMutex
+	containers map[string]domain.ContainerInfo
+	synced     bool
+}
+
+func NewDiscoveryUsecase(
+	cr repositories.ContainerRepository,
+	resyncInterval time.Duration,
+	logger utils.LoggerInterface,
+) *DiscoveryUsecase {
+	return &DiscoveryUsecase{
+		containerRepo:  cr,
+		resyncInterval: resyncInterval,
+		logger:         logger,
+		changes:        make(chan domain.ContainerChange, changesQueueSize),
+		containers:     make(map[string]domain.ContainerInfo),
+	}
+}
+
+func (uc *DiscoveryUsecase) Run(ctx context.Context) {
+	uc.logger.Infof("Starting container discovery with resync interval %v", uc.resyncInterval)
+
+	ticker := time.NewTicker(uc.resyncInterval)
+	defer ticker.Stop()
+
+	for {
+		events, errs := uc.containerRepo.WatchEvents(ctx)
+		uc.resync(ctx)
+
+		if !uc.watch(ctx, events, errs, ticker) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventsRetryInterval):
+		}
+	}
+}
+
+func (uc *DiscoveryUsecase) Containers() ([]domain.ContainerInfo, bool) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	containers := make([]domain.ContainerInfo, 0, len(uc.containers))
+	for _, container := range uc.containers {
+		containers = append(containers, container)
+	}
+	slices.SortFunc(containers, func(a, b domain.ContainerInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return containers, uc.synced
+}
+
+func (uc *DiscoveryUsecase) Changes() <-chan domain.ContainerChange {
+	return uc.changes
+}
+
+// watch applies the events until the stream fails, resyncing on every tick.
+// It returns false when ctx is cancelled.
+func (uc *DiscoveryUsecase) watch(
+	ctx context.Context,
+	events <-chan domain.ContainerEvent,
+	errs <-chan error,
+	ticker *time.Ticker,
+) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			uc.resync(ctx)
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return false
+				}
+				uc.logger.Errorf("Docker events stream closed, reconnecting in %v: %v", eventsRetryInterval, <-errs)
+				return true
+			}
+			uc.apply(ctx, event)
+		}
+	}
+}
+
+func (uc *DiscoveryUsecase) apply(ctx context.Context, event domain.ContainerEvent) {
+	if event.Action == "destroy" {
+		uc.remove(event.ContainerID)
+		return
+	}
+
+	container, err := uc.containerRepo.GetContainer(ctx, event.ContainerID)
+	if errors.Is(err, repositories.ErrContainerNotFound) || errors.Is(err, repositories.ErrContainerExcluded) {
+		uc.remove(event.ContainerID)
+		return
+	}
+	if err != nil {
+		uc.logger.Warnf("Failed to inspect container %s after %s event, waiting for resync: %v", event.ContainerID, event.Action, err)
+		return
+	}
+
+	uc.mu.Lock()
+	previous, known := uc.containers[container.ContainerID]
+	uc.containers[container.ContainerID] = container
+	synced := uc.synced
+	uc.mu.Unlock()
+
+	if synced && (!known || !previous.Equal(container)) {
+		uc.logger.Infof("Container %s (ID: %s) %s: IP %q, status %s",
+			container.Name, container.ContainerID, event.Action, container.IP, container.Status)
+		uc.notify(domain.ContainerChange{Container: container})
+	}
+}
+
+func (uc *DiscoveryUsecase) remove(containerID string) {
+	uc.mu.Lock()
+	container, known := uc.containers[containerID]
+	delete(uc.containers, containerID)
+	synced := uc.synced
+	uc.mu.Unlock()
+
+	if synced && known {
+		uc.logger.Infof("Container %s (ID: %s) removed", container.Name, container.ContainerID)
+		uc.notify(domain.ContainerChange{Container: container, Removed: true})
+	}
+}
+
+// resync replaces the inventory with the containers Docker lists and reports
+// the differences the events did not cover. The inventory is kept when
+// Docker is unavailable.
+func (uc *DiscoveryUsecase) resync(ctx context.Context) {
+	list, err := uc.containerRepo.GetContainers(ctx)
+	if err != nil {
+		uc.logger.Errorf("Container resync failed: %v", err)
+		return
+	}
+
+	containers := make(map[string]domain.ContainerInfo, len(list))
+	for _, container := range list {
+		containers[container.ContainerID] = container
+	}
+
+	uc.mu.Lock()
+	previous, synced := uc.containers, uc.synced
+	uc.containers, uc.synced = containers, true
+	uc.mu.Unlock()
+
+	uc.logger.Debugf("Resynced %d containers", len(containers))
+	if !synced {
+		return
+	}
+
+	for id, container := range containers {
+		if old, ok := previous[id]; !ok || !old.Equal(container) {
+			uc.logger.Infof("Resync found a change of container %s (ID: %s)", container.Name, container.ContainerID)
+			uc.notify(domain.ContainerChange{Container: container})
+		}
+	}
+	for id, container := range previous {
+		if _, ok := containers[id]; !ok {
+			uc.logger.Infof("Resync found container %s (ID: %s) removed", container.Name, container.ContainerID)
+			uc.notify(domain.ContainerChange{Container: container, Removed: true})
+		}
+	}
+}
+
+// notify never blocks, a change that does not fit in the queue is reported
+// with the next ping cycle.
+func (uc *DiscoveryUsecase) notify(change domain.ContainerChange) {
+	select {
+	case uc.changes <- change:
+	default:
+		uc.logger.Warnf("Change queue is full, container %s will be reported with the next cycle", change.Container.ContainerID)
+	}
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/config"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/docker"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+// dockerContainer is a running container served by startDockerAPI, both as
+// listed and as inspected.
+type dockerContainer struct {
+	id     string
+	name   string
+	status string
+	labels map[string]string
+	health *types.Health
+	pid    int
+	ip     string
+}
+
+func (c dockerContainer) networks() map[string]*network.EndpointSettings {
+	return map[string]*network.EndpointSettings{
+		"bridge": {IPAddress: c.ip, Gateway: "172.17.0.1", MacAddress: "02:42:ac:11:00:02"},
+	}
+}
+
+func (c dockerContainer) listed() types.Container {
+	return types.Container{
+		ID:              c.id,
+		Names:           []string{c.name},
+		Image:           "nginx:1.27",
+		ImageID:         "sha256:0123",
+		State:           "running",
+		Status:          c.status,
+		Labels:          c.labels,
+		NetworkSettings: &types.SummaryNetworkSettings{Networks: c.networks()},
+	}
+}
+
+func (c dockerContainer) inspected() types.ContainerJSON {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:   c.id,
+			Name: c.name,
+			State: &types.ContainerState{
+				Status:  "running",
+				Running: true,
+				Pid:     c.pid,
+				Health:  c.health,
+			},
+		},
+		Config:          &container.Config{Image: "nginx:1.27", Labels: c.labels},
+		NetworkSettings: &types.NetworkSettings{Networks: c.networks()},
+	}
+}
+
+// startDockerAPI serves the list and the inspect of containers on a unix
+// socket and returns its path.
+func startDockerAPI(t *testing.T, containers ...dockerContainer) string {
+	t.Helper()
+
+	// A socket path may not be longer than about 100 bytes, which the test
+	// temp dir can exceed.
+	dir, err := os.MkdirTemp("", "docker")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "docker.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Api-Version", "1.45")
+
+		var body any
+		switch path := r.URL.Path; {
+		case path == "/_ping":
+			_, _ = w.Write([]byte("OK"))
+			return
+		case strings.HasSuffix(path, "/containers/json"):
+			list := make([]types.Container, 0, len(containers))
+			for _, c := range containers {
+				list = append(list, c.listed())
+			}
+			body = list
+		default:
+			for _, c := range containers {
+				if strings.HasSuffix(path, "/containers/"+c.id+"/json") {
+					body = c.inspected()
+				}
+			}
+		}
+		if body == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func TestDiscovery_ResyncAfterEventReportsNoChange(t *testing.T) {
+	web := dockerContainer{
+		id:     "web",
+		name:   "/web",
+		status: "Up 2 minutes",
+		pid:    4242,
+		ip:     "172.17.0.2",
+	}
+	db := dockerContainer{
+		id:     "db",
+		name:   "/db",
+		status: "Up 2 minutes (healthy)",
+		health: &types.Health{
+			Status:        types.Healthy,
+			FailingStreak: 0,
+			Log:           []*types.HealthcheckResult{{Output: "accepting connections\n"}},
+		},
+		pid: 4343,
+		ip:  "172.17.0.3",
+	}
+	probed := dockerContainer{
+		id:     "probed",
+		name:   "/probed",
+		status: "Up 2 minutes",
+		labels: map[string]string{"monitoring.probe.netns": domain.NetnsLoopback},
+		pid:    4444,
+		ip:     "172.17.0.4",
+	}
+
+	cfg := &config.Config{
+		Docker: &config.DockerConfig{SocketPath: startDockerAPI(t, web, db, probed)},
+		Ping:   &config.PingConfig{Probe: &config.ProbeConfig{Netns: domain.NetnsOff}},
+	}
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+	repo, err := docker.NewDockerContainerRepo(cfg, logger)
+	require.NoError(t, err)
+
+	uc := NewDiscoveryUsecase(repo, time.Minute, logger)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	uc.resync(ctx)
+	for _, c := range []dockerContainer{web, db, probed} {
+		uc.apply(ctx, domain.ContainerEvent{ContainerID: c.id, Action: "start"})
+	}
+	uc.resync(ctx)
+
+	assert.Len(t, uc.changes, 0, "an event-updated container differs from its listed copy")
+
+	containers, synced := uc.Containers()
+	require.True(t, synced)
+	require.Len(t, containers, 3)
+	pids := map[string]int{}
+	for _, c := range containers {
+		pids[c.ContainerID] = c.Pid
+	}
+	assert.Equal(t, map[string]int{"web": 0, "db": 0, "probed": 4444}, pids, "only a netns probe needs the PID")
+}
@@ -1,42 +1,87 @@
 package usecases
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"hash/fnv"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	probing "github.com/prometheus-community/pro-bing"
-
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
 	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
 )
 
 type PingerUsecase struct {
-	containerRepo repositories.ContainerRepository
-	statusRepo    repositories.StatusRepository
-	interval      time.Duration
-	logger        utils.LoggerInterface
+	inventory    ContainerInventory
+	statusRepo   repositories.StatusRepository
+	probers      map[string]probers.Prober
+	defaultProbe domain.ProbeSpec
+	interval     time.Duration
+	workers      int
+	jitter       time.Duration
+	logger       utils.LoggerInterface
+
+	// mu guards lastCycle and lastChecked, which the cycles and the change
+	// reports share.
+	mu          sync.Mutex
+	lastCycle   domain.CycleStats
+	lastChecked map[string]time.Time
 }
 
+// NewPingerUsecase checks the containers with defaultProbe unless they set
+// a probe of their own. defaultProbe must have a timeout and a count, which
+// also apply to the probes of the containers without them. Every inter a
+// cycle checks the due containers on at most workers goroutines, starting
+// the checks spread over jitter.
 func NewPingerUsecase(
-	cr repositories.ContainerRepository,
+	inventory ContainerInventory,
 	sr repositories.StatusRepository,
+	probeList []probers.Prober,
+	defaultProbe domain.ProbeSpec,
 	inter time.Duration,
+	workers int,
+	jitter time.Duration,
 	logger utils.LoggerInterface,
 ) *PingerUsecase {
+	registry := make(map[string]probers.Prober, len(probeList))
+	for _, prober := range probeList {
+		registry[prober.Type()] = prober
+	}
+
 	return &PingerUsecase{
-		containerRepo: cr,
-		statusRepo:    sr,
-		interval:      inter,
-		logger:        logger,
+		inventory:    inventory,
+		statusRepo:   sr,
+		probers:      registry,
+		defaultProbe: defaultProbe,
+		interval:     inter,
+		workers:      workers,
+		jitter:       jitter,
+		logger:       logger,
+		lastCycle:    domain.CycleStats{Interval: inter},
+		lastChecked:  make(map[string]time.Time),
 	}
 }
 
+// Run checks the containers every interval until ctx is cancelled. The
+// cycles run one after the other on this goroutine, so they never overlap;
+// the ticker drops the ticks a long cycle misses. The changes of the
+// inventory are reported on a goroutine of their own, a long cycle does not
+// hold them up.
 func (uc *PingerUsecase) Run(ctx context.Context) error {
-	uc.logger.Infof("Starting monitoring with interval %v", uc.interval)
+	uc.logger.Infof("Starting monitoring with interval %v, %d workers and %v jitter", uc.interval, uc.workers, uc.jitter)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		uc.watchChanges(ctx)
+	}()
+	defer wg.Wait()
 
 	uc.logger.Debugf("Ticker interval: %v", uc.interval)
 	ticker := time.NewTicker(uc.interval)
@@ -55,162 +100,374 @@ func (uc *PingerUsecase) Run(ctx context.Context) error {
 	}
 }
 
+// watchChanges reports the changes of the inventory as they come until ctx
+// is cancelled. A report may reach the backend before the batch of a cycle
+// that started earlier; the next cycle corrects what that batch outdated.
+func (uc *PingerUsecase) watchChanges(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change := <-uc.inventory.Changes():
+			if err := uc.reportChanges(ctx, change); err != nil {
+				uc.logger.Errorf("Reporting container changes failed: %v", err)
+			}
+		}
+	}
+}
+
+func (uc *PingerUsecase) LastCycle() domain.CycleStats {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.lastCycle
+}
+
 func (uc *PingerUsecase) checkContainers(ctx context.Context) error {
-	containers, err := uc.containerRepo.GetContainers(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get container info: %w", err)
+	started := time.Now()
+
+	containers, synced := uc.inventory.Containers()
+	if !synced {
+		uc.logger.Debug("Containers are not discovered yet, skipping cycle")
+		return nil
 	}
 
-	activeContainerIDs := make(map[string]bool)
+	liveContainerIDs := make([]string, 0, len(containers))
 	containerInfos := make([]string, 0, len(containers))
 	for _, container := range containers {
-		activeContainerIDs[container.ContainerID] = true
+		liveContainerIDs = append(liveContainerIDs, container.ContainerID)
 		containerInfos = append(containerInfos,
 			fmt.Sprintf("%s (ID: %s, IP: %s) [%s]", container.Name, container.ContainerID, container.IP, container.Status))
 	}
 	uc.logger.Debugf("Discovered %d containers: %s", len(containers), strings.Join(containerInfos, ", "))
 
-	uc.logger.Debug("Pinging containers")
-	var wg sync.WaitGroup
+	due := uc.dueContainers(containers, started)
+	uc.logger.Debugf("Pinging %d of %d containers", len(due), len(containers))
+	results := uc.checkAll(ctx, due, uc.jitter)
 
-	for _, container := range containers {
-		wg.Add(1)
-		go func(container domain.ContainerInfo) {
-			defer wg.Done()
+	batch := domain.StatusBatch{CheckedAt: started, Results: results, LiveContainerIDs: liveContainerIDs}
+	err := uc.statusRepo.SendBatch(ctx, batch)
+	uc.recordCycle(len(containers), time.Since(started))
+	if err != nil {
+		uc.logger.Errorf("Sending batch of %d statuses failed: %v", len(results), err)
+		return fmt.Errorf("sending batch failed: %w", err)
+	}
 
-			var result *domain.PingResult
-			if container.IP == "" {
-				uc.logger.Warnf("No IP for container %s (ID: %s), updating status as %s", container.Name, container.ContainerID, container.Status)
-				result = &domain.PingResult{
-					ContainerID: container.ContainerID,
-					IP:          "",
-					Name:        container.Name,
-					Status:      container.Status,
-					Success:     false,
-					PingTime:    0,
-					LastPing:    time.Now().Format(time.RFC3339),
-				}
-			} else {
-				res, err := uc.ping(container)
-				if err != nil {
-					uc.logger.Warnf("Ping failed for container %s (ID: %s, IP: %s) [%s]: %v",
-						container.Name, container.ContainerID, container.IP, container.Status, err)
-					res = &domain.PingResult{
-						ContainerID: container.ContainerID,
-						IP:          container.IP,
-						Name:        container.Name,
-						Status:      container.Status,
-						Success:     false,
-						PingTime:    0,
-						LastPing:    time.Now().Format(time.RFC3339),
-					}
-				}
-				result = res
-			}
+	return nil
+}
 
-			if err := uc.updateStatus(ctx, result); err != nil {
-				uc.logger.Errorf("Failed to update status for container %s (ID: %s, IP: %s) [%s]: %v",
-					container.Name, container.ContainerID, container.IP, container.Status, err)
-			}
-		}(container)
+// recordCycle keeps the stats of a completed cycle. Its duration includes
+// sending the batch, which holds up the next cycle as well.
+func (uc *PingerUsecase) recordCycle(containerCount int, duration time.Duration) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.lastCycle.ContainerCount = containerCount
+	uc.lastCycle.Duration = duration
+	if duration > uc.interval {
+		uc.lastCycle.Overruns++
+		uc.logger.Warnf("Cycle of %d containers took %v, longer than the interval %v; the next cycle starts late",
+			containerCount, duration, uc.interval)
 	}
-	wg.Wait()
+}
+
+// reportChanges sends the containers changed since the last cycle right
+// away, without waiting for the next tick. The changes already queued are
+// sent in the same batch, with the latest change of each container winning.
+func (uc *PingerUsecase) reportChanges(ctx context.Context, first domain.ContainerChange) error {
+	started := time.Now()
+
+	changes := map[string]domain.ContainerChange{first.Container.ContainerID: first}
+drain:
+	for {
+		select {
+		case change := <-uc.inventory.Changes():
+			changes[change.Container.ContainerID] = change
+		default:
+			break drain
+		}
+	}
+
+	changed := make([]domain.ContainerInfo, 0, len(changes))
+	for _, change := range changes {
+		if !change.Removed {
+			changed = append(changed, change.Container)
+		}
+	}
+
+	containers, _ := uc.inventory.Containers()
+	liveContainerIDs := make([]string, 0, len(containers))
+	for _, container := range containers {
+		liveContainerIDs = append(liveContainerIDs, container.ContainerID)
+	}
+
+	uc.logger.Debugf("Reporting %d changed and %d removed containers",
+		len(changed), len(changes)-len(changed))
+	uc.mu.Lock()
+	for _, container := range changed {
+		uc.lastChecked[container.ContainerID] = started
+	}
+	uc.mu.Unlock()
+	results := uc.checkAll(ctx, changed, 0)
 
-	if err := uc.cleanupStatuses(ctx, activeContainerIDs); err != nil {
-		uc.logger.Errorf("Cleanup statuses failed: %v", err)
-		return fmt.Errorf("cleanup statuses failed: %w", err)
+	batch := domain.StatusBatch{CheckedAt: started, Results: results, LiveContainerIDs: liveContainerIDs}
+	if err := uc.statusRepo.SendBatch(ctx, batch); err != nil {
+		return fmt.Errorf("sending batch failed: %w", err)
 	}
 
 	return nil
 }
 
-func (uc *PingerUsecase) ping(container domain.ContainerInfo) (*domain.PingResult, error) {
-	uc.logger.Debugf("Pinging container %s (ID: %s, IP: %s) [%s]",
-		container.Name, container.ContainerID, container.IP, container.Status)
+// dueContainers drops the containers whose own interval has not elapsed yet.
+// Half a tick of slack keeps a container with an interval that is a multiple
+// of the tick from skipping a tick due to timer drift.
+func (uc *PingerUsecase) dueContainers(containers []domain.ContainerInfo, now time.Time) []domain.ContainerInfo {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
 
-	pinger, err := probing.NewPinger(container.IP)
-	if err != nil {
-		uc.logger.Errorf("Ping init failed for container %s (ID: %s, IP: %s) [%s]: %v",
-			container.Name, container.ContainerID, container.IP, container.Status, err)
-		return nil, fmt.Errorf("ping init failed: %w", err)
+	live := make(map[string]bool, len(containers))
+	due := make([]domain.ContainerInfo, 0, len(containers))
+	for _, container := range containers {
+		live[container.ContainerID] = true
+
+		last, checked := uc.lastChecked[container.ContainerID]
+		if container.Interval > 0 && checked && now.Sub(last)+uc.interval/2 < container.Interval {
+			continue
+		}
+
+		uc.lastChecked[container.ContainerID] = now
+		due = append(due, container)
 	}
 
-	pinger.Count = 100
-	pinger.Timeout = 2 * time.Second
-	pinger.SetPrivileged(true)
+	for containerID := range uc.lastChecked {
+		if !live[containerID] {
+			delete(uc.lastChecked, containerID)
+		}
+	}
 
-	if err := pinger.Run(); err != nil {
-		uc.logger.Errorf("Ping execution failed for container %s (ID: %s, IP: %s) [%s]: %v",
-			container.Name, container.ContainerID, container.IP, container.Status, err)
-		return nil, fmt.Errorf("ping execution failed: %w", err)
+	return due
+}
+
+// checkAll checks the containers on at most uc.workers goroutines, the
+// results keep their order. The check of each container starts at its
+// offset within spread; a container is handed to a worker once its offset
+// has passed and a worker is free.
+func (uc *PingerUsecase) checkAll(ctx context.Context, containers []domain.ContainerInfo, spread time.Duration) []domain.PingResult {
+	results := make([]domain.PingResult, len(containers))
+	offsets := make([]time.Duration, len(containers))
+	order := make([]int, len(containers))
+	for i, container := range containers {
+		offsets[i] = startOffset(container.ContainerID, spread)
+		order[i] = i
 	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Compare(offsets[a], offsets[b])
+	})
 
-	stats := pinger.Statistics()
-	uc.logger.Debugf("Ping stats for container %s (ID: %s, IP: %s) [%s]: %+v",
-		container.Name, container.ContainerID, container.IP, container.Status, stats)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(uc.workers, len(containers)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = uc.check(ctx, containers[i])
+			}
+		}()
+	}
+
+	// Once ctx is cancelled the remaining containers are handed out at once,
+	// their probes fail right away.
+	started := time.Now()
+	for _, i := range order {
+		if wait := time.Until(started.Add(offsets[i])); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+			case <-timer.C:
+			}
+			timer.Stop()
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
 
-	var pingTime int64 = -1
-	if stats.PacketsRecv > 0 {
-		pingTime = stats.AvgRtt.Microseconds()
+// startOffset places the container within spread by a hash of its ID, so it
+// is checked at the same point of every cycle and the time between two of
+// its checks stays the interval.
+func startOffset(containerID string, spread time.Duration) time.Duration {
+	if spread <= 0 {
+		return 0
 	}
 
-	uc.logger.Debugf("Ping time for container %s (ID: %s, IP: %s) [%s]: %.2f ms",
-		container.Name, container.ContainerID, container.IP, container.Status, pingTime)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(containerID))
+
+	return time.Duration(h.Sum64() % uint64(spread))
+}
+
+// loopbackAddress is the target of a probe in NetnsLoopback mode.
+const loopbackAddress = "127.0.0.1"
 
-	return &domain.PingResult{
+// check probes every address of every network of the container at once, or
+// its loopback address or gateways from inside its network namespace. A
+// container without any target is reported with its status and networks
+// only.
+func (uc *PingerUsecase) check(ctx context.Context, container domain.ContainerInfo) domain.PingResult {
+	spec := uc.probeSpec(container)
+	result := domain.PingResult{
 		ContainerID: container.ContainerID,
 		IP:          container.IP,
 		Name:        container.Name,
 		Status:      container.Status,
-		Success:     stats.PacketsRecv > 0,
-		PingTime:    pingTime,
-	}, nil
-}
-
-func (uc *PingerUsecase) updateStatus(ctx context.Context, result *domain.PingResult) error {
-	if err := uc.statusRepo.UpdateStatus(ctx, result.ContainerID, result.PingTime, result.Name, result.Status, result.Success); err != nil {
-		uc.logger.Warnf("Update failed for container %s (ID: %s, IP: %s) [%s], trying to create: %v",
-			result.Name, result.ContainerID, result.IP, result.Status, err)
+		ProbeType:   spec.Type,
+		Health:      &container.Health,
+	}
 
-		if err := uc.statusRepo.CreateStatus(ctx, result.ContainerID, result.IP, result.PingTime, result.Name, result.Status); err != nil {
-			uc.logger.Errorf("Create status failed for container %s (ID: %s, IP: %s) [%s]: %v",
-				result.Name, result.ContainerID, result.IP, result.Status, err)
-			return fmt.Errorf("create status failed for container %s (ID: %s, IP: %s) [%s]: %w",
-				result.Name, result.ContainerID, result.IP, result.Status, err)
+	result.Networks = make([]domain.NetworkPingResult, len(container.Networks))
+	for i, network := range container.Networks {
+		result.Networks[i] = domain.NetworkPingResult{
+			Name:    network.Name,
+			MAC:     network.MAC,
+			Gateway: network.Gateway,
+			IPv4:    network.IPv4,
+			IPv6:    network.IPv6,
 		}
 	}
 
-	return nil
-}
+	// Only the addresses of the networks have a ping time of their own.
+	type target struct {
+		address  string
+		pingTime **int64
+		stats    domain.ProbeStats
+	}
+	targets := make([]*target, 0, 2*len(container.Networks))
+	switch {
+	case spec.Netns != domain.NetnsOff && spec.NetnsPID == 0:
+		// The container is not running, it has no namespace to enter.
+	case spec.Netns == domain.NetnsLoopback:
+		targets = append(targets, &target{address: loopbackAddress})
+	case spec.Netns == domain.NetnsGateway:
+		for _, network := range container.Networks {
+			if network.Gateway != "" {
+				targets = append(targets, &target{address: network.Gateway})
+			}
+		}
+	default:
+		for i, network := range container.Networks {
+			for _, t := range []*target{
+				{address: network.IPv4, pingTime: &result.Networks[i].IPv4PingTime},
+				{address: network.IPv6, pingTime: &result.Networks[i].IPv6PingTime},
+			} {
+				if t.address != "" {
+					targets = append(targets, t)
+				}
+			}
+		}
+	}
 
-func (uc *PingerUsecase) cleanupStatuses(ctx context.Context, activeContainerIDs map[string]bool) error {
-	uc.logger.Debug("Cleaning up statuses")
-	statuses, err := uc.statusRepo.GetStatuses(ctx)
-	if err != nil {
-		uc.logger.Errorf("Failed to get statuses: %v", err)
-		return fmt.Errorf("failed to get statuses: %w", err)
+	if len(targets) == 0 {
+		uc.logger.Warnf("Nothing to probe for container %s (ID: %s, netns %s), updating status as %s",
+			container.Name, container.ContainerID, spec.Netns, container.Status)
+		return result
 	}
 
-	for _, status := range statuses {
-		uc.logger.Debugf("Status: %+v", status)
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		wg.Add(1)
+		go func(t *target) {
+			defer wg.Done()
+
+			t.stats = uc.probe(ctx, container, t.address, spec)
+			if t.stats.PacketsReceived > 0 && t.pingTime != nil {
+				pingTime := t.stats.AvgRTT.Microseconds()
+				*t.pingTime = &pingTime
+			}
+		}(t)
 	}
+	wg.Wait()
 
-	for _, status := range statuses {
-		if status.ContainerID == "" {
-			uc.logger.Debugf("Skipping deletion for container %s with empty container_id", status.Name)
+	// The container is summed up by its best address, or by the attempts of
+	// all of them when none answered.
+	result.PingTime = -1
+	var lost domain.ProbeStats
+	for _, t := range targets {
+		if t.stats.PacketsReceived == 0 {
+			lost.PacketsSent += t.stats.PacketsSent
 			continue
 		}
-
-		if !activeContainerIDs[status.ContainerID] {
-			uc.logger.Debugf("Container with container_id %s not found among active containers. Deleting its record.", status.ContainerID)
-			if err := uc.statusRepo.DeleteStatus(ctx, status.ContainerID); err != nil {
-				uc.logger.Errorf("Failed to delete status for container_id %s: %v", status.ContainerID, err)
-				return fmt.Errorf("failed to delete status for container_id %s: %w", status.ContainerID, err)
-			} else {
-				uc.logger.Debugf("Successfully deleted status for container %s with container_id %s", status.Name, status.ContainerID)
-			}
+		if result.Stats == nil || t.stats.AvgRTT < result.Stats.AvgRTT {
+			stats := t.stats
+			result.Stats = &stats
 		}
 	}
+	if result.Stats != nil {
+		result.Success = true
+		result.PingTime = result.Stats.AvgRTT.Microseconds()
+	} else if lost.PacketsSent > 0 {
+		result.Stats = &lost
+	}
+	if result.Success {
+		result.LastPing = time.Now().Format(time.RFC3339)
+	}
 
-	return nil
+	return result
+}
+
+// probeSpec is the probe of the container, or the default one. The default
+// timeout, count and netns mode apply to a probe without its own. A probe
+// in a netns mode enters the namespace of the main process of the
+// container.
+func (uc *PingerUsecase) probeSpec(container domain.ContainerInfo) domain.ProbeSpec {
+	spec := container.Probe
+	if spec.Type == "" {
+		spec = uc.defaultProbe
+		spec.Netns = container.Probe.Netns
+	}
+
+	if spec.Timeout == 0 {
+		spec.Timeout = uc.defaultProbe.Timeout
+	}
+	if spec.Count == 0 {
+		spec.Count = uc.defaultProbe.Count
+	}
+	spec.Netns = cmp.Or(spec.Netns, uc.defaultProbe.Netns, domain.NetnsOff)
+	if spec.Netns != domain.NetnsOff {
+		spec.NetnsPID = container.Pid
+	}
+
+	return spec
+}
+
+// probe checks the address with the probe of the container. A failed check
+// is logged, the attempts it made are still counted.
+func (uc *PingerUsecase) probe(ctx context.Context, container domain.ContainerInfo, address string, spec domain.ProbeSpec) domain.ProbeStats {
+	prober, ok := uc.probers[spec.Type]
+	if !ok {
+		uc.logger.Errorf("No prober for %s, container %s (ID: %s) is not checked", spec, container.Name, container.ContainerID)
+		return domain.ProbeStats{}
+	}
+
+	uc.logger.Debugf("Probing container %s (ID: %s, IP: %s) [%s] with %s, netns %s",
+		container.Name, container.ContainerID, address, container.Status, spec, spec.Netns)
+
+	probeCtx, cancel := context.WithTimeout(ctx, spec.Timeout)
+	defer cancel()
+
+	stats, err := prober.Probe(probeCtx, address, spec)
+	if err != nil {
+		uc.logger.Debugf("Probe %s failed for container %s (ID: %s, IP: %s) [%s]: %v",
+			spec, container.Name, container.ContainerID, address, container.Status, err)
+		return stats
+	}
+
+	uc.logger.Debugf("Probe of container %s (ID: %s, IP: %s) [%s]: %d of %d answered, %.1f%% loss, rtt min/avg/max/stddev %v/%v/%v/%v",
+		container.Name, container.ContainerID, address, container.Status, stats.PacketsReceived, stats.PacketsSent, stats.PacketLoss(),
+		stats.MinRTT, stats.AvgRTT, stats.MaxRTT, stats.StdDevRTT)
+
+	return stats
 }
@@ -0,0 +1,284 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+// fakeProber answers every probe after delay and records how many probes
+// ran at once.
+type fakeProber struct {
+	delay time.Duration
+
+	running atomic.Int32
+	peak    atomic.Int32
+	probes  atomic.Int32
+}
+
+func (p *fakeProber) Type() string {
+	return domain.ProbeTCP
+}
+
+func (p *fakeProber) Probe(ctx context.Context, _ string, _ domain.ProbeSpec) (domain.ProbeStats, error) {
+	p.probes.Add(1)
+	n := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return domain.SingleAttempt(0, false), ctx.Err()
+	case <-time.After(p.delay):
+	}
+
+	return domain.SingleAttempt(time.Millisecond, true), nil
+}
+
+type fakeInventory struct {
+	containers []domain.ContainerInfo
+	changes    chan domain.ContainerChange
+}
+
+func (i *fakeInventory) Containers() ([]domain.ContainerInfo, bool) {
+	return i.containers, true
+}
+
+func (i *fakeInventory) Changes() <-chan domain.ContainerChange {
+	return i.changes
+}
+
+// batchRecorder keeps the batches sent.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []domain.StatusBatch
+}
+
+func (r *batchRecorder) SendBatch(_ context.Context, batch domain.StatusBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, batch)
+
+	return nil
+}
+
+func (r *batchRecorder) sent() []domain.StatusBatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.StatusBatch(nil), r.batches...)
+}
+
+func testContainers(n int) []domain.ContainerInfo {
+	containers := make([]domain.ContainerInfo, n)
+	for i := range containers {
+		ip := fmt.Sprintf("10.0.0.%d", i+1)
+		containers[i] = domain.ContainerInfo{
+			ContainerID: fmt.Sprintf("c%02d", i),
+			Name:        fmt.Sprintf("/c%02d", i),
+			IP:          ip,
+			Status:      "running",
+			Networks:    []domain.NetworkAttachment{{Name: "bridge", IPv4: ip}},
+		}
+	}
+
+	return containers
+}
+
+func newTestPinger(inventory ContainerInventory, repo *batchRecorder, prober probers.Prober, interval time.Duration, workers int) *PingerUsecase {
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+	probe := domain.ProbeSpec{Type: domain.ProbeTCP, Port: 80, Timeout: time.Second, Count: 1, Netns: domain.NetnsOff}
+
+	return NewPingerUsecase(inventory, repo, []probers.Prober{prober}, probe, interval, workers, 0, logger)
+}
+
+func TestCheckAll_BoundedByWorkers(t *testing.T) {
+	prober := &fakeProber{delay: 20 * time.Millisecond}
+	uc := newTestPinger(nil, nil, prober, time.Minute, 4)
+	containers := testContainers(20)
+
+	results := uc.checkAll(context.Background(), containers, 0)
+
+	require.Len(t, results, len(containers))
+	for i, result := range results {
+		assert.Equal(t, containers[i].ContainerID, result.ContainerID, "results keep the order of the containers")
+		assert.True(t, result.Success)
+	}
+	assert.EqualValues(t, 20, prober.probes.Load())
+	assert.EqualValues(t, 4, prober.peak.Load())
+}
+
+func TestCheckAll_FewerContainersThanWorkers(t *testing.T) {
+	prober := &fakeProber{delay: 20 * time.Millisecond}
+	uc := newTestPinger(nil, nil, prober, time.Minute, 8)
+
+	results := uc.checkAll(context.Background(), testContainers(3), 0)
+
+	assert.Len(t, results, 3)
+	assert.LessOrEqual(t, prober.peak.Load(), int32(3))
+}
+
+func TestStartOffset(t *testing.T) {
+	spread := 10 * time.Second
+
+	assert.Equal(t, startOffset("c01", spread), startOffset("c01", spread), "offset is stable")
+	assert.NotEqual(t, startOffset("c01", spread), startOffset("c02", spread))
+	for _, container := range testContainers(50) {
+		offset := startOffset(container.ContainerID, spread)
+		assert.GreaterOrEqual(t, offset, time.Duration(0))
+		assert.Less(t, offset, spread)
+	}
+	assert.Zero(t, startOffset("c01", 0))
+}
+
+func TestDueContainers_RespectsIntervals(t *testing.T) {
+	tick := 10 * time.Second
+	uc := newTestPinger(nil, nil, &fakeProber{}, tick, 1)
+
+	containers := testContainers(2)
+	containers[1].Interval = 30 * time.Second
+	ids := func(due []domain.ContainerInfo) []string {
+		ids := make([]string, 0, len(due))
+		for _, container := range due {
+			ids = append(ids, container.ContainerID)
+		}
+		return ids
+	}
+
+	start := time.Now()
+	tests := []struct {
+		after time.Duration
+		want  []string
+	}{
+		{0, []string{"c00", "c01"}},
+		{10 * time.Second, []string{"c00"}},
+		{20 * time.Second, []string{"c00"}},
+		// The tick fires a little early, half a tick of slack keeps c01
+		// on every third tick.
+		{29 * time.Second, []string{"c00", "c01"}},
+		{40 * time.Second, []string{"c00"}},
+		{50 * time.Second, []string{"c00"}},
+		{60 * time.Second, []string{"c00", "c01"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ids(uc.dueContainers(containers, start.Add(tt.after))), "after %v", tt.after)
+	}
+}
+
+func TestDueContainers_ForgetsRemovedContainers(t *testing.T) {
+	uc := newTestPinger(nil, nil, &fakeProber{}, 10*time.Second, 1)
+	containers := testContainers(2)
+	containers[1].Interval = time.Hour
+
+	now := time.Now()
+	uc.dueContainers(containers, now)
+	require.Len(t, uc.lastChecked, 2)
+
+	uc.dueContainers(containers[:1], now.Add(10*time.Second))
+	assert.Len(t, uc.lastChecked, 1)
+
+	// A container that comes back is checked right away.
+	due := uc.dueContainers(containers, now.Add(20*time.Second))
+	assert.Len(t, due, 2)
+}
+
+func TestCheckContainers_CountsOverruns(t *testing.T) {
+	inventory := &fakeInventory{containers: testContainers(2)}
+	repo := &batchRecorder{}
+	prober := &fakeProber{delay: 10 * time.Millisecond}
+	uc := newTestPinger(inventory, repo, prober, 50*time.Millisecond, 1)
+
+	require.NoError(t, uc.checkContainers(context.Background()))
+	cycle := uc.LastCycle()
+	assert.Equal(t, 2, cycle.ContainerCount)
+	assert.Zero(t, cycle.Overruns)
+	assert.Less(t, cycle.Duration, 50*time.Millisecond)
+
+	// One worker probes the containers one after the other, 2 x 40ms take
+	// longer than the interval.
+	prober.delay = 40 * time.Millisecond
+	require.NoError(t, uc.checkContainers(context.Background()))
+	require.NoError(t, uc.checkContainers(context.Background()))
+	cycle = uc.LastCycle()
+	assert.EqualValues(t, 2, cycle.Overruns)
+	assert.Greater(t, cycle.Duration, 50*time.Millisecond)
+	assert.Equal(t, 50*time.Millisecond, cycle.Interval)
+
+	require.Len(t, repo.sent(), 3)
+	for _, batch := range repo.sent() {
+		assert.Len(t, batch.Results, 2)
+		assert.Equal(t, []string{"c00", "c01"}, batch.LiveContainerIDs)
+	}
+}
+
+// blockingProber holds the probes of address until release is closed and
+// signals blocked when the first one starts.
+type blockingProber struct {
+	address string
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingProber) Type() string {
+	return domain.ProbeTCP
+}
+
+func (p *blockingProber) Probe(ctx context.Context, address string, _ domain.ProbeSpec) (domain.ProbeStats, error) {
+	if address == p.address {
+		select {
+		case p.blocked <- struct{}{}:
+		default:
+		}
+		select {
+		case <-ctx.Done():
+		case <-p.release:
+		}
+	}
+
+	return domain.SingleAttempt(time.Millisecond, true), nil
+}
+
+func TestRun_ReportsChangesDuringCycle(t *testing.T) {
+	containers := testContainers(2)
+	inventory := &fakeInventory{containers: containers[:1], changes: make(chan domain.ContainerChange, 1)}
+	repo := &batchRecorder{}
+	prober := &blockingProber{address: containers[0].IP, blocked: make(chan struct{}, 1), release: make(chan struct{})}
+	uc := newTestPinger(inventory, repo, prober, 20*time.Millisecond, 1)
+	uc.defaultProbe.Timeout = 5 * time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- uc.Run(ctx) }()
+
+	<-prober.blocked
+	inventory.changes <- domain.ContainerChange{Container: containers[1]}
+
+	require.Eventually(t, func() bool { return len(repo.sent()) == 1 }, 2*time.Second, 5*time.Millisecond,
+		"change was not reported while the cycle was running")
+	report := repo.sent()[0]
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, containers[1].ContainerID, report.Results[0].ContainerID)
+
+	close(prober.release)
+	require.Eventually(t, func() bool { return len(repo.sent()) >= 2 }, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// AgentInfo is what the pinger reports about itself when it registers and
+// with every heartbeat.
+type AgentInfo struct {
+	ID             string
+	HostName       string
+	Version        string
+	DockerVersion  string
+	ContainerCount int
+	CycleDuration  time.Duration
+	PingInterval   time.Duration
+	OverrunCycles  int
+}
+
+type EngineInfo struct {
+	HostName string
+	Version  string
+}
+
+// CycleStats describes the last completed ping cycle. Overruns counts the
+// cycles since the start that took longer than Interval.
+type CycleStats struct {
+	ContainerCount int
+	Duration       time.Duration
+	Interval       time.Duration
+	Overruns       int
+}
@@ -0,0 +1,15 @@
+package domain
+
+// ContainerEvent is a lifecycle change of a container reported by Docker:
+// start, die, stop, destroy, or a network connect/disconnect.
+type ContainerEvent struct {
+	ContainerID string
+	Action      string
+}
+
+// ContainerChange is a change of the container inventory. Removed is set
+// when the container no longer exists on the host.
+type ContainerChange struct {
+	Container ContainerInfo
+	Removed   bool
+}
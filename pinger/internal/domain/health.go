@@ -0,0 +1,18 @@
+package domain
+
+// Health states of a container HEALTHCHECK. HealthNone is reported for a
+// container whose image defines none, or that is not running.
+const (
+	HealthNone      = "none"
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// ContainerHealth is the state Docker keeps of the HEALTHCHECK of a
+// container. LastOutput is the output of the latest check.
+type ContainerHealth struct {
+	Status        string `json:"status"`
+	FailingStreak int    `json:"failing_streak"`
+	LastOutput    string `json:"last_output"`
+}
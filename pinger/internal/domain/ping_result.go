@@ -1,18 +1,95 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
 type PingResult struct {
 	ContainerID string `json:"container_id"`
+	HostID      string `json:"host_id"`
 	IP          string `json:"ip_address"`
 	Name        string `json:"name"`
 	Status      string `json:"status"`
 	Success     bool   `json:"success"`
 	PingTime    int64  `json:"ping_time"`
 	LastPing    string `json:"last_successful_ping"`
+	// ProbeType is the probe the addresses were checked with. PingTime is
+	// its average latency in microseconds, -1 when no address answered.
+	ProbeType string `json:"probe_type,omitempty"`
+	// Stats are the attempts of the probe at the address PingTime comes
+	// from, or of all addresses when none answered. It is nil when no
+	// address was probed, and in batches spooled before it was reported.
+	Stats *ProbeStats `json:"stats,omitempty"`
+	// Health is the HEALTHCHECK state of the container, nil in batches
+	// spooled before it was reported.
+	Health *ContainerHealth `json:"health,omitempty"`
+	// Networks holds the result of every network, IP is the address of one
+	// of them. Success and PingTime sum them up: the container answered on
+	// at least one address, in PingTime at best. It is nil in batches
+	// spooled before the networks were reported.
+	Networks []NetworkPingResult `json:"networks"`
+}
+
+// NetworkPingResult is the outcome of pinging the addresses of one network
+// of a container. The ping times are in microseconds; nil means the address
+// did not answer or is not assigned.
+type NetworkPingResult struct {
+	Name         string `json:"network_name"`
+	MAC          string `json:"mac_address"`
+	Gateway      string `json:"gateway"`
+	IPv4         string `json:"ipv4_address"`
+	IPv4PingTime *int64 `json:"ipv4_ping_time"`
+	IPv6         string `json:"ipv6_address"`
+	IPv6PingTime *int64 `json:"ipv6_ping_time"`
+}
+
+// StatusBatch is the outcome of one ping cycle. LiveContainerIDs lists every
+// container found on the host, including the ones without a result. Replayed
+// is set on a batch sent late from the spool, whose live containers are
+// outdated by then.
+type StatusBatch struct {
+	CheckedAt        time.Time    `json:"checked_at"`
+	Results          []PingResult `json:"results"`
+	LiveContainerIDs []string     `json:"live_container_ids"`
+	Replayed         bool         `json:"replayed,omitempty"`
 }
 
+// ContainerInfo describes a container and every network it is attached to,
+// ordered by name. IP is the first IPv4 address of the networks, or the
+// first IPv6 address when there is none, so it does not change between
+// cycles. Interval and Probe override the ping interval and the default
+// probe for this container; the zero values keep the defaults. Health is
+// read from Docker, not probed. Pid is the main process of a running
+// container probed from its network namespace, zero otherwise.
 type ContainerInfo struct {
 	ContainerID string
 	IP          string
 	Name        string
 	Status      string
+	Networks    []NetworkAttachment
+	Interval    time.Duration
+	Probe       ProbeSpec
+	Health      ContainerHealth
+	Pid         int
+}
+
+type NetworkAttachment struct {
+	Name    string
+	IPv4    string
+	IPv6    string
+	MAC     string
+	Gateway string
+}
+
+func (c ContainerInfo) Equal(other ContainerInfo) bool {
+	return c.ContainerID == other.ContainerID &&
+		c.IP == other.IP &&
+		c.Name == other.Name &&
+		c.Status == other.Status &&
+		c.Interval == other.Interval &&
+		c.Probe == other.Probe &&
+		c.Health == other.Health &&
+		c.Pid == other.Pid &&
+		slices.Equal(c.Networks, other.Networks)
 }
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ProbeICMP  = "icmp"
+	ProbeTCP   = "tcp"
+	ProbeHTTP  = "http"
+	ProbeHTTPS = "https"
+	ProbeDNS   = "dns"
+)
+
+// Network namespaces a probe runs in. NetnsOff probes from the namespace of
+// the pinger, which has to share a network with the container. The others
+// enter the namespace of the container and probe its addresses, its
+// loopback address or the gateways of its networks.
+const (
+	NetnsOff       = "off"
+	NetnsAddresses = "addresses"
+	NetnsLoopback  = "loopback"
+	NetnsGateway   = "gateway"
+)
+
+// defaultProbePorts are used when a probe is written without a port. TCP
+// has no default.
+var defaultProbePorts = map[string]int{
+	ProbeHTTP:  80,
+	ProbeHTTPS: 443,
+	ProbeDNS:   53,
+}
+
+// ProbeSpec is how the addresses of a container are checked. The zero value
+// is an ICMP ping. Path is the request path of an HTTP probe and the name a
+// DNS probe resolves. An HTTP probe succeeds on ExpectStatus, or on any 2xx
+// and 3xx status when it is zero, and when the body contains BodyContains.
+// Count is the number of echo requests of an ICMP probe. An empty Netns, a
+// zero Timeout or Count leave them to the caller. NetnsPID is the process
+// whose network namespace a probe not in NetnsOff mode enters; the pinger
+// sets it for every check.
+type ProbeSpec struct {
+	Type         string
+	Port         int
+	Path         string
+	ExpectStatus int
+	BodyContains string
+	Timeout      time.Duration
+	Count        int
+	Netns        string
+	NetnsPID     int
+}
+
+// ParseProbeSpec reads a probe written as "type[:port][/path]": "icmp",
+// "tcp:5432", "http:8080/healthz", "https/status" or "dns/example.com".
+func ParseProbeSpec(value string) (ProbeSpec, error) {
+	head, path, hasPath := strings.Cut(value, "/")
+	probeType, port, hasPort := strings.Cut(head, ":")
+
+	spec := ProbeSpec{Type: probeType}
+	switch probeType {
+	case ProbeICMP:
+		if hasPort || hasPath {
+			return ProbeSpec{}, fmt.Errorf("icmp probe takes no port or path: %q", value)
+		}
+		return spec, nil
+	case ProbeTCP:
+		if !hasPort || hasPath {
+			return ProbeSpec{}, fmt.Errorf("tcp probe needs a port and takes no path: %q", value)
+		}
+	case ProbeHTTP, ProbeHTTPS:
+		spec.Path = "/" + path
+	case ProbeDNS:
+		if path == "" {
+			return ProbeSpec{}, fmt.Errorf("dns probe needs a name to resolve: %q", value)
+		}
+		spec.Path = path
+	default:
+		return ProbeSpec{}, fmt.Errorf("unknown probe type: %q", value)
+	}
+
+	spec.Port = defaultProbePorts[probeType]
+	if hasPort {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return ProbeSpec{}, fmt.Errorf("invalid probe port: %q", value)
+		}
+		spec.Port = n
+	}
+
+	return spec, nil
+}
+
+// IsValidNetns reports whether mode is one of the Netns modes.
+func IsValidNetns(mode string) bool {
+	switch mode {
+	case NetnsOff, NetnsAddresses, NetnsLoopback, NetnsGateway:
+		return true
+	default:
+		return false
+	}
+}
+
+// String writes the probe back in the syntax of ParseProbeSpec.
+func (p ProbeSpec) String() string {
+	switch p.Type {
+	case ProbeTCP:
+		return fmt.Sprintf("%s:%d", p.Type, p.Port)
+	case ProbeHTTP, ProbeHTTPS:
+		return fmt.Sprintf("%s:%d%s", p.Type, p.Port, p.Path)
+	case ProbeDNS:
+		return fmt.Sprintf("%s:%d/%s", p.Type, p.Port, p.Path)
+	default:
+		return ProbeICMP
+	}
+}
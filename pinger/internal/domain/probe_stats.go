@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// ProbeStats sums up the attempts of a probe at an address. An ICMP probe
+// sends many echo requests, the other probes make a single attempt. The
+// round trip times are those of the answered attempts.
+type ProbeStats struct {
+	PacketsSent     int           `json:"packets_sent"`
+	PacketsReceived int           `json:"packets_received"`
+	MinRTT          time.Duration `json:"min_rtt_ns"`
+	AvgRTT          time.Duration `json:"avg_rtt_ns"`
+	MaxRTT          time.Duration `json:"max_rtt_ns"`
+	StdDevRTT       time.Duration `json:"stddev_rtt_ns"`
+}
+
+// SingleAttempt is the outcome of a probe making one attempt, answered
+// after latency or not at all.
+func SingleAttempt(latency time.Duration, answered bool) ProbeStats {
+	if !answered {
+		return ProbeStats{PacketsSent: 1}
+	}
+
+	return ProbeStats{PacketsSent: 1, PacketsReceived: 1, MinRTT: latency, AvgRTT: latency, MaxRTT: latency}
+}
+
+// PacketLoss is the share of unanswered attempts in percent.
+func (s ProbeStats) PacketLoss() float64 {
+	if s.PacketsSent == 0 {
+		return 0
+	}
+
+	return float64(s.PacketsSent-s.PacketsReceived) / float64(s.PacketsSent) * 100
+}
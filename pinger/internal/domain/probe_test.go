@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+)
+
+func TestParseProbeSpec(t *testing.T) {
+	tests := []struct {
+		value string
+		want  domain.ProbeSpec
+	}{
+		{"icmp", domain.ProbeSpec{Type: domain.ProbeICMP}},
+		{"tcp:5432", domain.ProbeSpec{Type: domain.ProbeTCP, Port: 5432}},
+		{"http", domain.ProbeSpec{Type: domain.ProbeHTTP, Port: 80, Path: "/"}},
+		{"http:8080/healthz", domain.ProbeSpec{Type: domain.ProbeHTTP, Port: 8080, Path: "/healthz"}},
+		{"https/status/ready", domain.ProbeSpec{Type: domain.ProbeHTTPS, Port: 443, Path: "/status/ready"}},
+		{"dns/example.com", domain.ProbeSpec{Type: domain.ProbeDNS, Port: 53, Path: "example.com"}},
+		{"dns:5353/db.internal", domain.ProbeSpec{Type: domain.ProbeDNS, Port: 5353, Path: "db.internal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			spec, err := domain.ParseProbeSpec(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec)
+		})
+	}
+}
+
+func TestParseProbeSpec_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"ping",
+		"icmp:80",
+		"icmp/path",
+		"tcp",
+		"tcp:5432/path",
+		"tcp:0",
+		"tcp:65536",
+		"tcp:db",
+		"http:",
+		"dns",
+		"dns:53",
+		"dns/",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			_, err := domain.ParseProbeSpec(value)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProbeSpec_StringRoundTrips(t *testing.T) {
+	for _, value := range []string{"icmp", "tcp:5432", "http:8080/healthz", "https:443/", "dns:53/example.com"} {
+		spec, err := domain.ParseProbeSpec(value)
+		require.NoError(t, err)
+
+		assert.Equal(t, value, spec.String())
+	}
+}
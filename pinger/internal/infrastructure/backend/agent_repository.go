@@ -0,0 +1,132 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+type BackendAgentRepo struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	logger     utils.LoggerInterface
+}
+
+func NewBackendAgentRepo(
+	baseURL, apiKey string,
+	logger utils.LoggerInterface,
+) repositories.AgentRepository {
+	return &BackendAgentRepo{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+	}
+}
+
+func (r *BackendAgentRepo) Register(ctx context.Context, agent domain.AgentInfo) (time.Duration, error) {
+	url := fmt.Sprintf("%s/api/v1/agents/register", r.baseURL)
+	r.logger.Debugf("Sending POST request to %s with data: %+v", url, agent)
+
+	payload := agentPayload(agent)
+	payload["id"] = agent.ID
+
+	resp, err := r.post(ctx, url, payload)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		r.logger.Errorf("API returned error status: %s", resp.Status)
+		return 0, fmt.Errorf("api returned error status: %s", resp.Status)
+	}
+
+	var registration struct {
+		HeartbeatInterval string `json:"heartbeat_interval"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&registration); err != nil {
+		r.logger.Errorf("JSON decode failed: %v", err)
+		return 0, fmt.Errorf("json decode failed: %w", err)
+	}
+
+	interval, err := time.ParseDuration(registration.HeartbeatInterval)
+	if err != nil || interval <= 0 {
+		r.logger.Errorf("Invalid heartbeat interval %q", registration.HeartbeatInterval)
+		return 0, fmt.Errorf("invalid heartbeat interval %q", registration.HeartbeatInterval)
+	}
+
+	r.logger.Infof("Registered agent %s, heartbeat interval %v", agent.ID, interval)
+	return interval, nil
+}
+
+func (r *BackendAgentRepo) Heartbeat(ctx context.Context, agent domain.AgentInfo) error {
+	url := fmt.Sprintf("%s/api/v1/agents/%s/heartbeat", r.baseURL, neturl.PathEscape(agent.ID))
+	r.logger.Debugf("Sending POST request to %s with data: %+v", url, agent)
+
+	resp, err := r.post(ctx, url, agentPayload(agent))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		r.logger.Warnf("Backend does not know agent %s", agent.ID)
+		return repositories.ErrAgentNotRegistered
+	}
+
+	if resp.StatusCode >= 400 {
+		r.logger.Errorf("API returned error status: %s", resp.Status)
+		return fmt.Errorf("api returned error status: %s", resp.Status)
+	}
+
+	r.logger.Debugf("Successfully sent heartbeat of agent %s", agent.ID)
+	return nil
+}
+
+func (r *BackendAgentRepo) post(ctx context.Context, url string, payload map[string]interface{}) (*http.Response, error) {
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		r.logger.Errorf("JSON marshal failed: %v", err)
+		return nil, fmt.Errorf("json marshal failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		r.logger.Errorf("Request creation failed: %v", err)
+		return nil, fmt.Errorf("request creation failed: %w", err)
+	}
+	req.Header.Set("X-Api-Key", r.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Errorf("Request execution failed: %v", err)
+		return nil, fmt.Errorf("request execution failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// agentPayload reports the cycle duration and the ping interval in
+// milliseconds, as the backend expects.
+func agentPayload(agent domain.AgentInfo) map[string]interface{} {
+	return map[string]interface{}{
+		"host_name":       agent.HostName,
+		"version":         agent.Version,
+		"docker_version":  agent.DockerVersion,
+		"container_count": agent.ContainerCount,
+		"cycle_duration":  float64(agent.CycleDuration) / float64(time.Millisecond),
+		"ping_interval":   float64(agent.PingInterval) / float64(time.Millisecond),
+		"overrun_cycles":  agent.OverrunCycles,
+	}
+}
@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+// NATSStatusRepo sends the batches over NATS instead of HTTP. Every batch is
+// a request, so a batch no backend has applied is reported as failed. A batch
+// the backend rejects as invalid wraps repositories.ErrBatchRejected, like
+// on the HTTP transport.
+type NATSStatusRepo struct {
+	conn    *nats.Conn
+	subject string
+	hostID  string
+	timeout time.Duration
+	logger  utils.LoggerInterface
+}
+
+func NewNATSStatusRepo(
+	conn *nats.Conn,
+	subject, hostID string,
+	timeout time.Duration,
+	logger utils.LoggerInterface,
+) repositories.StatusRepository {
+	return &NATSStatusRepo{
+		conn:    conn,
+		subject: subject,
+		hostID:  hostID,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (r *NATSStatusRepo) SendBatch(ctx context.Context, batch domain.StatusBatch) error {
+	r.logger.Debugf("Publishing to %s %d statuses and %d live containers", r.subject, len(batch.Results), len(batch.LiveContainerIDs))
+
+	body, err := json.Marshal(newBatchRequest(r.hostID, batch))
+	if err != nil {
+		r.logger.Errorf("JSON marshal failed: %v", err)
+		return fmt.Errorf("json marshal failed: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	msg, err := r.conn.RequestWithContext(ctx, r.subject, body)
+	if errors.Is(err, nats.ErrNoResponders) {
+		r.logger.Errorf("No backend is subscribed to %s", r.subject)
+		return fmt.Errorf("no backend is subscribed to %s: %w", r.subject, err)
+	}
+	if err != nil {
+		r.logger.Errorf("Request execution failed: %v", err)
+		return fmt.Errorf("request execution failed: %w", err)
+	}
+
+	var reply batchReply
+	if err := json.Unmarshal(msg.Data, &reply); err != nil {
+		r.logger.Errorf("JSON decode failed: %v", err)
+		return fmt.Errorf("json decode failed: %w", err)
+	}
+
+	if reply.Rejected {
+		r.logger.Errorf("Backend rejected batch: %s", reply.Error)
+		return fmt.Errorf("%w: %s", repositories.ErrBatchRejected, reply.Error)
+	}
+	if reply.Error != "" {
+		r.logger.Errorf("Backend failed to apply batch: %s", reply.Error)
+		return fmt.Errorf("backend failed to apply batch: %s", reply.Error)
+	}
+
+	r.logger.Debugf("Batch applied: %+v", reply.batchResponse)
+	return nil
+}
+
+// ConnectNATS connects to the NATS server, retrying in the background when it
+// is not reachable yet.
+func ConnectNATS(url string, logger utils.LoggerInterface) (*nats.Conn, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("docker-monitoring-pinger"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			logger.Warnf("Disconnected from NATS: %v", err)
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			logger.Infof("Reconnected to NATS at %s", conn.ConnectedUrl())
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nats connect failed: %w", err)
+	}
+
+	return conn, nil
+}
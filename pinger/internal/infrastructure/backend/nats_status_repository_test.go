@@ -0,0 +1,116 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/backend"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+const subject = "container_status.batch"
+
+// connectNATS runs an in-process NATS server on a free port and connects to
+// it.
+func connectNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second), "NATS server did not start")
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	return conn
+}
+
+// replyWith answers every batch like the backend does and hands the decoded
+// request to received.
+func replyWith(t *testing.T, conn *nats.Conn, reply string, received chan<- map[string]any) {
+	t.Helper()
+
+	_, err := conn.Subscribe(subject, func(msg *nats.Msg) {
+		var body map[string]any
+		_ = json.Unmarshal(msg.Data, &body)
+		if received != nil {
+			received <- body
+		}
+		_ = msg.Respond([]byte(reply))
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.Flush())
+}
+
+func newNATSRepo(conn *nats.Conn) repositories.StatusRepository {
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+
+	return backend.NewNATSStatusRepo(conn, subject, "node-1", 2*time.Second, logger)
+}
+
+func testBatch() domain.StatusBatch {
+	return domain.StatusBatch{
+		Results: []domain.PingResult{
+			{ContainerID: "web", IP: "172.18.0.2", Name: "/web", Status: "running", PingTime: 420, Success: true},
+		},
+		LiveContainerIDs: []string{"web"},
+	}
+}
+
+func TestNATSStatusRepo_SendBatch_Applied(t *testing.T) {
+	conn := connectNATS(t)
+	received := make(chan map[string]any, 1)
+	replyWith(t, conn, `{"created":1,"updated":0,"deleted":0,"skipped":0}`, received)
+
+	err := newNATSRepo(conn).SendBatch(context.Background(), testBatch())
+
+	require.NoError(t, err)
+	body := <-received
+	assert.Equal(t, "node-1", body["host_id"])
+	statuses := body["statuses"].([]any)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, 0.42, statuses[0].(map[string]any)["ping_time"])
+}
+
+func TestNATSStatusRepo_SendBatch_RejectedWrapsErrBatchRejected(t *testing.T) {
+	conn := connectNATS(t)
+	replyWith(t, conn, `{"error":"Validation error: status","rejected":true}`, nil)
+
+	err := newNATSRepo(conn).SendBatch(context.Background(), testBatch())
+
+	assert.ErrorIs(t, err, repositories.ErrBatchRejected)
+}
+
+func TestNATSStatusRepo_SendBatch_FailedIsNotRejected(t *testing.T) {
+	conn := connectNATS(t)
+	replyWith(t, conn, `{"error":"Failed to apply container status batch"}`, nil)
+
+	err := newNATSRepo(conn).SendBatch(context.Background(), testBatch())
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, repositories.ErrBatchRejected)
+}
+
+func TestNATSStatusRepo_SendBatch_NoBackend(t *testing.T) {
+	conn := connectNATS(t)
+
+	err := newNATSRepo(conn).SendBatch(context.Background(), testBatch())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, nats.ErrNoResponders)
+	assert.NotErrorIs(t, err, repositories.ErrBatchRejected)
+}
@@ -0,0 +1,113 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the backend while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// RetryPolicy describes how often a request failing with a network error or a
+// 5xx status is retried. The backoff doubles after every failed attempt up to
+// MaxBackoff, and a random half of it is cut off so pingers do not retry in
+// lockstep.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// CircuitBreakerPolicy opens the breaker after FailureThreshold failed
+// attempts in a row. Once Cooldown has passed a single trial attempt is let
+// through, which closes the breaker again when it succeeds.
+type CircuitBreakerPolicy struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	trial    bool
+	openedAt time.Time
+}
+
+func newCircuitBreaker(policy CircuitBreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{policy: policy}
+}
+
+// allow reports whether an attempt may be made now.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+
+	if b.trial || time.Since(b.openedAt) < b.policy.Cooldown {
+		return false
+	}
+
+	b.trial = true
+
+	return true
+}
+
+// record takes the outcome of an attempt and reports whether it opened the
+// breaker.
+func (b *circuitBreaker) record(success bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.failures = 0
+		b.open = false
+		b.trial = false
+		return false
+	}
+
+	b.failures++
+	if !b.trial && (b.open || b.failures < b.policy.FailureThreshold) {
+		return false
+	}
+
+	wasOpen := b.open
+	b.open = true
+	b.trial = false
+	b.openedAt = time.Now()
+
+	return !wasOpen
+}
+
+// jitter returns a random duration between half of backoff and backoff.
+func jitter(backoff time.Duration) time.Duration {
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+
+	return half + rand.N(half+1)
+}
+
+// sleepContext waits for the given duration and reports false if the context
+// got cancelled first.
+func sleepContext(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
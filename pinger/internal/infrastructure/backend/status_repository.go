@@ -16,35 +16,111 @@ import (
 type BackendStatusRepo struct {
 	baseURL    string
 	apiKey     string
+	hostID     string
 	httpClient *http.Client
+	retry      RetryPolicy
+	breaker    *circuitBreaker
 	logger     utils.LoggerInterface
 }
 
+// batchItem carries the times in milliseconds, the unit of the backend.
+type batchItem struct {
+	ContainerID string  `json:"container_id"`
+	IP          string  `json:"ip_address,omitempty"`
+	Name        string  `json:"name"`
+	Status      string  `json:"status"`
+	PingTime    float64 `json:"ping_time"`
+	LastPing    string  `json:"last_successful_ping,omitempty"`
+	ProbeType   string  `json:"probe_type,omitempty"`
+	// Stats is omitted when no address was probed, the backend keeps the
+	// stored ones then.
+	Stats *batchStats `json:"ping_stats,omitempty"`
+	// Health is omitted for batches spooled before it was reported, so the
+	// backend keeps the stored one.
+	Health *batchHealth `json:"health,omitempty"`
+	// Networks is null for batches spooled before the networks were
+	// reported, so the backend keeps the stored ones.
+	Networks []batchNetwork `json:"networks"`
+}
+
+type batchNetwork struct {
+	Name         string   `json:"network_name"`
+	MAC          string   `json:"mac_address,omitempty"`
+	Gateway      string   `json:"gateway,omitempty"`
+	IPv4         string   `json:"ipv4_address,omitempty"`
+	IPv4PingTime *float64 `json:"ipv4_ping_time"`
+	IPv6         string   `json:"ipv6_address,omitempty"`
+	IPv6PingTime *float64 `json:"ipv6_ping_time"`
+}
+
+type batchStats struct {
+	PacketsSent     int     `json:"packets_sent"`
+	PacketsReceived int     `json:"packets_received"`
+	PacketLoss      float64 `json:"packet_loss_percent"`
+	MinRTT          float64 `json:"rtt_min_ms"`
+	AvgRTT          float64 `json:"rtt_avg_ms"`
+	MaxRTT          float64 `json:"rtt_max_ms"`
+	StdDevRTT       float64 `json:"rtt_stddev_ms"`
+}
+
+type batchHealth struct {
+	Status        string `json:"status"`
+	FailingStreak int    `json:"failing_streak"`
+	LastOutput    string `json:"last_output"`
+}
+
+// batchRequest marks a replayed batch, so the backend does not delete the
+// containers created since it was checked.
+type batchRequest struct {
+	HostID           string      `json:"host_id"`
+	CheckedAt        string      `json:"checked_at,omitempty"`
+	Replayed         bool        `json:"replayed,omitempty"`
+	Statuses         []batchItem `json:"statuses"`
+	LiveContainerIDs []string    `json:"live_container_ids"`
+}
+
+// batchReply is the answer of the backend to a batch sent over the queue.
+// Rejected is set when the batch itself is invalid.
+type batchReply struct {
+	batchResponse
+	Error    string `json:"error"`
+	Rejected bool   `json:"rejected"`
+}
+
+type batchResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
+}
+
 func NewBackendStatusRepo(
-	baseURL, apiKey string,
+	baseURL, apiKey, hostID string,
+	timeout time.Duration,
+	retry RetryPolicy,
+	breaker CircuitBreakerPolicy,
 	logger utils.LoggerInterface,
 ) repositories.StatusRepository {
 	return &BackendStatusRepo{
 		baseURL:    baseURL,
 		apiKey:     apiKey,
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		hostID:     hostID,
+		httpClient: &http.Client{Timeout: timeout},
+		retry:      retry,
+		breaker:    newCircuitBreaker(breaker),
 		logger:     logger,
 	}
 }
 
-func (r *BackendStatusRepo) UpdateStatus(ctx context.Context, containerID string, pingTime int64, name, status string, success bool) error {
-	url := fmt.Sprintf("%s/api/v1/container_status/%s", r.baseURL, containerID)
-	r.logger.Debugf("Sending PATCH request to %s with data: name=%s, status=%s, ping_time=%d", url, name, status, pingTime)
-
-	payload := map[string]interface{}{
-		"ping_time": pingTime,
-		"name":      name,
-		"status":    status,
-	}
+// SendBatch retries network errors and 5xx statuses, which is safe as the
+// backend upserts the statuses of a batch. Other 4xx statuses are returned
+// at once, wrapping repositories.ErrBatchRejected when the batch itself is
+// invalid.
+func (r *BackendStatusRepo) SendBatch(ctx context.Context, batch domain.StatusBatch) error {
+	url := fmt.Sprintf("%s/api/v1/container_status/batch", r.baseURL)
+	r.logger.Debugf("Sending POST request to %s with %d statuses and %d live containers", url, len(batch.Results), len(batch.LiveContainerIDs))
 
-	if success {
-		payload["last_successful_ping"] = time.Now().Format(time.RFC3339)
-	}
+	payload := newBatchRequest(r.hostID, batch)
 
 	jsonBody, err := json.Marshal(payload)
 	if err != nil {
@@ -52,129 +128,151 @@ func (r *BackendStatusRepo) UpdateStatus(ctx context.Context, containerID string
 		return fmt.Errorf("json marshal failed: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		r.logger.Errorf("Request creation failed: %v", err)
-		return fmt.Errorf("request creation failed: %w", err)
-	}
-	req.Header.Set("X-Api-Key", r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		r.logger.Errorf("Request execution failed: %v", err)
-		return fmt.Errorf("request execution failed: %w", err)
-	}
-	defer resp.Body.Close()
+	backoff := r.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		if !r.breaker.allow() {
+			r.logger.Debugf("Circuit breaker is open, not sending batch to %s", url)
+			return ErrCircuitOpen
+		}
 
-	if resp.StatusCode >= 400 {
-		r.logger.Errorf("API returned error status: %s", resp.Status)
-		return fmt.Errorf("api returned error status: %s", resp.Status)
-	}
+		retryable, err := r.postBatch(ctx, url, jsonBody)
+		if ctx.Err() != nil {
+			return err
+		}
+		if r.breaker.record(!retryable) {
+			r.logger.Warnf("Backend keeps failing, opening circuit breaker for %v", r.breaker.policy.Cooldown)
+		}
+		if !retryable {
+			return err
+		}
 
-	r.logger.Debugf("Successfully updated status for container ID %s", containerID)
-	return nil
-}
+		r.logger.Warnf("Attempt %d of %d to send batch failed: %v", attempt, r.retry.MaxAttempts, err)
+		if attempt >= r.retry.MaxAttempts || !sleepContext(ctx, jitter(backoff)) {
+			return err
+		}
 
-func (r *BackendStatusRepo) CreateStatus(ctx context.Context, containerID, ip string, pingTime int64, name, status string) error {
-	url := fmt.Sprintf("%s/api/v1/container_status", r.baseURL)
-	r.logger.Debugf("Sending POST request to %s with data: container_id=%s, name=%s, status=%s, ping_time=%d", url, containerID, name, status, pingTime)
-
-	payload := map[string]interface{}{
-		"container_id":         containerID,
-		"ip_address":           ip,
-		"ping_time":            pingTime,
-		"last_successful_ping": time.Now().Format(time.RFC3339),
-		"name":                 name,
-		"status":               status,
-	}
-
-	jsonBody, err := json.Marshal(payload)
-	if err != nil {
-		r.logger.Errorf("JSON marshal failed: %v", err)
-		return fmt.Errorf("json marshal failed: %w", err)
+		backoff = min(backoff*2, r.retry.MaxBackoff)
 	}
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+// postBatch makes a single attempt and reports whether its failure is worth
+// retrying.
+func (r *BackendStatusRepo) postBatch(ctx context.Context, url string, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
 		r.logger.Errorf("Request creation failed: %v", err)
-		return fmt.Errorf("request creation failed: %w", err)
+		return false, fmt.Errorf("request creation failed: %w", err)
 	}
 	req.Header.Set("X-Api-Key", r.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request execution failed: %w", err)
+		return true, fmt.Errorf("request execution failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
+	switch {
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("api returned error status: %s", resp.Status)
+	case resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusRequestEntityTooLarge,
+		resp.StatusCode == http.StatusUnprocessableEntity:
+		r.logger.Errorf("API rejected batch: %s", resp.Status)
+		return false, fmt.Errorf("%w: %s", repositories.ErrBatchRejected, resp.Status)
+	case resp.StatusCode >= 400:
 		r.logger.Errorf("API returned error status: %s", resp.Status)
-		return fmt.Errorf("api returned error status: %s", resp.Status)
+		return false, fmt.Errorf("api returned error status: %s", resp.Status)
 	}
 
-	r.logger.Infof("Successfully created status for container ID %s", containerID)
-	return nil
-}
+	var result batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		r.logger.Errorf("JSON decode failed: %v", err)
+		return false, fmt.Errorf("json decode failed: %w", err)
+	}
 
-func (r *BackendStatusRepo) GetStatuses(ctx context.Context) ([]domain.PingResult, error) {
-	url := fmt.Sprintf("%s/api/v1/container_status", r.baseURL)
-	r.logger.Debugf("Sending GET request to %s", url)
+	r.logger.Debugf("Batch applied: %+v", result)
+	return false, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
-	if err != nil {
-		r.logger.Errorf("Request creation failed: %v", err)
-		return nil, fmt.Errorf("request creation failed: %w", err)
+// newBatchRequest builds the body both transports send. last_successful_ping
+// is only set for a successful ping. The ping times of the results are in
+// microseconds and sent in milliseconds; a failed ping keeps its -1.
+func newBatchRequest(hostID string, batch domain.StatusBatch) batchRequest {
+	payload := batchRequest{
+		HostID:           hostID,
+		Replayed:         batch.Replayed,
+		Statuses:         make([]batchItem, 0, len(batch.Results)),
+		LiveContainerIDs: batch.LiveContainerIDs,
 	}
-	req.Header.Set("X-Api-Key", r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		r.logger.Errorf("Request execution failed: %v", err)
-		return nil, fmt.Errorf("request execution failed: %w", err)
+	if !batch.CheckedAt.IsZero() {
+		payload.CheckedAt = batch.CheckedAt.Format(time.RFC3339Nano)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		r.logger.Errorf("API returned error status: %s", resp.Status)
-		return nil, fmt.Errorf("api returned error status: %s", resp.Status)
+	if payload.LiveContainerIDs == nil {
+		payload.LiveContainerIDs = []string{}
 	}
-
-	var statuses []domain.PingResult
-	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
-		r.logger.Errorf("JSON decode failed: %v", err)
-		return nil, fmt.Errorf("json decode failed: %w", err)
+	for _, result := range batch.Results {
+		item := batchItem{
+			ContainerID: result.ContainerID,
+			IP:          result.IP,
+			Name:        result.Name,
+			Status:      result.Status,
+			PingTime:    -1,
+			ProbeType:   result.ProbeType,
+		}
+		if result.PingTime >= 0 {
+			item.PingTime = microsToMillis(result.PingTime)
+		}
+		if result.Stats != nil {
+			item.Stats = &batchStats{
+				PacketsSent:     result.Stats.PacketsSent,
+				PacketsReceived: result.Stats.PacketsReceived,
+				PacketLoss:      result.Stats.PacketLoss(),
+				MinRTT:          durationToMillis(result.Stats.MinRTT),
+				AvgRTT:          durationToMillis(result.Stats.AvgRTT),
+				MaxRTT:          durationToMillis(result.Stats.MaxRTT),
+				StdDevRTT:       durationToMillis(result.Stats.StdDevRTT),
+			}
+		}
+		if result.Success {
+			item.LastPing = result.LastPing
+		}
+		if result.Health != nil {
+			health := batchHealth(*result.Health)
+			item.Health = &health
+		}
+		if result.Networks != nil {
+			item.Networks = make([]batchNetwork, 0, len(result.Networks))
+			for _, network := range result.Networks {
+				item.Networks = append(item.Networks, batchNetwork{
+					Name:         network.Name,
+					MAC:          network.MAC,
+					Gateway:      network.Gateway,
+					IPv4:         network.IPv4,
+					IPv4PingTime: optionalMillis(network.IPv4PingTime),
+					IPv6:         network.IPv6,
+					IPv6PingTime: optionalMillis(network.IPv6PingTime),
+				})
+			}
+		}
+		payload.Statuses = append(payload.Statuses, item)
 	}
 
-	r.logger.Debugf("Received response: %+v", resp)
-	r.logger.Debugf("Received statuses: %+v", statuses)
-	return statuses, nil
+	return payload
 }
 
-func (r *BackendStatusRepo) DeleteStatus(ctx context.Context, containerID string) error {
-	url := fmt.Sprintf("%s/api/v1/container_status/%s", r.baseURL, containerID)
-	req, err := http.NewRequestWithContext(ctx, "DELETE", url, http.NoBody)
-	if err != nil {
-		r.logger.Errorf("Request creation failed: %v", err)
-		return fmt.Errorf("request creation failed: %w", err)
-	}
-	req.Header.Set("X-Api-Key", r.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+func microsToMillis(micros int64) float64 {
+	return float64(micros) / 1000
+}
 
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		r.logger.Errorf("Request execution failed: %v", err)
-		return fmt.Errorf("request execution failed: %w", err)
-	}
-	defer resp.Body.Close()
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
 
-	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusNoContent {
-		r.logger.Errorf("API returned error status: %s", resp.Status)
-		return fmt.Errorf("api returned error status: %s", resp.Status)
+func optionalMillis(micros *int64) *float64 {
+	if micros == nil {
+		return nil
 	}
 
-	r.logger.Debugf("Successfully deleted status for container ID %s", containerID)
-	return nil
+	millis := microsToMillis(*micros)
+	return &millis
 }
@@ -0,0 +1,179 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/backend"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+// apiStub answers every batch with status, and with the applied counts when
+// it is 200. It counts the requests it got.
+type apiStub struct {
+	status   atomic.Int32
+	requests atomic.Int32
+}
+
+func startAPI(t *testing.T, status int) (*apiStub, string) {
+	t.Helper()
+
+	api := &apiStub{}
+	api.status.Store(int32(status))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.requests.Add(1)
+		assert.Equal(t, "/api/v1/container_status/batch", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		status := int(api.status.Load())
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_ = json.NewEncoder(w).Encode(map[string]int{"created": 1})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return api, server.URL
+}
+
+func newHTTPRepo(url string, maxAttempts int, breaker backend.CircuitBreakerPolicy) repositories.StatusRepository {
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+	retry := backend.RetryPolicy{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	return backend.NewBackendStatusRepo(url, "secret", "node-1", 2*time.Second, retry, breaker, logger)
+}
+
+// noBreaker never opens within a test.
+var noBreaker = backend.CircuitBreakerPolicy{FailureThreshold: 1000, Cooldown: time.Minute}
+
+func TestBackendStatusRepo_SendBatch_Applied(t *testing.T) {
+	api, url := startAPI(t, http.StatusOK)
+
+	err := newHTTPRepo(url, 3, noBreaker).SendBatch(context.Background(), testBatch())
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, api.requests.Load())
+}
+
+func TestBackendStatusRepo_SendBatch_RetriesUpToLimit(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusRequestTimeout, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			api, url := startAPI(t, status)
+
+			err := newHTTPRepo(url, 3, noBreaker).SendBatch(context.Background(), testBatch())
+
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, repositories.ErrBatchRejected)
+			assert.EqualValues(t, 3, api.requests.Load())
+		})
+	}
+}
+
+func TestBackendStatusRepo_SendBatch_SucceedsOnRetry(t *testing.T) {
+	api := &apiStub{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if api.requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"created":1}`))
+	}))
+	defer server.Close()
+
+	err := newHTTPRepo(server.URL, 3, noBreaker).SendBatch(context.Background(), testBatch())
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, api.requests.Load())
+}
+
+func TestBackendStatusRepo_SendBatch_RejectedWithoutRetry(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			api, url := startAPI(t, status)
+
+			err := newHTTPRepo(url, 3, noBreaker).SendBatch(context.Background(), testBatch())
+
+			assert.ErrorIs(t, err, repositories.ErrBatchRejected)
+			assert.EqualValues(t, 1, api.requests.Load())
+		})
+	}
+}
+
+func TestBackendStatusRepo_SendBatch_OtherClientErrorWithoutRetry(t *testing.T) {
+	api, url := startAPI(t, http.StatusUnauthorized)
+
+	err := newHTTPRepo(url, 3, noBreaker).SendBatch(context.Background(), testBatch())
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, repositories.ErrBatchRejected)
+	assert.EqualValues(t, 1, api.requests.Load())
+}
+
+func TestBackendStatusRepo_SendBatch_CircuitBreaker(t *testing.T) {
+	api, url := startAPI(t, http.StatusServiceUnavailable)
+	cooldown := 100 * time.Millisecond
+	repo := newHTTPRepo(url, 1, backend.CircuitBreakerPolicy{FailureThreshold: 3, Cooldown: cooldown})
+	ctx := context.Background()
+
+	// Closed: every batch reaches the backend until the third failure opens
+	// the breaker.
+	for range 3 {
+		err := repo.SendBatch(ctx, testBatch())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, backend.ErrCircuitOpen)
+	}
+	assert.EqualValues(t, 3, api.requests.Load())
+
+	// Open: batches fail without a request.
+	assert.ErrorIs(t, repo.SendBatch(ctx, testBatch()), backend.ErrCircuitOpen)
+	assert.EqualValues(t, 3, api.requests.Load())
+
+	// Half-open: a single failing trial opens it again.
+	time.Sleep(cooldown)
+	err := repo.SendBatch(ctx, testBatch())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, backend.ErrCircuitOpen)
+	assert.EqualValues(t, 4, api.requests.Load())
+	assert.ErrorIs(t, repo.SendBatch(ctx, testBatch()), backend.ErrCircuitOpen)
+	assert.EqualValues(t, 4, api.requests.Load())
+
+	// Half-open: a successful trial closes it.
+	api.status.Store(http.StatusOK)
+	time.Sleep(cooldown)
+	require.NoError(t, repo.SendBatch(ctx, testBatch()))
+	assert.EqualValues(t, 5, api.requests.Load())
+
+	// Closed again: a single failure does not open it.
+	api.status.Store(http.StatusServiceUnavailable)
+	require.Error(t, repo.SendBatch(ctx, testBatch()))
+	api.status.Store(http.StatusOK)
+	require.NoError(t, repo.SendBatch(ctx, testBatch()))
+	assert.EqualValues(t, 7, api.requests.Load())
+}
+
+func TestBackendStatusRepo_SendBatch_StopsRetryingOnCancel(t *testing.T) {
+	api, url := startAPI(t, http.StatusServiceUnavailable)
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+	retry := backend.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	repo := backend.NewBackendStatusRepo(url, "secret", "node-1", 2*time.Second, retry, noBreaker, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := repo.SendBatch(ctx, testBatch())
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, api.requests.Load())
+}
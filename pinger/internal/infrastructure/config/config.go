@@ -12,19 +12,111 @@ type Config struct {
 	Ping    *PingConfig    `mapstructure:"ping" validate:"required"`
 	Docker  *DockerConfig  `mapstructure:"docker"        validate:"required"`
 	Backend *BackendConfig `mapstructure:"backend"       validate:"required"`
+	Agent   *AgentConfig   `mapstructure:"agent"`
+	Spool   *SpoolConfig   `mapstructure:"spool"`
 }
 
+// SpoolConfig keeps the batches the backend did not accept on disk until it
+// is back. Batches are appended to segments of up to SegmentBytes, and the
+// oldest segments are dropped when the spool outgrows MaxBytes.
+type SpoolConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	Dir          string `mapstructure:"dir"           validate:"required_if=Enabled true"`
+	MaxBytes     int64  `mapstructure:"max_bytes"     validate:"required_if=Enabled true,gte=0"`
+	SegmentBytes int64  `mapstructure:"segment_bytes" validate:"required_if=Enabled true,gte=0,ltefield=MaxBytes"`
+}
+
+// AgentConfig identifies the pinger to the backend. Every pinger must have an
+// ID of its own, as the backend scopes the statuses of each host by it. When
+// the ID is empty the name of the Docker host is used.
+type AgentConfig struct {
+	ID string `mapstructure:"id" validate:"omitempty,max=255"`
+}
+
+// BackendConfig locates the backend. The ping results are sent over
+// Transport, "http" (the default) or "nats"; registration and heartbeats
+// always use HTTP.
 type BackendConfig struct {
-	URL    string `mapstructure:"url"     validate:"required,url"`
-	APIKey string `mapstructure:"api_key" validate:"required"`
+	URL            string                `mapstructure:"url"             validate:"required,url"`
+	APIKey         string                `mapstructure:"api_key"         validate:"required"`
+	Transport      string                `mapstructure:"transport"       validate:"omitempty,oneof=http nats"`
+	NATS           *NATSConfig           `mapstructure:"nats"            validate:"required_if=Transport nats"`
+	Timeout        time.Duration         `mapstructure:"timeout"         validate:"gt=0"`
+	Retry          *RetryConfig          `mapstructure:"retry"           validate:"required"`
+	CircuitBreaker *CircuitBreakerConfig `mapstructure:"circuit_breaker" validate:"required"`
 }
 
+// RetryConfig controls how the statuses sent over HTTP are retried on network
+// errors and 5xx statuses. The backoff doubles after every failed attempt up
+// to MaxBackoff, with random jitter.
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"    validate:"gte=1"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" validate:"gt=0"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"     validate:"gtefield=InitialBackoff"`
+}
+
+// CircuitBreakerConfig stops sending to the backend for Cooldown after
+// FailureThreshold failed attempts in a row.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold" validate:"gte=1"`
+	Cooldown         time.Duration `mapstructure:"cooldown"          validate:"gt=0"`
+}
+
+type NATSConfig struct {
+	URL     string        `mapstructure:"url"     validate:"required,url"`
+	Subject string        `mapstructure:"subject" validate:"required"`
+	Timeout time.Duration `mapstructure:"timeout" validate:"required,gt=0"`
+}
+
+// PingConfig schedules the ping cycles. A cycle checks the due containers
+// on at most Workers goroutines, and spreads the start of their checks over
+// Jitter so they are not all probed at the same moment. A cycle that takes
+// longer than PingInterval delays the next one, cycles never overlap.
 type PingConfig struct {
 	PingInterval time.Duration `mapstructure:"ping_interval" validate:"required,gt=4s"`
+	Workers      int           `mapstructure:"workers"       validate:"gte=1"`
+	Jitter       time.Duration `mapstructure:"jitter"        validate:"gte=0,ltfield=PingInterval"`
+	Probe        *ProbeConfig  `mapstructure:"probe"         validate:"required"`
 }
 
+// ProbeConfig is the probe of the containers without a monitoring.probe
+// label. Spec is written as "type[:port][/path]", e.g. "icmp", "tcp:5432"
+// or "http:8080/healthz". ExpectStatus and BodyContains only apply to HTTP
+// probes; a zero ExpectStatus accepts any 2xx and 3xx status. Count is the
+// number of echo requests of an ICMP probe, they are spread over Timeout.
+// Netns is the network namespace the probes run in: "off" probes from the
+// pinger, "addresses", "loopback" and "gateway" enter the namespace of the
+// container and probe its addresses, 127.0.0.1 or its gateways.
+type ProbeConfig struct {
+	Spec         string        `mapstructure:"spec"          validate:"required"`
+	ExpectStatus int           `mapstructure:"expect_status" validate:"omitempty,gte=100,lte=599"`
+	BodyContains string        `mapstructure:"body_contains"`
+	Timeout      time.Duration `mapstructure:"timeout"       validate:"gt=0"`
+	Count        int           `mapstructure:"count"         validate:"gte=1"`
+	Netns        string        `mapstructure:"netns"         validate:"oneof=off addresses loopback gateway"`
+}
+
+// DockerConfig locates the Docker socket. The containers are tracked from
+// the Docker events, and fully listed again every ResyncInterval in case an
+// event was missed. Only the containers matching Include and none of
+// Exclude are monitored.
 type DockerConfig struct {
-	SocketPath string `mapstructure:"socket_path" validate:"required"`
+	SocketPath     string          `mapstructure:"socket_path"     validate:"required"`
+	ResyncInterval time.Duration   `mapstructure:"resync_interval" validate:"gt=0"`
+	Include        *SelectorConfig `mapstructure:"include"`
+	Exclude        *SelectorConfig `mapstructure:"exclude"`
+}
+
+// SelectorConfig selects containers by label ("key" or "key=value"), name
+// regular expression, Compose project, image glob and state. As an include
+// selector a container has to match one entry of every list that is set;
+// as an exclude selector one matching entry of any list is enough.
+type SelectorConfig struct {
+	Labels   []string `mapstructure:"labels"   validate:"dive,required"`
+	Names    []string `mapstructure:"names"    validate:"dive,required"`
+	Projects []string `mapstructure:"projects" validate:"dive,required"`
+	Images   []string `mapstructure:"images"   validate:"dive,required"`
+	States   []string `mapstructure:"states"   validate:"dive,oneof=created restarting running removing paused exited dead"`
 }
 
 func Load(configPath string) (*Config, error) {
@@ -40,6 +132,26 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("config unmarshal error: %w", err)
 	}
 
+	if cfg.Backend != nil {
+		setBackendDefaults(cfg.Backend)
+	}
+
+	if cfg.Ping != nil {
+		setPingDefaults(cfg.Ping)
+	}
+
+	if cfg.Docker != nil {
+		setDockerDefaults(cfg.Docker)
+	}
+
+	if cfg.Agent == nil {
+		cfg.Agent = &AgentConfig{}
+	}
+
+	if cfg.Spool == nil {
+		cfg.Spool = &SpoolConfig{}
+	}
+
 	validate := validator.New()
 	if err := validate.Struct(cfg); err != nil {
 		return nil, fmt.Errorf("config validation error: %w", err)
@@ -47,3 +159,56 @@ func Load(configPath string) (*Config, error) {
 
 	return &cfg, nil
 }
+
+// setPingDefaults fills in the settings older config files do not have.
+func setPingDefaults(ping *PingConfig) {
+	if ping.Workers == 0 {
+		ping.Workers = 32
+	}
+
+	if ping.Probe == nil {
+		ping.Probe = &ProbeConfig{Spec: "icmp", Timeout: 2 * time.Second}
+	}
+
+	if ping.Probe.Count == 0 {
+		ping.Probe.Count = 3
+	}
+
+	if ping.Probe.Netns == "" {
+		ping.Probe.Netns = "off"
+	}
+}
+
+// setDockerDefaults fills in the settings older config files do not have.
+func setDockerDefaults(docker *DockerConfig) {
+	if docker.ResyncInterval == 0 {
+		docker.ResyncInterval = time.Minute
+	}
+
+	if docker.Include == nil {
+		docker.Include = &SelectorConfig{}
+	}
+
+	if docker.Exclude == nil {
+		docker.Exclude = &SelectorConfig{}
+	}
+}
+
+// setBackendDefaults fills in the settings older config files do not have.
+func setBackendDefaults(backend *BackendConfig) {
+	if backend.Transport == "" {
+		backend.Transport = "http"
+	}
+
+	if backend.Timeout == 0 {
+		backend.Timeout = 10 * time.Second
+	}
+
+	if backend.Retry == nil {
+		backend.Retry = &RetryConfig{MaxAttempts: 3, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 5 * time.Second}
+	}
+
+	if backend.CircuitBreaker == nil {
+		backend.CircuitBreaker = &CircuitBreakerConfig{FailureThreshold: 5, Cooldown: 30 * time.Second}
+	}
+}
@@ -3,8 +3,16 @@ package docker
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	dockerClient "github.com/docker/docker/client"
 
 	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
@@ -13,8 +21,24 @@ import (
 	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
 )
 
+// Labels a container overrides its monitoring with.
+const (
+	intervalLabel     = "monitoring.interval"
+	probeLabel        = "monitoring.probe"
+	probeStatusLabel  = "monitoring.probe.status"
+	probeBodyLabel    = "monitoring.probe.body"
+	probeTimeoutLabel = "monitoring.probe.timeout"
+	probeNetnsLabel   = "monitoring.probe.netns"
+)
+
+// maxHealthOutput bounds the health check output kept, as Docker does.
+const maxHealthOutput = 4096
+
 type DockerContainerRepo struct {
-	client *dockerClient.Client
+	client   *dockerClient.Client
+	selector *selector
+	// netns is the default network namespace mode of the probes.
+	netns  string
 	logger utils.LoggerInterface
 }
 
@@ -30,7 +54,12 @@ func NewDockerContainerRepo(
 		return nil, fmt.Errorf("docker client init failed: %w", err)
 	}
 
-	return &DockerContainerRepo{client: client, logger: logger}, nil
+	sel, err := newSelector(cfg.Docker.Include, cfg.Docker.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("container selector init failed: %w", err)
+	}
+
+	return &DockerContainerRepo{client: client, selector: sel, netns: cfg.Ping.Probe.Netns, logger: logger}, nil
 }
 
 func (r *DockerContainerRepo) GetContainers(ctx context.Context) ([]domain.ContainerInfo, error) {
@@ -49,19 +78,347 @@ func (r *DockerContainerRepo) GetContainers(ctx context.Context) ([]domain.Conta
 
 	containerList := make([]domain.ContainerInfo, 0, len(containers))
 	for i := range containers {
-		var ip string
-		for _, n := range containers[i].NetworkSettings.Networks {
-			ip = n.IPAddress
-			break
+		// The list shows the image ID instead of the name the container was
+		// created with once the name points to another image; the selectors
+		// see the name, as they do for an inspect.
+		image := containers[i].Image
+		if image == containers[i].ImageID {
+			image = r.configImage(ctx, containers[i].ID, image)
+		}
+		c := candidate{
+			name:   containers[i].Names[0],
+			image:  image,
+			state:  containers[i].State,
+			labels: containers[i].Labels,
+		}
+		if !r.selector.matches(c) {
+			r.logger.Debugf("Container %s (ID: %s) is not selected for monitoring", c.name, containers[i].ID)
+			continue
 		}
 
-		containerList = append(containerList, domain.ContainerInfo{
+		info := domain.ContainerInfo{
 			ContainerID: containers[i].ID,
-			IP:          ip,
 			Name:        containers[i].Names[0],
 			Status:      containers[i].State,
-		})
+			Health:      domain.ContainerHealth{Status: domain.HealthNone},
+		}
+		if containers[i].NetworkSettings != nil {
+			info.Networks = toAttachments(containers[i].NetworkSettings.Networks)
+			info.IP = primaryIP(info.Networks)
+		}
+		r.applyLabels(&info, containers[i].Labels)
+
+		// The list only shows the health in the status text, e.g. "Up 2
+		// minutes (healthy)"; the streak and the output need an inspect, as
+		// does the PID a netns probe enters the namespace of.
+		if containers[i].State == "running" &&
+			(strings.Contains(containers[i].Status, "health") || r.probeNetns(info) != domain.NetnsOff) {
+			if state := r.inspectState(ctx, containers[i].ID); state != nil {
+				info.Health = toHealth(state)
+				info.Pid = r.probePid(info, state)
+			}
+		}
+
+		containerList = append(containerList, info)
 	}
 
 	return containerList, nil
 }
+
+func (r *DockerContainerRepo) GetContainer(ctx context.Context, containerID string) (domain.ContainerInfo, error) {
+	r.logger.Debugf("Inspecting container %s", containerID)
+	c, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		if dockerClient.IsErrNotFound(err) {
+			return domain.ContainerInfo{}, repositories.ErrContainerNotFound
+		}
+		r.logger.Errorf("Container inspect failed for %s: %v", containerID, err)
+		return domain.ContainerInfo{}, fmt.Errorf("container inspect failed: %w", err)
+	}
+
+	info := domain.ContainerInfo{
+		ContainerID: c.ID,
+		Name:        c.Name,
+		Health:      toHealth(c.State),
+	}
+	if c.State != nil {
+		info.Status = c.State.Status
+	}
+
+	var image string
+	var labels map[string]string
+	if c.Config != nil {
+		image, labels = c.Config.Image, c.Config.Labels
+	}
+	if !r.selector.matches(candidate{name: c.Name, image: image, state: info.Status, labels: labels}) {
+		r.logger.Debugf("Container %s (ID: %s) is not selected for monitoring", c.Name, c.ID)
+		return domain.ContainerInfo{}, repositories.ErrContainerExcluded
+	}
+
+	if c.NetworkSettings != nil {
+		info.Networks = toAttachments(c.NetworkSettings.Networks)
+		info.IP = primaryIP(info.Networks)
+	}
+	r.applyLabels(&info, labels)
+	info.Pid = r.probePid(info, c.State)
+
+	return info, nil
+}
+
+func (r *DockerContainerRepo) WatchEvents(ctx context.Context) (<-chan domain.ContainerEvent, <-chan error) {
+	r.logger.Debug("Subscribing to Docker events")
+	// The client subscribes on a goroutine of its own; the daemon replays the
+	// events since the call that happen before the subscription is made.
+	now := time.Now()
+	messages, errs := r.client.Events(ctx, events.ListOptions{
+		Since: fmt.Sprintf("%d.%09d", now.Unix(), now.Nanosecond()),
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("type", string(events.NetworkEventType)),
+			filters.Arg("event", string(events.ActionStart)),
+			filters.Arg("event", string(events.ActionDie)),
+			filters.Arg("event", string(events.ActionStop)),
+			filters.Arg("event", string(events.ActionDestroy)),
+			filters.Arg("event", string(events.ActionConnect)),
+			filters.Arg("event", string(events.ActionDisconnect)),
+			filters.Arg("event", string(events.ActionHealthStatus)),
+		),
+	})
+
+	out := make(chan domain.ContainerEvent)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				outErrs <- fmt.Errorf("docker events stream failed: %w", err)
+				return
+			case msg := <-messages:
+				event, ok := toContainerEvent(msg)
+				if !ok {
+					continue
+				}
+				r.logger.Debugf("Docker event %s for container %s", event.Action, event.ContainerID)
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, outErrs
+}
+
+func (r *DockerContainerRepo) GetEngineInfo(ctx context.Context) (domain.EngineInfo, error) {
+	r.logger.Debug("Getting Docker engine info")
+	info, err := r.client.Info(ctx)
+	if err != nil {
+		r.logger.Errorf("Docker info failed: %v", err)
+		return domain.EngineInfo{}, fmt.Errorf("docker info failed: %w", err)
+	}
+
+	return domain.EngineInfo{
+		HostName: info.Name,
+		Version:  info.ServerVersion,
+	}, nil
+}
+
+// inspectState reads the state of a listed container. A container that
+// cannot be inspected, e.g. as it was just removed, is reported without
+// health and PID; the events and the next resync catch up with it.
+func (r *DockerContainerRepo) inspectState(ctx context.Context, containerID string) *types.ContainerState {
+	c, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		r.logger.Warnf("Container inspect failed for %s, reporting it without health: %v", containerID, err)
+		return nil
+	}
+
+	return c.State
+}
+
+// configImage is the image name the container was created with, or
+// fallback when the container cannot be inspected.
+func (r *DockerContainerRepo) configImage(ctx context.Context, containerID, fallback string) string {
+	c, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil || c.Config == nil {
+		r.logger.Warnf("Container inspect failed for %s, selecting it by image %s: %v", containerID, fallback, err)
+		return fallback
+	}
+
+	return c.Config.Image
+}
+
+// probePid is the PID a netns probe of the container enters the namespace
+// of, zero when it is probed from the host. Both the list and the inspect
+// fill it this way, so a resync does not see a change in it.
+func (r *DockerContainerRepo) probePid(info domain.ContainerInfo, state *types.ContainerState) int {
+	if r.probeNetns(info) == domain.NetnsOff {
+		return 0
+	}
+
+	return runningPid(state)
+}
+
+// probeNetns is the network namespace mode the container is probed in.
+func (r *DockerContainerRepo) probeNetns(info domain.ContainerInfo) string {
+	if info.Probe.Netns != "" {
+		return info.Probe.Netns
+	}
+
+	return r.netns
+}
+
+// applyLabels reads the monitoring overrides of a container. The
+// monitoring.probe.* labels refine the probe of monitoring.probe, except
+// monitoring.probe.netns, which also applies to the default probe. An
+// invalid label is ignored with a warning, the container is still
+// monitored.
+func (r *DockerContainerRepo) applyLabels(info *domain.ContainerInfo, labels map[string]string) {
+	if value, ok := labels[intervalLabel]; ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			r.logger.Warnf("Ignoring label %s=%q of container %s: not a positive duration", intervalLabel, value, info.Name)
+		} else {
+			info.Interval = interval
+		}
+	}
+
+	if value, ok := labels[probeLabel]; ok {
+		probe, err := domain.ParseProbeSpec(value)
+		if err != nil {
+			r.logger.Warnf("Ignoring label %s of container %s: %v", probeLabel, info.Name, err)
+		} else {
+			r.refineProbe(&probe, info.Name, labels)
+			info.Probe = probe
+		}
+	}
+
+	if value, ok := labels[probeNetnsLabel]; ok {
+		if domain.IsValidNetns(value) {
+			info.Probe.Netns = value
+		} else {
+			r.logger.Warnf("Ignoring label %s=%q of container %s: not one of off, addresses, loopback, gateway",
+				probeNetnsLabel, value, info.Name)
+		}
+	}
+}
+
+// refineProbe applies the monitoring.probe.* labels to the probe of the
+// monitoring.probe label.
+func (r *DockerContainerRepo) refineProbe(probe *domain.ProbeSpec, name string, labels map[string]string) {
+	if value, ok := labels[probeStatusLabel]; ok {
+		status, err := strconv.Atoi(value)
+		if err != nil || status < 100 || status > 599 {
+			r.logger.Warnf("Ignoring label %s=%q of container %s: not an HTTP status", probeStatusLabel, value, name)
+		} else {
+			probe.ExpectStatus = status
+		}
+	}
+
+	probe.BodyContains = labels[probeBodyLabel]
+
+	if value, ok := labels[probeTimeoutLabel]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			r.logger.Warnf("Ignoring label %s=%q of container %s: not a positive duration", probeTimeoutLabel, value, name)
+		} else {
+			probe.Timeout = timeout
+		}
+	}
+}
+
+// toHealth reads the HEALTHCHECK state of a running container. Docker keeps
+// the last state of a stopped or paused one, which is not reported.
+func toHealth(state *types.ContainerState) domain.ContainerHealth {
+	if state == nil || state.Health == nil || !state.Running || state.Paused || state.Health.Status == types.NoHealthcheck {
+		return domain.ContainerHealth{Status: domain.HealthNone}
+	}
+
+	health := domain.ContainerHealth{
+		Status:        state.Health.Status,
+		FailingStreak: state.Health.FailingStreak,
+	}
+	if n := len(state.Health.Log); n > 0 && state.Health.Log[n-1] != nil {
+		output := strings.TrimSpace(state.Health.Log[n-1].Output)
+		if len(output) > maxHealthOutput {
+			output = strings.ToValidUTF8(output[:maxHealthOutput], "")
+		}
+		health.LastOutput = output
+	}
+
+	return health
+}
+
+// runningPid is the PID of the main process of a running container, zero
+// otherwise.
+func runningPid(state *types.ContainerState) int {
+	if state == nil || !state.Running {
+		return 0
+	}
+
+	return state.Pid
+}
+
+// toContainerEvent maps a Docker event to the container it concerns. Network
+// events carry the container in their attributes; the network destroy event
+// passes the filter too and is skipped.
+func toContainerEvent(msg events.Message) (domain.ContainerEvent, bool) {
+	switch msg.Type {
+	case events.ContainerEventType:
+		return domain.ContainerEvent{ContainerID: msg.Actor.ID, Action: string(msg.Action)}, msg.Actor.ID != ""
+	case events.NetworkEventType:
+		if msg.Action != events.ActionConnect && msg.Action != events.ActionDisconnect {
+			return domain.ContainerEvent{}, false
+		}
+		containerID := msg.Actor.Attributes["container"]
+		return domain.ContainerEvent{ContainerID: containerID, Action: "network " + string(msg.Action)}, containerID != ""
+	default:
+		return domain.ContainerEvent{}, false
+	}
+}
+
+// toAttachments lists the networks ordered by name, as the map order of the
+// Docker API changes between calls.
+func toAttachments(networks map[string]*network.EndpointSettings) []domain.NetworkAttachment {
+	attachments := make([]domain.NetworkAttachment, 0, len(networks))
+	for name, n := range networks {
+		if n == nil {
+			continue
+		}
+
+		attachments = append(attachments, domain.NetworkAttachment{
+			Name:    name,
+			IPv4:    n.IPAddress,
+			IPv6:    n.GlobalIPv6Address,
+			MAC:     n.MacAddress,
+			Gateway: n.Gateway,
+		})
+	}
+	slices.SortFunc(attachments, func(a, b domain.NetworkAttachment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return attachments
+}
+
+// primaryIP is the first IPv4 address of the sorted networks, or the first
+// IPv6 address when no network has an IPv4 one.
+func primaryIP(attachments []domain.NetworkAttachment) string {
+	for _, a := range attachments {
+		if a.IPv4 != "" {
+			return a.IPv4
+		}
+	}
+	for _, a := range attachments {
+		if a.IPv6 != "" {
+			return a.IPv6
+		}
+	}
+
+	return ""
+}
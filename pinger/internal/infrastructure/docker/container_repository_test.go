@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+func TestApplyLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		want     domain.ContainerInfo
+		warnings int
+	}{
+		{
+			name: "no labels",
+			want: domain.ContainerInfo{},
+		},
+		{
+			name:   "interval",
+			labels: map[string]string{intervalLabel: "30s"},
+			want:   domain.ContainerInfo{Interval: 30 * time.Second},
+		},
+		{
+			name:     "invalid interval",
+			labels:   map[string]string{intervalLabel: "often"},
+			warnings: 1,
+		},
+		{
+			name:     "negative interval",
+			labels:   map[string]string{intervalLabel: "-5s"},
+			warnings: 1,
+		},
+		{
+			name:   "tcp probe",
+			labels: map[string]string{probeLabel: "tcp:5432"},
+			want:   domain.ContainerInfo{Probe: domain.ProbeSpec{Type: domain.ProbeTCP, Port: 5432}},
+		},
+		{
+			name:     "invalid probe",
+			labels:   map[string]string{probeLabel: "tcp"},
+			warnings: 1,
+		},
+		{
+			name: "http probe refined",
+			labels: map[string]string{
+				probeLabel:        "http:8080/healthz",
+				probeStatusLabel:  "204",
+				probeBodyLabel:    "ok",
+				probeTimeoutLabel: "2s",
+			},
+			want: domain.ContainerInfo{Probe: domain.ProbeSpec{
+				Type: domain.ProbeHTTP, Port: 8080, Path: "/healthz", ExpectStatus: 204, BodyContains: "ok", Timeout: 2 * time.Second,
+			}},
+		},
+		{
+			name: "invalid refinements",
+			labels: map[string]string{
+				probeLabel:        "http",
+				probeStatusLabel:  "700",
+				probeTimeoutLabel: "0s",
+			},
+			want:     domain.ContainerInfo{Probe: domain.ProbeSpec{Type: domain.ProbeHTTP, Port: 80, Path: "/"}},
+			warnings: 2,
+		},
+		{
+			name:   "refinements without probe",
+			labels: map[string]string{probeStatusLabel: "204", probeTimeoutLabel: "2s"},
+			want:   domain.ContainerInfo{},
+		},
+		{
+			name:   "netns",
+			labels: map[string]string{probeNetnsLabel: domain.NetnsLoopback},
+			want:   domain.ContainerInfo{Probe: domain.ProbeSpec{Netns: domain.NetnsLoopback}},
+		},
+		{
+			name:   "netns with probe",
+			labels: map[string]string{probeLabel: "tcp:80", probeNetnsLabel: domain.NetnsGateway},
+			want:   domain.ContainerInfo{Probe: domain.ProbeSpec{Type: domain.ProbeTCP, Port: 80, Netns: domain.NetnsGateway}},
+		},
+		{
+			name:     "invalid netns",
+			labels:   map[string]string{probeNetnsLabel: "host"},
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.WarnLevel)
+			repo := &DockerContainerRepo{logger: &utils.Logger{SugaredLogger: zap.New(core).Sugar()}}
+
+			var info domain.ContainerInfo
+			repo.applyLabels(&info, tt.labels)
+
+			assert.Equal(t, tt.want, info)
+			assert.Equal(t, tt.warnings, logs.Len(), "warnings: %v", logs.All())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package docker
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/config"
+)
+
+const composeProjectLabel = "com.docker.compose.project"
+
+// candidate is what the selectors look at, taken from the container list or
+// from an inspect.
+type candidate struct {
+	name   string
+	image  string
+	state  string
+	labels map[string]string
+}
+
+// selector decides which containers are monitored: the ones matching the
+// include rules and none of the exclude rules.
+type selector struct {
+	include rules
+	exclude rules
+}
+
+type rules struct {
+	labels   []labelMatch
+	names    []*regexp.Regexp
+	projects []string
+	images   []*regexp.Regexp
+	states   []string
+}
+
+// labelMatch matches a label by key, and by value when value is set.
+type labelMatch struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func newSelector(include, exclude *config.SelectorConfig) (*selector, error) {
+	in, err := newRules(include)
+	if err != nil {
+		return nil, fmt.Errorf("include selector: %w", err)
+	}
+
+	ex, err := newRules(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude selector: %w", err)
+	}
+
+	return &selector{include: in, exclude: ex}, nil
+}
+
+func newRules(cfg *config.SelectorConfig) (rules, error) {
+	if cfg == nil {
+		return rules{}, nil
+	}
+
+	r := rules{
+		projects: cfg.Projects,
+		states:   cfg.States,
+	}
+
+	for _, label := range cfg.Labels {
+		key, value, hasValue := strings.Cut(label, "=")
+		r.labels = append(r.labels, labelMatch{key: key, value: value, hasValue: hasValue})
+	}
+
+	for _, name := range cfg.Names {
+		re, err := regexp.Compile(name)
+		if err != nil {
+			return rules{}, fmt.Errorf("invalid name pattern %q: %w", name, err)
+		}
+		r.names = append(r.names, re)
+	}
+
+	for _, image := range cfg.Images {
+		r.images = append(r.images, globToRegexp(image))
+	}
+
+	return r, nil
+}
+
+func (s *selector) matches(c candidate) bool {
+	return s.include.matchAll(c) && !s.exclude.matchAny(c)
+}
+
+// matchAll requires a match in every list that is set, an empty rule set
+// matches everything.
+func (r rules) matchAll(c candidate) bool {
+	return (len(r.labels) == 0 || r.matchLabel(c)) &&
+		(len(r.names) == 0 || r.matchName(c)) &&
+		(len(r.projects) == 0 || r.matchProject(c)) &&
+		(len(r.images) == 0 || r.matchImage(c)) &&
+		(len(r.states) == 0 || r.matchState(c))
+}
+
+// matchAny requires a match in any list, an empty rule set matches nothing.
+func (r rules) matchAny(c candidate) bool {
+	return r.matchLabel(c) || r.matchName(c) || r.matchProject(c) || r.matchImage(c) || r.matchState(c)
+}
+
+func (r rules) matchLabel(c candidate) bool {
+	for _, m := range r.labels {
+		value, ok := c.labels[m.key]
+		if ok && (!m.hasValue || value == m.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchName matches the name without the leading slash Docker adds.
+func (r rules) matchName(c candidate) bool {
+	name := strings.TrimPrefix(c.name, "/")
+	for _, re := range r.names {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r rules) matchProject(c candidate) bool {
+	project, ok := c.labels[composeProjectLabel]
+	return ok && slices.Contains(r.projects, project)
+}
+
+func (r rules) matchImage(c candidate) bool {
+	for _, re := range r.images {
+		if re.MatchString(c.image) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r rules) matchState(c candidate) bool {
+	return slices.Contains(r.states, c.state)
+}
+
+// globToRegexp compiles a glob where "*" matches any run of characters,
+// slashes included, so "ghcr.io/acme/*" covers every image of the registry
+// path, and "?" matches a single character.
+func globToRegexp(glob string) *regexp.Regexp {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, char := range glob {
+		switch char {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	pattern.WriteString("$")
+
+	return regexp.MustCompile(pattern.String())
+}
@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/config"
+)
+
+func TestSelector_Matches(t *testing.T) {
+	web := candidate{
+		name:  "/shop-web-1",
+		image: "ghcr.io/acme/web:1.4",
+		state: "running",
+		labels: map[string]string{
+			composeProjectLabel: "shop",
+			"monitoring":        "on",
+			"tier":              "frontend",
+		},
+	}
+	db := candidate{
+		name:   "/postgres",
+		image:  "postgres:16",
+		state:  "exited",
+		labels: map[string]string{"tier": "backend"},
+	}
+
+	tests := []struct {
+		name    string
+		include *config.SelectorConfig
+		exclude *config.SelectorConfig
+		want    []bool // web, db
+	}{
+		{"no rules", nil, nil, []bool{true, true}},
+		{"include label key", &config.SelectorConfig{Labels: []string{"monitoring"}}, nil, []bool{true, false}},
+		{"include label key=value", &config.SelectorConfig{Labels: []string{"tier=backend"}}, nil, []bool{false, true}},
+		{"include label key=empty value", &config.SelectorConfig{Labels: []string{"monitoring="}}, nil, []bool{false, false}},
+		{"include any label", &config.SelectorConfig{Labels: []string{"monitoring", "tier=backend"}}, nil, []bool{true, true}},
+		{"include name without slash", &config.SelectorConfig{Names: []string{"^postgres$"}}, nil, []bool{false, true}},
+		{"include name substring", &config.SelectorConfig{Names: []string{"web"}}, nil, []bool{true, false}},
+		{"include project", &config.SelectorConfig{Projects: []string{"shop"}}, nil, []bool{true, false}},
+		{"include image glob", &config.SelectorConfig{Images: []string{"ghcr.io/acme/*"}}, nil, []bool{true, false}},
+		{"include image tag glob", &config.SelectorConfig{Images: []string{"postgres:1?"}}, nil, []bool{false, true}},
+		{"include image glob anchored", &config.SelectorConfig{Images: []string{"acme/*"}}, nil, []bool{false, false}},
+		{"include state", &config.SelectorConfig{States: []string{"running"}}, nil, []bool{true, false}},
+		{
+			"include needs every list",
+			&config.SelectorConfig{Labels: []string{"tier"}, States: []string{"running"}},
+			nil,
+			[]bool{true, false},
+		},
+		{"exclude label key", nil, &config.SelectorConfig{Labels: []string{"monitoring"}}, []bool{false, true}},
+		{"exclude label key=value", nil, &config.SelectorConfig{Labels: []string{"tier=frontend"}}, []bool{false, true}},
+		{"exclude name", nil, &config.SelectorConfig{Names: []string{"^shop-"}}, []bool{false, true}},
+		{"exclude project", nil, &config.SelectorConfig{Projects: []string{"shop"}}, []bool{false, true}},
+		{"exclude image glob", nil, &config.SelectorConfig{Images: []string{"postgres:*"}}, []bool{true, false}},
+		{"exclude state", nil, &config.SelectorConfig{States: []string{"exited", "dead"}}, []bool{true, false}},
+		{
+			"exclude needs any list",
+			nil,
+			&config.SelectorConfig{Names: []string{"^nothing$"}, States: []string{"exited"}},
+			[]bool{true, false},
+		},
+		{
+			"exclude wins over include",
+			&config.SelectorConfig{Labels: []string{"tier"}},
+			&config.SelectorConfig{Images: []string{"ghcr.io/*"}},
+			[]bool{false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := newSelector(tt.include, tt.exclude)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, []bool{sel.matches(web), sel.matches(db)})
+		})
+	}
+}
+
+func TestNewSelector_InvalidNamePattern(t *testing.T) {
+	_, err := newSelector(&config.SelectorConfig{Names: []string{"web("}}, nil)
+	assert.ErrorContains(t, err, "include selector")
+
+	_, err = newSelector(nil, &config.SelectorConfig{Names: []string{"[db"}})
+	assert.ErrorContains(t, err, "exclude selector")
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		image string
+		want  bool
+	}{
+		{"nginx", "nginx", true},
+		{"nginx", "nginx:1.27", false},
+		{"nginx:*", "nginx:1.27", true},
+		{"nginx*", "nginx-exporter:latest", true},
+		{"*/acme/*", "ghcr.io/acme/web:1.4", true},
+		{"ghcr.io/*", "ghcr.io/acme/tools/cli", true},
+		{"ghcr.io/*", "ghcrXio/acme/web", false},
+		{"redis:7.?", "redis:7.2", true},
+		{"redis:7.?", "redis:7.20", false},
+		{"app+(v1)", "app+(v1)", true},
+		{"*", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, globToRegexp(tt.glob).MatchString(tt.image))
+		})
+	}
+}
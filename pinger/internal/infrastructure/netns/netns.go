@@ -0,0 +1,28 @@
+// Package netns runs code in the network namespace of another process, so
+// a container can be probed from the inside without the pinger sharing a
+// network with it. It needs the PID namespace of the host and the
+// CAP_SYS_ADMIN capability.
+package netns
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// ErrUnsupported is returned on systems without network namespaces.
+var ErrUnsupported = errors.New("network namespaces are only supported on linux")
+
+// Dial connects to address from the network namespace of the process pid.
+// A socket stays in the namespace it was created in, so the connection can
+// be used from any goroutine afterwards.
+func Dial(ctx context.Context, pid int, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	var conn net.Conn
+	err := Do(pid, func() error {
+		var err error
+		conn, err = dialer.DialContext(ctx, network, address)
+		return err
+	})
+
+	return conn, err
+}
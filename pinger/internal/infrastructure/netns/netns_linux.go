@@ -0,0 +1,46 @@
+//go:build linux
+
+package netns
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// Do runs fn on the current goroutine, locked to a thread that has entered
+// the network namespace of the process pid. The sockets fn creates belong
+// to that namespace; goroutines fn starts run in the namespace of the
+// pinger.
+func Do(pid int, fn func() error) error {
+	target, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return fmt.Errorf("network namespace of process %d not found: %w", pid, err)
+	}
+	defer target.Close()
+
+	runtime.LockOSThread()
+
+	own, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+	if err != nil {
+		runtime.UnlockOSThread()
+		return fmt.Errorf("network namespace of the pinger not found: %w", err)
+	}
+	defer own.Close()
+
+	if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+		runtime.UnlockOSThread()
+		return fmt.Errorf("entering network namespace of process %d failed: %w", pid, err)
+	}
+	defer func() {
+		// A thread that cannot switch back stays locked, the runtime ends it
+		// together with the goroutine instead of reusing it.
+		if err := unix.Setns(int(own.Fd()), unix.CLONE_NEWNET); err == nil {
+			runtime.UnlockOSThread()
+		}
+	}()
+
+	return fn()
+}
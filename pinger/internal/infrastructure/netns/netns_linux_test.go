@@ -0,0 +1,138 @@
+//go:build linux && integration
+
+package netns_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/netns"
+)
+
+// startNamespace runs a process in a new network namespace with its
+// loopback interface up, as a container has it. It needs root.
+func startNamespace(t *testing.T) int {
+	t.Helper()
+
+	if os.Geteuid() != 0 {
+		t.Skip("entering a network namespace needs root")
+	}
+
+	cmd := exec.Command("unshare", "--net", "sh", "-c", "ip link set lo up && echo ready && exec sleep 60")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("stdout pipe failed: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("unshare not available: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	// The shell runs once unshare entered the new namespace.
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil || line != "ready\n" {
+		t.Fatalf("namespace did not come up: %q, %v", line, err)
+	}
+
+	return cmd.Process.Pid
+}
+
+func TestDoEntersNamespace(t *testing.T) {
+	pid := startNamespace(t)
+
+	var names []string
+	err := netns.Do(pid, func() error {
+		interfaces, err := net.Interfaces()
+		for _, i := range interfaces {
+			names = append(names, i.Name)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if len(names) != 1 || names[0] != "lo" {
+		t.Errorf("interfaces in the namespace = %v, want only lo", names)
+	}
+}
+
+func TestDoRestoresNamespace(t *testing.T) {
+	pid := startNamespace(t)
+
+	own, err := os.Readlink("/proc/thread-self/ns/net")
+	if err != nil {
+		t.Fatalf("reading own namespace failed: %v", err)
+	}
+
+	var inside string
+	err = netns.Do(pid, func() error {
+		inside, err = os.Readlink("/proc/thread-self/ns/net")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if inside == own {
+		t.Errorf("Do ran in the namespace of the test %s", own)
+	}
+
+	after, err := os.Readlink("/proc/thread-self/ns/net")
+	if err != nil {
+		t.Fatalf("reading own namespace failed: %v", err)
+	}
+	if after != own {
+		t.Errorf("namespace after Do = %s, want %s", after, own)
+	}
+}
+
+func TestDialReachesLoopbackOfNamespace(t *testing.T) {
+	pid := startNamespace(t)
+
+	var listener net.Listener
+	err := netns.Do(pid, func() error {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("listening in the namespace failed: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := netns.Dial(ctx, pid, &dialer, "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial in the namespace failed: %v", err)
+	}
+	conn.Close()
+
+	// The listener is not on the loopback interface of the test.
+	if conn, err := dialer.DialContext(ctx, "tcp", listener.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("Dial outside the namespace reached its listener")
+	}
+}
+
+func TestDoUnknownProcess(t *testing.T) {
+	if err := netns.Do(0, func() error { return nil }); err == nil {
+		t.Error("Do succeeded for process 0")
+	}
+}
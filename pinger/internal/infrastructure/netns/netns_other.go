@@ -0,0 +1,8 @@
+//go:build !linux
+
+package netns
+
+// Do fails, other systems have no network namespaces.
+func Do(_ int, _ func() error) error {
+	return ErrUnsupported
+}
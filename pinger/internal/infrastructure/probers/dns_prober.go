@@ -0,0 +1,60 @@
+package probers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/netns"
+)
+
+// DNSProber asks the DNS server of the container to resolve the name of the
+// probe and reports how long the lookup took. The name is resolved as an
+// absolute one, the search domains of the pinger host do not apply. A
+// netns probe asks from the network namespace of the container.
+type DNSProber struct{}
+
+func NewDNSProber() probers.Prober {
+	return &DNSProber{}
+}
+
+func (p *DNSProber) Type() string {
+	return domain.ProbeDNS
+}
+
+func (p *DNSProber) Probe(ctx context.Context, address string, spec domain.ProbeSpec) (domain.ProbeStats, error) {
+	server := net.JoinHostPort(address, strconv.Itoa(spec.Port))
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			if spec.NetnsPID != 0 {
+				return netns.Dial(ctx, spec.NetnsPID, &dialer, network, server)
+			}
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+
+	name := spec.Path
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	started := time.Now()
+	addrs, err := resolver.LookupHost(ctx, name)
+	if err != nil {
+		return domain.SingleAttempt(0, false), fmt.Errorf("lookup of %s failed: %w", spec.Path, err)
+	}
+	latency := time.Since(started)
+
+	if len(addrs) == 0 {
+		return domain.SingleAttempt(0, false), fmt.Errorf("lookup of %s returned no addresses", spec.Path)
+	}
+
+	return domain.SingleAttempt(latency, true), nil
+}
@@ -0,0 +1,107 @@
+package probers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/netns"
+)
+
+// maxProbeBody bounds how much of the body is searched for BodyContains.
+const maxProbeBody = 1 << 20
+
+// HTTPProber sends a GET request and checks the status and the body. The
+// latency is the time until the response headers arrived. Redirects are not
+// followed, and HTTPS certificates are not verified: the container is
+// reached by IP address, which its certificate does not name. A netns
+// probe connects from the network namespace of the container.
+type HTTPProber struct {
+	scheme    string
+	transport *http.Transport
+	client    *http.Client
+}
+
+// NewHTTPProber creates the prober of scheme, "http" or "https".
+func NewHTTPProber(scheme string) probers.Prober {
+	transport := &http.Transport{
+		//nolint:gosec // The address is an IP, certificates cannot match it.
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	}
+
+	return &HTTPProber{
+		scheme:    scheme,
+		transport: transport,
+		client:    newProbeClient(transport),
+	}
+}
+
+func newProbeClient(transport *http.Transport) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (p *HTTPProber) Type() string {
+	return p.scheme
+}
+
+func (p *HTTPProber) Probe(ctx context.Context, address string, spec domain.ProbeSpec) (domain.ProbeStats, error) {
+	url := fmt.Sprintf("%s://%s%s", p.scheme, net.JoinHostPort(address, strconv.Itoa(spec.Port)), spec.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return domain.ProbeStats{}, fmt.Errorf("request build failed: %w", err)
+	}
+
+	// The transport dials on a goroutine of its own, which does not run in
+	// the namespace of the probe; a netns probe dials through a copy.
+	client := p.client
+	if spec.NetnsPID != 0 {
+		transport := p.transport.Clone()
+		transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			var dialer net.Dialer
+			return netns.Dial(ctx, spec.NetnsPID, &dialer, network, address)
+		}
+		client = newProbeClient(transport)
+	}
+
+	started := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return domain.SingleAttempt(0, false), fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	latency := time.Since(started)
+
+	if spec.ExpectStatus != 0 && resp.StatusCode != spec.ExpectStatus {
+		return domain.SingleAttempt(0, false), fmt.Errorf("unexpected status %d, expected %d", resp.StatusCode, spec.ExpectStatus)
+	}
+	if spec.ExpectStatus == 0 && (resp.StatusCode < 200 || resp.StatusCode >= 400) {
+		return domain.SingleAttempt(0, false), fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if spec.BodyContains != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBody))
+		if err != nil {
+			return domain.SingleAttempt(0, false), fmt.Errorf("body read failed: %w", err)
+		}
+		if !strings.Contains(string(body), spec.BodyContains) {
+			return domain.SingleAttempt(0, false), fmt.Errorf("body does not contain %q", spec.BodyContains)
+		}
+	}
+
+	return domain.SingleAttempt(latency, true), nil
+}
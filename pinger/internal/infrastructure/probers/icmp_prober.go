@@ -0,0 +1,83 @@
+package probers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	probing "github.com/prometheus-community/pro-bing"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/netns"
+)
+
+// defaultICMPCount is used for a probe without a count.
+const defaultICMPCount = 3
+
+var errNoReply = errors.New("no echo reply")
+
+// ICMPProber sends the echo requests of the probe spread over the time left
+// until the deadline, so the replies to all of them can arrive in time, and
+// reports the requests sent and answered with their round trip times. A
+// netns probe opens its socket in the network namespace of the container.
+type ICMPProber struct{}
+
+func NewICMPProber() probers.Prober {
+	return &ICMPProber{}
+}
+
+func (p *ICMPProber) Type() string {
+	return domain.ProbeICMP
+}
+
+func (p *ICMPProber) Probe(ctx context.Context, address string, spec domain.ProbeSpec) (domain.ProbeStats, error) {
+	pinger, err := probing.NewPinger(address)
+	if err != nil {
+		return domain.ProbeStats{}, fmt.Errorf("ping init failed: %w", err)
+	}
+
+	pinger.Count = spec.Count
+	if pinger.Count <= 0 {
+		pinger.Count = defaultICMPCount
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		pinger.Timeout = time.Until(deadline)
+		// The requests are a second apart as with ping, or closer when they
+		// would not fit before the deadline with an interval left for the
+		// reply to the last one.
+		pinger.Interval = min(pinger.Interval, pinger.Timeout/time.Duration(pinger.Count+1))
+	}
+	pinger.SetPrivileged(true)
+
+	run := func() error {
+		return pinger.RunWithContext(ctx)
+	}
+	if spec.NetnsPID != 0 {
+		// The socket is opened before RunWithContext starts any goroutine.
+		run = func() error {
+			return netns.Do(spec.NetnsPID, func() error {
+				return pinger.RunWithContext(ctx)
+			})
+		}
+	}
+	if err := run(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return domain.ProbeStats{}, fmt.Errorf("ping execution failed: %w", err)
+	}
+
+	stats := pinger.Statistics()
+	result := domain.ProbeStats{
+		PacketsSent:     stats.PacketsSent,
+		PacketsReceived: stats.PacketsRecv,
+		MinRTT:          stats.MinRtt,
+		AvgRTT:          stats.AvgRtt,
+		MaxRTT:          stats.MaxRtt,
+		StdDevRTT:       stats.StdDevRtt,
+	}
+	if stats.PacketsRecv == 0 {
+		return result, errNoReply
+	}
+
+	return result, nil
+}
@@ -0,0 +1,264 @@
+package probers_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/probers"
+)
+
+func probeContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+func splitAddr(t *testing.T, addr string) (string, int) {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	n, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	return host, n
+}
+
+func assertAnswered(t *testing.T, stats domain.ProbeStats, err error) {
+	t.Helper()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.PacketsSent)
+	assert.Equal(t, 1, stats.PacketsReceived)
+	assert.Positive(t, stats.AvgRTT)
+}
+
+func assertUnanswered(t *testing.T, stats domain.ProbeStats, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.Equal(t, domain.SingleAttempt(0, false), stats)
+}
+
+func TestTCPProber_OpenPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	host, port := splitAddr(t, listener.Addr().String())
+
+	stats, err := probers.NewTCPProber().Probe(probeContext(t), host, domain.ProbeSpec{Type: domain.ProbeTCP, Port: port})
+
+	assertAnswered(t, stats, err)
+}
+
+func TestTCPProber_ClosedPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port := splitAddr(t, listener.Addr().String())
+	require.NoError(t, listener.Close())
+
+	stats, err := probers.NewTCPProber().Probe(probeContext(t), host, domain.ProbeSpec{Type: domain.ProbeTCP, Port: port})
+
+	assertUnanswered(t, stats, err)
+}
+
+// httpTarget serves handler and returns the address and the HTTP probe of
+// path on it.
+func httpTarget(t *testing.T, handler http.HandlerFunc, path string) (string, domain.ProbeSpec) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port := splitAddr(t, u.Host)
+
+	return host, domain.ProbeSpec{Type: domain.ProbeHTTP, Port: port, Path: path}
+}
+
+func TestHTTPProber(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case "/moved":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		case "/starting":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"starting"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		expectStatus int
+		bodyContains string
+		answered     bool
+	}{
+		{"2xx by default", "/healthz", 0, "", true},
+		{"3xx by default, not followed", "/moved", 0, "", true},
+		{"4xx by default", "/missing", 0, "", false},
+		{"5xx by default", "/starting", 0, "", false},
+		{"expected status", "/starting", http.StatusServiceUnavailable, "", true},
+		{"unexpected status", "/healthz", http.StatusNoContent, "", false},
+		{"body contains", "/healthz", 0, `"ok"`, true},
+		{"body does not contain", "/healthz", 0, `"ready"`, false},
+		{"body of an expected status", "/starting", http.StatusServiceUnavailable, "starting", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, spec := httpTarget(t, handler, tt.path)
+			spec.ExpectStatus = tt.expectStatus
+			spec.BodyContains = tt.bodyContains
+
+			stats, err := probers.NewHTTPProber(domain.ProbeHTTP).Probe(probeContext(t), host, spec)
+
+			if tt.answered {
+				assertAnswered(t, stats, err)
+			} else {
+				assertUnanswered(t, stats, err)
+			}
+		})
+	}
+}
+
+func TestHTTPProber_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	host, spec := httpTarget(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}, "/slow")
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	stats, err := probers.NewHTTPProber(domain.ProbeHTTP).Probe(ctx, host, spec)
+
+	assertUnanswered(t, stats, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+// startDNSServer answers A queries for name with 127.0.0.1 over UDP and
+// every other name with NXDOMAIN.
+func startDNSServer(t *testing.T, name string) (string, int) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply, err := dnsReply(buf[:n], name); err == nil {
+				_, _ = conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+
+	return splitAddr(t, conn.LocalAddr().String())
+}
+
+func dnsReply(query []byte, name string) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	reply := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true},
+		Questions: []dnsmessage.Question{question},
+	}
+	switch {
+	case question.Name.String() != name:
+		reply.Header.RCode = dnsmessage.RCodeNameError
+	case question.Type == dnsmessage.TypeA:
+		reply.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+			Body:   &dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}},
+		}}
+	}
+
+	return reply.Pack()
+}
+
+func TestDNSProber(t *testing.T) {
+	host, port := startDNSServer(t, "db.internal.")
+
+	tests := []struct {
+		name     string
+		path     string
+		answered bool
+	}{
+		{"known name", "db.internal", true},
+		{"absolute name", "db.internal.", true},
+		{"unknown name", "cache.internal", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := domain.ProbeSpec{Type: domain.ProbeDNS, Port: port, Path: tt.path}
+
+			stats, err := probers.NewDNSProber().Probe(probeContext(t), host, spec)
+
+			if tt.answered {
+				assertAnswered(t, stats, err)
+			} else {
+				assertUnanswered(t, stats, err)
+			}
+		})
+	}
+}
+
+func TestDNSProber_NoServer(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port := splitAddr(t, conn.LocalAddr().String())
+	require.NoError(t, conn.Close())
+
+	stats, err := probers.NewDNSProber().Probe(probeContext(t), host, domain.ProbeSpec{Type: domain.ProbeDNS, Port: port, Path: "db.internal"})
+
+	assertUnanswered(t, stats, err)
+}
@@ -0,0 +1,47 @@
+package probers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/probers"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/netns"
+)
+
+// TCPProber reports the time a TCP connection to the port takes. A netns
+// probe connects from the network namespace of the container.
+type TCPProber struct {
+	dialer net.Dialer
+}
+
+func NewTCPProber() probers.Prober {
+	return &TCPProber{}
+}
+
+func (p *TCPProber) Type() string {
+	return domain.ProbeTCP
+}
+
+func (p *TCPProber) Probe(ctx context.Context, address string, spec domain.ProbeSpec) (domain.ProbeStats, error) {
+	started := time.Now()
+
+	target := net.JoinHostPort(address, strconv.Itoa(spec.Port))
+	var conn net.Conn
+	var err error
+	if spec.NetnsPID != 0 {
+		conn, err = netns.Dial(ctx, spec.NetnsPID, &p.dialer, "tcp", target)
+	} else {
+		conn, err = p.dialer.DialContext(ctx, "tcp", target)
+	}
+	if err != nil {
+		return domain.SingleAttempt(0, false), fmt.Errorf("connect failed: %w", err)
+	}
+	latency := time.Since(started)
+	_ = conn.Close()
+
+	return domain.SingleAttempt(latency, true), nil
+}
@@ -0,0 +1,346 @@
+package spool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+const (
+	segmentExt = ".seg"
+	cursorFile = "cursor.json"
+)
+
+type segment struct {
+	seq  uint64
+	size int64
+}
+
+// cursor is the position of the next batch to replay.
+type cursor struct {
+	Segment uint64 `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+// SpoolStatusRepo keeps the batches the wrapped repository fails to deliver in
+// append-only segment files, one JSON batch per line, and replays them in
+// order before any newer batch is sent. A replayed batch is marked so, its
+// live containers are outdated. When the spool outgrows maxBytes the oldest
+// segments are dropped.
+type SpoolStatusRepo struct {
+	next         repositories.StatusRepository
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+	logger       utils.LoggerInterface
+
+	mu       sync.Mutex
+	segments []segment
+	nextSeq  uint64
+	cursor   cursor
+}
+
+func NewSpoolStatusRepo(
+	next repositories.StatusRepository,
+	dir string,
+	maxBytes, segmentBytes int64,
+	logger utils.LoggerInterface,
+) (repositories.StatusRepository, error) {
+	r := &SpoolStatusRepo{
+		next:         next,
+		dir:          dir,
+		maxBytes:     maxBytes,
+		segmentBytes: segmentBytes,
+		logger:       logger,
+		nextSeq:      1,
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("spool dir creation failed: %w", err)
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	if len(r.segments) > 0 {
+		logger.Infof("Spool has %d pending bytes in %d segments", r.pendingBytes(), len(r.segments))
+	}
+
+	return r, nil
+}
+
+// SendBatch reports a spooled batch as delivered; it only fails when the
+// batch could not be written to the spool either, or when the backend
+// rejected it, which spooling would not change.
+func (r *SpoolStatusRepo) SendBatch(ctx context.Context, batch domain.StatusBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.segments) > 0 {
+		if err := r.replay(ctx); err != nil {
+			r.logger.Warnf("Backend still unavailable, spooling batch (%d bytes pending): %v", r.pendingBytes(), err)
+			return r.append(batch)
+		}
+		r.logger.Info("Spool replayed")
+	}
+
+	err := r.next.SendBatch(ctx, batch)
+	if errors.Is(err, repositories.ErrBatchRejected) {
+		return err
+	}
+	if err != nil {
+		r.logger.Warnf("Sending batch failed, spooling it: %v", err)
+		return r.append(batch)
+	}
+
+	return nil
+}
+
+// replay sends the spooled batches oldest first and deletes every segment
+// once it is delivered. It stops at the first failure; the cursor makes the
+// next attempt resume with the failed batch, even after a restart.
+func (r *SpoolStatusRepo) replay(ctx context.Context) error {
+	for len(r.segments) > 0 {
+		seg := r.segments[0]
+
+		offset := int64(0)
+		if r.cursor.Segment == seg.seq {
+			offset = r.cursor.Offset
+		}
+
+		if err := r.replaySegment(ctx, seg, offset); err != nil {
+			return err
+		}
+
+		if err := os.Remove(r.segmentPath(seg.seq)); err != nil {
+			return fmt.Errorf("spool segment removal failed: %w", err)
+		}
+		r.segments = r.segments[1:]
+	}
+
+	if err := os.Remove(filepath.Join(r.dir, cursorFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("spool cursor removal failed: %w", err)
+	}
+	r.cursor = cursor{}
+
+	return nil
+}
+
+func (r *SpoolStatusRepo) replaySegment(ctx context.Context, seg segment, offset int64) error {
+	file, err := os.Open(r.segmentPath(seg.seq))
+	if err != nil {
+		return fmt.Errorf("spool segment open failed: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("spool segment seek failed: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("spool segment read failed: %w", err)
+		}
+
+		var batch domain.StatusBatch
+		if err := json.Unmarshal(line, &batch); err != nil {
+			r.logger.Errorf("Skipping corrupted batch in spool segment %d at offset %d: %v", seg.seq, offset, err)
+		} else if err := r.sendReplayed(ctx, batch); err != nil {
+			return err
+		}
+
+		offset += int64(len(line))
+		if err := r.saveCursor(cursor{Segment: seg.seq, Offset: offset}); err != nil {
+			return err
+		}
+	}
+}
+
+// sendReplayed sends a spooled batch, dropping it when the backend rejects
+// it.
+func (r *SpoolStatusRepo) sendReplayed(ctx context.Context, batch domain.StatusBatch) error {
+	batch.Replayed = true
+
+	err := r.next.SendBatch(ctx, batch)
+	if errors.Is(err, repositories.ErrBatchRejected) {
+		r.logger.Errorf("Dropping spooled batch of %v rejected by the backend: %v", batch.CheckedAt, err)
+		return nil
+	}
+
+	return err
+}
+
+func (r *SpoolStatusRepo) append(batch domain.StatusBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		r.logger.Errorf("JSON marshal failed: %v", err)
+		return fmt.Errorf("json marshal failed: %w", err)
+	}
+	data = append(data, '\n')
+
+	if n := len(r.segments); n == 0 || r.segments[n-1].size+int64(len(data)) > r.segmentBytes {
+		r.segments = append(r.segments, segment{seq: r.nextSeq})
+		r.nextSeq++
+	}
+	last := &r.segments[len(r.segments)-1]
+
+	file, err := os.OpenFile(r.segmentPath(last.seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		r.logger.Errorf("Spool segment open failed: %v", err)
+		return fmt.Errorf("spool segment open failed: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		r.logger.Errorf("Spool write failed: %v", err)
+		return fmt.Errorf("spool write failed: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		r.logger.Errorf("Spool sync failed: %v", err)
+		return fmt.Errorf("spool sync failed: %w", err)
+	}
+	last.size += int64(len(data))
+
+	r.enforceLimit()
+
+	return nil
+}
+
+// enforceLimit drops the oldest segments until the spool fits in maxBytes.
+// The segment being written is always kept.
+func (r *SpoolStatusRepo) enforceLimit() {
+	for len(r.segments) > 1 && r.pendingBytes() > r.maxBytes {
+		oldest := r.segments[0]
+		if err := os.Remove(r.segmentPath(oldest.seq)); err != nil {
+			r.logger.Errorf("Spool segment removal failed: %v", err)
+			return
+		}
+		r.segments = r.segments[1:]
+		r.logger.Warnf("Spool is full, dropped segment %d with %d bytes", oldest.seq, oldest.size)
+	}
+}
+
+// load picks up the segments and cursor left by a previous run. A batch cut
+// short by a crash is truncated away, so the next append starts a new line.
+func (r *SpoolStatusRepo) load() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return fmt.Errorf("spool dir read failed: %w", err)
+	}
+
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), segmentExt)
+		if !found || entry.IsDir() {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			r.logger.Warnf("Ignoring unknown file %s in spool dir", entry.Name())
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("spool segment stat failed: %w", err)
+		}
+
+		r.segments = append(r.segments, segment{seq: seq, size: info.Size()})
+	}
+
+	sort.Slice(r.segments, func(i, j int) bool { return r.segments[i].seq < r.segments[j].seq })
+
+	if n := len(r.segments); n > 0 {
+		r.nextSeq = r.segments[n-1].seq + 1
+		if err := r.truncatePartial(&r.segments[n-1]); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.dir, cursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("spool cursor read failed: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.cursor); err != nil {
+		r.logger.Warnf("Ignoring corrupted spool cursor, replaying from the oldest segment: %v", err)
+		r.cursor = cursor{}
+	}
+
+	return nil
+}
+
+func (r *SpoolStatusRepo) truncatePartial(seg *segment) error {
+	data, err := os.ReadFile(r.segmentPath(seg.seq))
+	if err != nil {
+		return fmt.Errorf("spool segment read failed: %w", err)
+	}
+
+	complete := int64(bytes.LastIndexByte(data, '\n') + 1)
+	if complete == seg.size {
+		return nil
+	}
+
+	r.logger.Warnf("Truncating incomplete batch at the end of spool segment %d", seg.seq)
+	if err := os.Truncate(r.segmentPath(seg.seq), complete); err != nil {
+		return fmt.Errorf("spool segment truncate failed: %w", err)
+	}
+	seg.size = complete
+
+	return nil
+}
+
+// saveCursor replaces the cursor file atomically.
+func (r *SpoolStatusRepo) saveCursor(c cursor) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("json marshal failed: %w", err)
+	}
+
+	path := filepath.Join(r.dir, cursorFile)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return fmt.Errorf("spool cursor write failed: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("spool cursor write failed: %w", err)
+	}
+	r.cursor = c
+
+	return nil
+}
+
+func (r *SpoolStatusRepo) pendingBytes() int64 {
+	var total int64
+	for _, seg := range r.segments {
+		total += seg.size
+	}
+
+	return total
+}
+
+func (r *SpoolStatusRepo) segmentPath(seq uint64) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+}
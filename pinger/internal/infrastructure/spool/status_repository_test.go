@@ -0,0 +1,195 @@
+package spool_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/application/repositories"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/domain"
+	"github.com/repyg/DockerMonitoringApp/pinger/internal/infrastructure/spool"
+	"github.com/repyg/DockerMonitoringApp/pinger/pkg/utils"
+)
+
+var errBackendDown = errors.New("backend down")
+
+// backendStub delivers batches while up; a non-negative accept limits how
+// many more it takes.
+type backendStub struct {
+	up        bool
+	accept    int
+	delivered []domain.StatusBatch
+}
+
+func (b *backendStub) SendBatch(_ context.Context, batch domain.StatusBatch) error {
+	if !b.up || b.accept == 0 {
+		return errBackendDown
+	}
+	if b.accept > 0 {
+		b.accept--
+	}
+	b.delivered = append(b.delivered, batch)
+
+	return nil
+}
+
+// ids lists the batches delivered by their single live container, with a
+// trailing "*" for a replayed one.
+func (b *backendStub) ids() []string {
+	ids := make([]string, 0, len(b.delivered))
+	for _, batch := range b.delivered {
+		id := batch.LiveContainerIDs[0]
+		if batch.Replayed {
+			id += "*"
+		}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func batch(id string) domain.StatusBatch {
+	return domain.StatusBatch{LiveContainerIDs: []string{id}}
+}
+
+func newSpool(t *testing.T, next repositories.StatusRepository, dir string, maxBytes, segmentBytes int64) repositories.StatusRepository {
+	t.Helper()
+
+	logger := &utils.Logger{SugaredLogger: zap.NewNop().Sugar()}
+	repo, err := spool.NewSpoolStatusRepo(next, dir, maxBytes, segmentBytes, logger)
+	require.NoError(t, err)
+
+	return repo
+}
+
+func send(t *testing.T, repo repositories.StatusRepository, ids ...string) {
+	t.Helper()
+
+	for _, id := range ids {
+		require.NoError(t, repo.SendBatch(context.Background(), batch(id)))
+	}
+}
+
+func segments(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	require.NoError(t, err)
+
+	return files
+}
+
+func TestSpool_ReplaysInOrderBeforeNewBatch(t *testing.T) {
+	dir := t.TempDir()
+	backend := &backendStub{accept: -1}
+	repo := newSpool(t, backend, dir, 1<<20, 64)
+
+	send(t, repo, "b1", "b2", "b3")
+	assert.Empty(t, backend.delivered)
+	assert.Greater(t, len(segments(t, dir)), 1, "batches should be split over segments")
+
+	backend.up = true
+	send(t, repo, "b4")
+
+	assert.Equal(t, []string{"b1*", "b2*", "b3*", "b4"}, backend.ids())
+	assert.Empty(t, segments(t, dir))
+	assert.NoFileExists(t, filepath.Join(dir, "cursor.json"))
+}
+
+func TestSpool_ResumesFromCursorAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	backend := &backendStub{accept: -1}
+	repo := newSpool(t, backend, dir, 1<<20, 1<<20)
+
+	send(t, repo, "b1", "b2", "b3")
+
+	// The backend takes one batch of the replay and fails again, so b4 is
+	// spooled behind the rest.
+	backend.up, backend.accept = true, 1
+	send(t, repo, "b4")
+	assert.Equal(t, []string{"b1*"}, backend.ids())
+
+	restarted := &backendStub{up: true, accept: -1}
+	repo = newSpool(t, restarted, dir, 1<<20, 1<<20)
+	send(t, repo, "b5")
+
+	assert.Equal(t, []string{"b2*", "b3*", "b4*", "b5"}, restarted.ids())
+}
+
+func TestSpool_TruncatesCutOffLastBatch(t *testing.T) {
+	dir := t.TempDir()
+	backend := &backendStub{accept: -1}
+	repo := newSpool(t, backend, dir, 1<<20, 1<<20)
+
+	send(t, repo, "b1", "b2")
+
+	files := segments(t, dir)
+	require.Len(t, files, 1)
+	file, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"checked_at":"2025-02-09T12:00:00Z","results":[{"contai`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	repo = newSpool(t, backend, dir, 1<<20, 1<<20)
+	send(t, repo, "b3")
+
+	backend.up = true
+	send(t, repo, "b4")
+
+	assert.Equal(t, []string{"b1*", "b2*", "b3*", "b4"}, backend.ids())
+}
+
+func TestSpool_DropsOldestSegmentAtSizeLimit(t *testing.T) {
+	line, err := json.Marshal(batch("b1"))
+	require.NoError(t, err)
+	size := int64(len(line) + 1)
+
+	dir := t.TempDir()
+	backend := &backendStub{accept: -1}
+	// Every batch gets a segment of its own and two of them fit.
+	repo := newSpool(t, backend, dir, 2*size, 1)
+
+	send(t, repo, "b1", "b2", "b3", "b4")
+	assert.Len(t, segments(t, dir), 2)
+
+	backend.up = true
+	send(t, repo, "b5")
+
+	assert.Equal(t, []string{"b3*", "b4*", "b5"}, backend.ids())
+}
+
+func TestSpool_DropsRejectedBatch(t *testing.T) {
+	dir := t.TempDir()
+	backend := &backendStub{accept: -1}
+	repo := newSpool(t, backend, dir, 1<<20, 1<<20)
+
+	send(t, repo, "b1")
+
+	rejecting := &rejectingStub{backendStub: backendStub{up: true, accept: -1}, reject: "b1"}
+	repo = newSpool(t, rejecting, dir, 1<<20, 1<<20)
+	send(t, repo, "b2")
+
+	assert.Equal(t, []string{"b2"}, rejecting.ids())
+	assert.Empty(t, segments(t, dir))
+}
+
+type rejectingStub struct {
+	backendStub
+	reject string
+}
+
+func (r *rejectingStub) SendBatch(ctx context.Context, batch domain.StatusBatch) error {
+	if batch.LiveContainerIDs[0] == r.reject {
+		return repositories.ErrBatchRejected
+	}
+
+	return r.backendStub.SendBatch(ctx, batch)
+}